@@ -0,0 +1,180 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	"k8s.io/client-go/tools/clientcmd"
+	apiserverflag "k8s.io/component-base/cli/flag"
+	"k8s.io/component-base/logs"
+	"k8s.io/klog/v2"
+
+	corev1b1 "sigs.k8s.io/kubefed/pkg/apis/core/v1beta1"
+	"sigs.k8s.io/kubefed/pkg/apis/core/v1beta1/defaults"
+	genericclient "sigs.k8s.io/kubefed/pkg/client/generic"
+	"sigs.k8s.io/kubefed/pkg/controller/agent"
+	"sigs.k8s.io/kubefed/pkg/controller/util"
+	"sigs.k8s.io/kubefed/pkg/features"
+	"sigs.k8s.io/kubefed/pkg/version"
+)
+
+const defaultKubeFedNamespace = "kube-federation-system"
+
+var (
+	kubeconfig, masterURL, hostKubeconfig, clusterName, kubeFedNamespace string
+	heartbeatPeriod                                                      = 10 * time.Second
+)
+
+// NewAgentCommand creates a *cobra.Command object with default parameters
+func NewAgentCommand(stopChan <-chan struct{}) *cobra.Command {
+	verFlag := false
+
+	cmd := &cobra.Command{
+		Use:   "agent",
+		Short: "Start a kubefed agent in a pull-mode member cluster",
+		Long: `The KubeFed agent runs in a member cluster registered in pull mode.
+It watches the federated resources in the cluster hosting the KubeFed
+control plane, propagates those placed on the member cluster and
+reports their status and the health of the member cluster.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			fmt.Fprintf(os.Stdout, "KubeFed agent version: %#v\n", version.Get())
+			if verFlag {
+				os.Exit(0)
+			}
+
+			if err := Run(stopChan); err != nil {
+				fmt.Fprintf(os.Stderr, "%v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&kubeconfig, "kubeconfig", "", "Path to a kubeconfig for the member cluster. Only required if out-of-cluster.")
+	cmd.Flags().StringVar(&masterURL, "master", "", "The address of the Kubernetes API server of the member cluster. Overrides any value in kubeconfig. Only required if out-of-cluster.")
+	cmd.Flags().StringVar(&hostKubeconfig, "host-kubeconfig", "", "Path to a kubeconfig for the cluster hosting the KubeFed control plane.")
+	cmd.Flags().StringVar(&clusterName, "cluster-name", "", "The name of the KubeFedCluster representing the member cluster.")
+	cmd.Flags().StringVar(&kubeFedNamespace, "kubefed-namespace", defaultKubeFedNamespace, "The namespace the KubeFed control plane is deployed in.")
+	cmd.Flags().DurationVar(&heartbeatPeriod, "heartbeat-period", heartbeatPeriod, "The interval at which the health of the member cluster is reported.")
+	cmd.Flags().BoolVar(&verFlag, "version", false, "Prints the Version info of agent.")
+
+	// Add the command line flags from other dependencies(klog, kubebuilder, etc.).
+	// do not warn if they contain underscores.
+	local := &flag.FlagSet{}
+	klog.InitFlags(local)
+	cmd.Flags().AddGoFlagSet(local)
+	cmd.Flags().SetNormalizeFunc(apiserverflag.WordSepNormalizeFunc)
+
+	return cmd
+}
+
+// Run runs the agent. This should never exit.
+func Run(stopChan <-chan struct{}) error {
+	logs.InitLogs()
+	defer logs.FlushLogs()
+
+	if len(hostKubeconfig) == 0 {
+		return errors.New("The host cluster kubeconfig must be specified via --host-kubeconfig")
+	}
+	if len(clusterName) == 0 {
+		return errors.New("The name of the member cluster must be specified via --cluster-name")
+	}
+
+	clusterConfig, err := clientcmd.BuildConfigFromFlags(masterURL, kubeconfig)
+	if err != nil {
+		return errors.Wrap(err, "error setting up the member cluster config")
+	}
+	hostConfig, err := clientcmd.BuildConfigFromFlags("", hostKubeconfig)
+	if err != nil {
+		return errors.Wrap(err, "error setting up the host cluster config")
+	}
+
+	config := &agent.Config{
+		ControllerConfig: &util.ControllerConfig{
+			KubeFedNamespaces: util.KubeFedNamespaces{
+				KubeFedNamespace: kubeFedNamespace,
+			},
+			KubeConfig: hostConfig,
+		},
+		ClusterName:     clusterName,
+		ClusterConfig:   clusterConfig,
+		HeartbeatPeriod: heartbeatPeriod,
+	}
+	if err := setConfigByKubeFedConfig(config.ControllerConfig); err != nil {
+		return err
+	}
+
+	if err := agent.StartAgent(config, stopChan); err != nil {
+		return errors.Wrap(err, "error starting agent")
+	}
+
+	<-stopChan
+	return nil
+}
+
+// setConfigByKubeFedConfig configures the agent with the KubeFedConfig
+// of the control plane so that resources are propagated to the member
+// cluster in the same way as to push-mode clusters.
+func setConfigByKubeFedConfig(config *util.ControllerConfig) error {
+	fedConfig := &corev1b1.KubeFedConfig{}
+	client := genericclient.NewForConfigOrDieWithUserAgent(config.KubeConfig, "kubefedconfig")
+	qualifiedName := util.QualifiedName{
+		Namespace: config.KubeFedNamespace,
+		Name:      util.KubeFedConfigName,
+	}
+	err := client.Get(context.Background(), fedConfig, qualifiedName.Namespace, qualifiedName.Name)
+	if apierrors.IsNotFound(err) {
+		klog.Infof("Cannot retrieve KubeFedConfig %q: %v. Default options will be used.", qualifiedName, err)
+	} else if err != nil {
+		return errors.Wrapf(err, "error retrieving KubeFedConfig %q", qualifiedName)
+	}
+	defaults.SetDefaultKubeFedConfig(fedConfig)
+
+	spec := fedConfig.Spec
+	if spec.Scope == apiextv1.NamespaceScoped {
+		config.TargetNamespace = config.KubeFedNamespace
+		klog.Infof("KubeFed is limited to the %q namespace", config.KubeFedNamespace)
+	} else {
+		config.TargetNamespace = metav1.NamespaceAll
+	}
+
+	config.CacheSyncTimeout = spec.ControllerDuration.CacheSyncTimeout.Duration
+	config.MaxConcurrentSyncReconciles = *spec.SyncController.MaxConcurrentReconciles
+	config.ClusterOperationTimeout = spec.SyncController.ClusterOperationTimeout.Duration
+	config.SkipAdoptingResources = *spec.SyncController.AdoptResources == corev1b1.AdoptResourcesDisabled
+
+	featureGates := make(map[string]bool)
+	for _, v := range spec.FeatureGates {
+		featureGates[v.Name] = v.Configuration == corev1b1.ConfigurationEnabled
+	}
+	if err := utilfeature.DefaultMutableFeatureGate.SetFromMap(featureGates); err != nil {
+		return errors.Wrap(err, "invalid feature gates")
+	}
+	config.RawResourceStatusCollection = utilfeature.DefaultFeatureGate.Enabled(features.RawResourceStatusCollection)
+
+	return nil
+}
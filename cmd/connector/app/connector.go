@@ -0,0 +1,140 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package app
+
+import (
+	"flag"
+	"fmt"
+	"net"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	restclient "k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	apiserverflag "k8s.io/component-base/cli/flag"
+	"k8s.io/component-base/logs"
+	"k8s.io/klog/v2"
+
+	"sigs.k8s.io/kubefed/pkg/tunnel"
+	"sigs.k8s.io/kubefed/pkg/version"
+)
+
+var (
+	kubeconfig, masterURL, hostKubeconfig, clusterName, tunnelServer, tunnelCAFile string
+)
+
+// NewConnectorCommand creates a *cobra.Command object with default parameters
+func NewConnectorCommand(stopChan <-chan struct{}) *cobra.Command {
+	verFlag := false
+
+	cmd := &cobra.Command{
+		Use:   "connector",
+		Short: "Start a kubefed tunnel connector in a member cluster",
+		Long: `The KubeFed connector runs in a member cluster whose API server
+cannot be reached by the control plane. It opens a reverse tunnel to the
+tunnel server of the KubeFed controller manager, through which the
+control plane accesses the API server of the member cluster.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			fmt.Fprintf(os.Stdout, "KubeFed connector version: %#v\n", version.Get())
+			if verFlag {
+				os.Exit(0)
+			}
+
+			if err := Run(stopChan); err != nil {
+				fmt.Fprintf(os.Stderr, "%v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&kubeconfig, "kubeconfig", "", "Path to a kubeconfig for the member cluster. Only required if out-of-cluster.")
+	cmd.Flags().StringVar(&masterURL, "master", "", "The address of the Kubernetes API server of the member cluster. Overrides any value in kubeconfig. Only required if out-of-cluster.")
+	cmd.Flags().StringVar(&hostKubeconfig, "host-kubeconfig", "", "Path to a kubeconfig with a bearer token for the cluster hosting the KubeFed control plane.")
+	cmd.Flags().StringVar(&clusterName, "cluster-name", "", "The name of the KubeFedCluster representing the member cluster.")
+	cmd.Flags().StringVar(&tunnelServer, "tunnel-server", "", "The URL of the tunnel server of the KubeFed controller manager.")
+	cmd.Flags().StringVar(&tunnelCAFile, "tunnel-ca-file", "", "Path to the CA bundle used to verify the certificate of the tunnel server. Defaults to the CA of the host kubeconfig.")
+	cmd.Flags().BoolVar(&verFlag, "version", false, "Prints the Version info of connector.")
+
+	// Add the command line flags from other dependencies(klog, kubebuilder, etc.).
+	// do not warn if they contain underscores.
+	local := &flag.FlagSet{}
+	klog.InitFlags(local)
+	cmd.Flags().AddGoFlagSet(local)
+	cmd.Flags().SetNormalizeFunc(apiserverflag.WordSepNormalizeFunc)
+
+	return cmd
+}
+
+// Run runs the connector. This should never exit.
+func Run(stopChan <-chan struct{}) error {
+	logs.InitLogs()
+	defer logs.FlushLogs()
+
+	if len(hostKubeconfig) == 0 {
+		return errors.New("The host cluster kubeconfig must be specified via --host-kubeconfig")
+	}
+	if len(clusterName) == 0 {
+		return errors.New("The name of the member cluster must be specified via --cluster-name")
+	}
+	if len(tunnelServer) == 0 {
+		return errors.New("The URL of the tunnel server must be specified via --tunnel-server")
+	}
+
+	clusterConfig, err := clientcmd.BuildConfigFromFlags(masterURL, kubeconfig)
+	if err != nil {
+		return errors.Wrap(err, "error setting up the member cluster config")
+	}
+	apiServerAddress, err := apiServerAddress(clusterConfig)
+	if err != nil {
+		return err
+	}
+
+	tunnelConfig, err := clientcmd.BuildConfigFromFlags(tunnelServer, hostKubeconfig)
+	if err != nil {
+		return errors.Wrap(err, "error setting up the tunnel server config")
+	}
+	if len(tunnelCAFile) != 0 {
+		tunnelConfig.CAFile = tunnelCAFile
+		tunnelConfig.CAData = nil
+	}
+
+	connector, err := tunnel.NewConnector(clusterName, tunnelConfig, apiServerAddress)
+	if err != nil {
+		return errors.Wrap(err, "error setting up the connector")
+	}
+	klog.Infof("Forwarding connections through the tunnel of cluster %q to %s", clusterName, apiServerAddress)
+	connector.Run(stopChan)
+	return nil
+}
+
+// apiServerAddress returns the host and port of the API server of the
+// given configuration.
+func apiServerAddress(config *restclient.Config) (string, error) {
+	serverURL, _, err := restclient.DefaultServerUrlFor(config)
+	if err != nil {
+		return "", errors.Wrap(err, "error determining the address of the member cluster API server")
+	}
+	port := serverURL.Port()
+	if len(port) == 0 {
+		port = "443"
+		if serverURL.Scheme == "http" {
+			port = "80"
+		}
+	}
+	return net.JoinHostPort(serverURL.Hostname(), port), nil
+}
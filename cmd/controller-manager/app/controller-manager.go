@@ -35,6 +35,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/yaml"
 	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	kubeclientset "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/component-base/logs"
@@ -49,12 +50,15 @@ import (
 	"sigs.k8s.io/kubefed/pkg/apis/core/v1beta1/defaults"
 	"sigs.k8s.io/kubefed/pkg/apis/core/v1beta1/validation"
 	genericclient "sigs.k8s.io/kubefed/pkg/client/generic"
+	"sigs.k8s.io/kubefed/pkg/controller/clusterrbac"
+	"sigs.k8s.io/kubefed/pkg/controller/credentialrotation"
 	"sigs.k8s.io/kubefed/pkg/controller/federatedtypeconfig"
 	"sigs.k8s.io/kubefed/pkg/controller/kubefedcluster"
 	"sigs.k8s.io/kubefed/pkg/controller/schedulingmanager"
 	"sigs.k8s.io/kubefed/pkg/controller/util"
 	"sigs.k8s.io/kubefed/pkg/features"
 	kubefedmetrics "sigs.k8s.io/kubefed/pkg/metrics"
+	"sigs.k8s.io/kubefed/pkg/tunnel"
 	"sigs.k8s.io/kubefed/pkg/version"
 )
 
@@ -65,6 +69,7 @@ const (
 
 var (
 	kubeconfig, kubeFedConfig, masterURL, metricsAddr, healthzAddr string
+	tunnelAddr, tunnelCertFile, tunnelKeyFile                      string
 	restConfigQPS                                                  float32
 	restConfigBurst                                                int
 )
@@ -103,6 +108,9 @@ member clusters and do the necessary reconciliation`,
 	flags.StringVar(&masterURL, "master", "", "The address of the Kubernetes API server. Overrides any value in kubeconfig. Only required if out-of-cluster.")
 	flags.Float32Var(&restConfigQPS, "rest-config-qps", 100.0, "Maximum QPS to the api-server from this client.")
 	flags.IntVar(&restConfigBurst, "rest-config-burst", 200, "Maximum burst for throttle to the api-server from this client.")
+	flags.StringVar(&tunnelAddr, "tunnel-addr", "", "The address the tunnel server for member clusters with the Tunnel transport binds to. The tunnel server is disabled if empty.")
+	flags.StringVar(&tunnelCertFile, "tunnel-cert-file", "", "Path to the TLS certificate of the tunnel server.")
+	flags.StringVar(&tunnelKeyFile, "tunnel-key-file", "", "Path to the TLS key of the tunnel server.")
 
 	local := flag.NewFlagSet(os.Args[0], flag.ExitOnError)
 	klog.InitFlags(local)
@@ -163,7 +171,20 @@ func Run(opts *options.Options, stopChan <-chan struct{}) error {
 		klog.Info("KubeFed will target all namespaces")
 	}
 
-	elector, err := leaderelection.NewKubeFedLeaderElector(opts, startControllers)
+	fnStartControllers := startControllers
+	if len(tunnelAddr) != 0 {
+		// Tunnels are registered with the replica that accepted them,
+		// so only the leader accepts tunnels and connectors retry
+		// until they reach it.
+		tunnelServer := newTunnelServer(opts.Config)
+		go serveTunnels(tunnelServer)
+		fnStartControllers = func(opts *options.Options, stopChan <-chan struct{}) {
+			tunnelServer.SetLeading()
+			startControllers(opts, stopChan)
+		}
+	}
+
+	elector, err := leaderelection.NewKubeFedLeaderElector(opts, fnStartControllers)
 	if err != nil {
 		panic(err)
 	}
@@ -190,6 +211,16 @@ func startControllers(opts *options.Options, stopChan <-chan struct{}) {
 		klog.Fatalf("Error starting cluster controller: %v", err)
 	}
 
+	if err := clusterrbac.StartController(opts.Config, stopChan); err != nil {
+		klog.Fatalf("Error starting cluster RBAC controller: %v", err)
+	}
+
+	if utilfeature.DefaultFeatureGate.Enabled(features.CredentialRotation) {
+		if err := credentialrotation.StartController(opts.Config, stopChan); err != nil {
+			klog.Fatalf("Error starting credential rotation controller: %v", err)
+		}
+	}
+
 	if utilfeature.DefaultFeatureGate.Enabled(features.SchedulerPreferences) {
 		if _, err := schedulingmanager.StartSchedulingManager(opts.Config, stopChan); err != nil {
 			klog.Fatalf("Error starting scheduling manager: %v", err)
@@ -365,7 +396,13 @@ func setOptionsByKubeFedConfig(opts *options.Options) {
 	opts.ClusterHealthCheckConfig.SuccessThreshold = *spec.ClusterHealthCheck.SuccessThreshold
 
 	opts.Config.MaxConcurrentSyncReconciles = *spec.SyncController.MaxConcurrentReconciles
+	opts.Config.ClusterOperationTimeout = spec.SyncController.ClusterOperationTimeout.Duration
+	opts.Config.ClusterInitialBackoff = spec.SyncController.ClusterInitialBackoff.Duration
+	opts.Config.ClusterMaxBackoff = spec.SyncController.ClusterMaxBackoff.Duration
+	opts.Config.ClusterExecutor = util.NewClusterExecutor(int(*spec.SyncController.MaxConcurrentClusterOperations),
+		int(*spec.SyncController.MaxConcurrentOperationsPerCluster))
 	opts.Config.MaxConcurrentStatusReconciles = *spec.StatusController.MaxConcurrentReconciles
+	opts.Config.CredentialRotationPeriod = spec.CredentialRotation.Period.Duration
 
 	opts.Config.SkipAdoptingResources = *spec.SyncController.AdoptResources == corev1b1.AdoptResourcesDisabled
 
@@ -388,6 +425,16 @@ func PrintFlags(flags *pflag.FlagSet) {
 	})
 }
 
+func newTunnelServer(config *util.ControllerConfig) *tunnel.Server {
+	kubeClient := kubeclientset.NewForConfigOrDie(rest.AddUserAgent(rest.CopyConfig(config.KubeConfig), "kubefed-tunnel-server"))
+	return tunnel.NewServer(kubeClient, config.KubeFedNamespace)
+}
+
+func serveTunnels(server *tunnel.Server) {
+	klog.Infof("Accepting tunnels of member clusters on %s", tunnelAddr)
+	klog.Fatal(server.ListenAndServeTLS(tunnelAddr, tunnelCertFile, tunnelKeyFile))
+}
+
 func serveHealthz(address string) {
 	http.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
 		w.WriteHeader(http.StatusOK)
@@ -36,6 +36,8 @@ import (
 	"k8s.io/component-base/logs"
 	"k8s.io/klog/v2"
 
+	agentapp "sigs.k8s.io/kubefed/cmd/agent/app"
+	connectorapp "sigs.k8s.io/kubefed/cmd/connector/app"
 	ctrlapp "sigs.k8s.io/kubefed/cmd/controller-manager/app"
 	webhookapp "sigs.k8s.io/kubefed/cmd/webhook/app"
 	"sigs.k8s.io/kubefed/pkg/kubefedctl"
@@ -83,11 +85,15 @@ func NewHyperFedCommand() (*cobra.Command, []func() *cobra.Command) {
 	controller := func() *cobra.Command { return ctrlapp.NewControllerManagerCommand(stopChan) }
 	kubefedctlCmd := func() *cobra.Command { return kubefedctl.NewKubeFedCtlCommand(os.Stdout) }
 	webhookCmd := func() *cobra.Command { return webhookapp.NewWebhookCommand(stopChan) }
+	agentCmd := func() *cobra.Command { return agentapp.NewAgentCommand(stopChan) }
+	connectorCmd := func() *cobra.Command { return connectorapp.NewConnectorCommand(stopChan) }
 
 	commandFns := []func() *cobra.Command{
 		controller,
 		kubefedctlCmd,
 		webhookCmd,
+		agentCmd,
+		connectorCmd,
 	}
 
 	makeSymlinksFlag := false
@@ -26,6 +26,15 @@ const (
 	ClusterOffline ClusterConditionType = "Offline"
 	// ClusterConfigMalformed means the cluster's configuration may be malformed.
 	ClusterConfigMalformed ClusterConditionType = "ConfigMalformed"
+	// ClusterCredentialsRotated means the credentials used to access the
+	// cluster were last rotated successfully.
+	ClusterCredentialsRotated ClusterConditionType = "CredentialsRotated"
+	// ClusterTunnelConnected means a connector in the cluster has opened
+	// the reverse tunnel the control plane uses to access the cluster.
+	ClusterTunnelConnected ClusterConditionType = "TunnelConnected"
+	// ClusterRBACUpToDate means the least-privilege role granted to the
+	// control plane in the cluster covers the enabled types.
+	ClusterRBACUpToDate ClusterConditionType = "RBACUpToDate"
 )
 
 const (
@@ -0,0 +1,109 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// FieldPathWildcard is the path segment that selects every element of a
+// list.
+const FieldPathWildcard = "*"
+
+// ParseFieldPath parses a field path expressed either as a JSON pointer
+// (e.g. /spec/ports/*/nodePort) or as a simple JSONPath expression (e.g.
+// .spec.ports[*].nodePort or {.spec.ports[*].nodePort}) into its
+// segments. Filters and other JSONPath operators are not supported.
+func ParseFieldPath(path string) ([]string, error) {
+	path = strings.TrimSpace(path)
+	if len(path) == 0 {
+		return nil, errors.New("path must not be empty")
+	}
+	var segments []string
+	var err error
+	if strings.HasPrefix(path, "/") {
+		segments, err = parseJSONPointer(path)
+	} else {
+		segments, err = parseJSONPath(path)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid path %q", path)
+	}
+	if len(segments) == 0 {
+		return nil, errors.Errorf("invalid path %q: path must select a field", path)
+	}
+	return segments, nil
+}
+
+func parseJSONPointer(path string) ([]string, error) {
+	var segments []string
+	for _, token := range strings.Split(path[1:], "/") {
+		if len(token) == 0 {
+			return nil, errors.New("empty path segment")
+		}
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+		segments = append(segments, token)
+	}
+	return segments, nil
+}
+
+func parseJSONPath(path string) ([]string, error) {
+	if strings.HasPrefix(path, "{") {
+		if !strings.HasSuffix(path, "}") {
+			return nil, errors.New("unterminated '{'")
+		}
+		path = strings.TrimSpace(path[1 : len(path)-1])
+	}
+	path = strings.TrimPrefix(path, "$")
+
+	var segments []string
+	for len(path) > 0 {
+		switch path[0] {
+		case '.':
+			path = path[1:]
+			end := strings.IndexAny(path, ".[")
+			if end == -1 {
+				end = len(path)
+			}
+			if end == 0 {
+				return nil, errors.New("empty path segment")
+			}
+			segments = append(segments, path[:end])
+			path = path[end:]
+		case '[':
+			end := strings.Index(path, "]")
+			if end == -1 {
+				return nil, errors.New("unterminated '['")
+			}
+			segment := strings.Trim(path[1:end], `'"`)
+			if len(segment) == 0 {
+				return nil, errors.New("empty path segment")
+			}
+			segments = append(segments, segment)
+			path = path[end+1:]
+		default:
+			// Tolerate a missing leading '.' (e.g. spec.clusterIP).
+			if len(segments) > 0 {
+				return nil, errors.Errorf("unexpected character %q", path[0])
+			}
+			path = "." + path
+		}
+	}
+	return segments, nil
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFieldPath(t *testing.T) {
+	testCases := map[string]struct {
+		path             string
+		expectedSegments []string
+		expectedErr      bool
+	}{
+		"JSON pointer": {
+			path:             "/spec/ports/*/nodePort",
+			expectedSegments: []string{"spec", "ports", "*", "nodePort"},
+		},
+		"JSON pointer with escaped characters": {
+			path:             "/metadata/labels/example.com~1foo~0bar",
+			expectedSegments: []string{"metadata", "labels", "example.com/foo~bar"},
+		},
+		"JSONPath": {
+			path:             ".spec.ports[*].nodePort",
+			expectedSegments: []string{"spec", "ports", "*", "nodePort"},
+		},
+		"JSONPath template with quoted key": {
+			path:             "{$.metadata.labels['example.com/foo']}",
+			expectedSegments: []string{"metadata", "labels", "example.com/foo"},
+		},
+		"JSONPath without leading dot": {
+			path:             "spec.clusterIP",
+			expectedSegments: []string{"spec", "clusterIP"},
+		},
+		"Empty path": {
+			path:        "",
+			expectedErr: true,
+		},
+		"Root JSON pointer": {
+			path:        "/",
+			expectedErr: true,
+		},
+		"Empty JSONPath segment": {
+			path:        ".spec..clusterIP",
+			expectedErr: true,
+		},
+		"Unterminated bracket": {
+			path:        ".spec.ports[*",
+			expectedErr: true,
+		},
+	}
+
+	for testName, tc := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			segments, err := ParseFieldPath(tc.path)
+			if tc.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedSegments, segments)
+		})
+	}
+}
@@ -18,6 +18,8 @@ package typeconfig
 
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/kubefed/pkg/apis/core/v1beta1"
 )
 
 // Interface defines how to interact with a FederatedTypeConfig
@@ -29,6 +31,8 @@ type Interface interface {
 	GetFederatedType() metav1.APIResource
 	GetStatusType() *metav1.APIResource
 	GetStatusEnabled() bool
+	GetServerSideApplyEnabled() bool
+	GetRetainFields() []v1beta1.RetainField
 	GetFederatedNamespaced() bool
 	IsNamespace() bool
 }
@@ -41,8 +41,15 @@ const (
 	DefaultClusterHealthCheckSuccessThreshold = 1
 	DefaultClusterHealthCheckTimeout          = 3 * time.Second
 
-	DefaultSyncControllerMaxConcurrentReconciles   = 1
-	DefaultStatusControllerMaxConcurrentReconciles = 1
+	DefaultSyncControllerMaxConcurrentReconciles           = 1
+	DefaultSyncControllerClusterOperationTimeout           = 30 * time.Second
+	DefaultSyncControllerClusterInitialBackoff             = 5 * time.Second
+	DefaultSyncControllerClusterMaxBackoff                 = 5 * time.Minute
+	DefaultSyncControllerMaxConcurrentClusterOperations    = 100
+	DefaultSyncControllerMaxConcurrentOperationsPerCluster = 10
+	DefaultStatusControllerMaxConcurrentReconciles         = 1
+
+	DefaultCredentialRotationPeriod = 24 * time.Hour
 )
 
 func SetDefaultKubeFedConfig(fedConfig *v1beta1.KubeFedConfig) {
@@ -93,6 +100,11 @@ func SetDefaultKubeFedConfig(fedConfig *v1beta1.KubeFedConfig) {
 	}
 
 	setInt64(&spec.SyncController.MaxConcurrentReconciles, DefaultSyncControllerMaxConcurrentReconciles)
+	setDuration(&spec.SyncController.ClusterOperationTimeout, DefaultSyncControllerClusterOperationTimeout)
+	setDuration(&spec.SyncController.ClusterInitialBackoff, DefaultSyncControllerClusterInitialBackoff)
+	setDuration(&spec.SyncController.ClusterMaxBackoff, DefaultSyncControllerClusterMaxBackoff)
+	setInt64(&spec.SyncController.MaxConcurrentClusterOperations, DefaultSyncControllerMaxConcurrentClusterOperations)
+	setInt64(&spec.SyncController.MaxConcurrentOperationsPerCluster, DefaultSyncControllerMaxConcurrentOperationsPerCluster)
 
 	if spec.SyncController.AdoptResources == nil {
 		spec.SyncController.AdoptResources = new(v1beta1.ResourceAdoption)
@@ -104,6 +116,12 @@ func SetDefaultKubeFedConfig(fedConfig *v1beta1.KubeFedConfig) {
 	}
 
 	setInt64(&spec.StatusController.MaxConcurrentReconciles, DefaultStatusControllerMaxConcurrentReconciles)
+
+	if spec.CredentialRotation == nil {
+		spec.CredentialRotation = &v1beta1.CredentialRotationConfig{}
+	}
+
+	setDuration(&spec.CredentialRotation.Period, DefaultCredentialRotationPeriod)
 }
 
 func setDefaultKubeFedFeatureGates(fgc []v1beta1.FeatureGatesConfig) []v1beta1.FeatureGatesConfig {
@@ -139,6 +139,38 @@ func TestSetDefaultKubeFedConfig(t *testing.T) {
 	SetDefaultKubeFedConfig(modifiedAdoptResourcesKFC)
 	successCases["spec.syncController.adoptResources is preserved"] = KubeFedConfigComparison{adoptResourcesKFC, modifiedAdoptResourcesKFC}
 
+	clusterOperationTimeoutKFC := defaultKubeFedConfig()
+	clusterOperationTimeoutKFC.Spec.SyncController.ClusterOperationTimeout.Duration = DefaultSyncControllerClusterOperationTimeout + 31*time.Second
+	modifiedClusterOperationTimeoutKFC := clusterOperationTimeoutKFC.DeepCopyObject().(*v1beta1.KubeFedConfig)
+	SetDefaultKubeFedConfig(modifiedClusterOperationTimeoutKFC)
+	successCases["spec.syncController.clusterOperationTimeout is preserved"] = KubeFedConfigComparison{clusterOperationTimeoutKFC, modifiedClusterOperationTimeoutKFC}
+
+	clusterInitialBackoffKFC := defaultKubeFedConfig()
+	clusterInitialBackoffKFC.Spec.SyncController.ClusterInitialBackoff.Duration = DefaultSyncControllerClusterInitialBackoff + 31*time.Second
+	modifiedClusterInitialBackoffKFC := clusterInitialBackoffKFC.DeepCopyObject().(*v1beta1.KubeFedConfig)
+	SetDefaultKubeFedConfig(modifiedClusterInitialBackoffKFC)
+	successCases["spec.syncController.clusterInitialBackoff is preserved"] = KubeFedConfigComparison{clusterInitialBackoffKFC, modifiedClusterInitialBackoffKFC}
+
+	clusterMaxBackoffKFC := defaultKubeFedConfig()
+	clusterMaxBackoffKFC.Spec.SyncController.ClusterMaxBackoff.Duration = DefaultSyncControllerClusterMaxBackoff + 31*time.Second
+	modifiedClusterMaxBackoffKFC := clusterMaxBackoffKFC.DeepCopyObject().(*v1beta1.KubeFedConfig)
+	SetDefaultKubeFedConfig(modifiedClusterMaxBackoffKFC)
+	successCases["spec.syncController.clusterMaxBackoff is preserved"] = KubeFedConfigComparison{clusterMaxBackoffKFC, modifiedClusterMaxBackoffKFC}
+
+	maxConcurrentClusterOperationsKFC := defaultKubeFedConfig()
+	maxConcurrentClusterOperations := int64(DefaultSyncControllerMaxConcurrentClusterOperations + 3)
+	maxConcurrentClusterOperationsKFC.Spec.SyncController.MaxConcurrentClusterOperations = &maxConcurrentClusterOperations
+	modifiedMaxConcurrentClusterOperationsKFC := maxConcurrentClusterOperationsKFC.DeepCopyObject().(*v1beta1.KubeFedConfig)
+	SetDefaultKubeFedConfig(modifiedMaxConcurrentClusterOperationsKFC)
+	successCases["spec.syncController.maxConcurrentClusterOperations is preserved"] = KubeFedConfigComparison{maxConcurrentClusterOperationsKFC, modifiedMaxConcurrentClusterOperationsKFC}
+
+	maxConcurrentOperationsPerClusterKFC := defaultKubeFedConfig()
+	maxConcurrentOperationsPerCluster := int64(DefaultSyncControllerMaxConcurrentOperationsPerCluster + 3)
+	maxConcurrentOperationsPerClusterKFC.Spec.SyncController.MaxConcurrentOperationsPerCluster = &maxConcurrentOperationsPerCluster
+	modifiedMaxConcurrentOperationsPerClusterKFC := maxConcurrentOperationsPerClusterKFC.DeepCopyObject().(*v1beta1.KubeFedConfig)
+	SetDefaultKubeFedConfig(modifiedMaxConcurrentOperationsPerClusterKFC)
+	successCases["spec.syncController.maxConcurrentOperationsPerCluster is preserved"] = KubeFedConfigComparison{maxConcurrentOperationsPerClusterKFC, modifiedMaxConcurrentOperationsPerClusterKFC}
+
 	// StatusController
 	statusControllerMaxConcurrentReconcilesKFC := defaultKubeFedConfig()
 	statusControllerMaxConcurrentReconciles := int64(DefaultStatusControllerMaxConcurrentReconciles + 3)
@@ -147,6 +179,13 @@ func TestSetDefaultKubeFedConfig(t *testing.T) {
 	SetDefaultKubeFedConfig(modifiedStatusControllerMaxConcurrentReconcilesKFC)
 	successCases["spec.statusController.maxConcurrentReconciles is preserved"] = KubeFedConfigComparison{statusControllerMaxConcurrentReconcilesKFC, modifiedStatusControllerMaxConcurrentReconcilesKFC}
 
+	// CredentialRotation
+	credentialRotationPeriodKFC := defaultKubeFedConfig()
+	credentialRotationPeriodKFC.Spec.CredentialRotation.Period.Duration = DefaultCredentialRotationPeriod + 31*time.Second
+	modifiedCredentialRotationPeriodKFC := credentialRotationPeriodKFC.DeepCopyObject().(*v1beta1.KubeFedConfig)
+	SetDefaultKubeFedConfig(modifiedCredentialRotationPeriodKFC)
+	successCases["spec.credentialRotation.period is preserved"] = KubeFedConfigComparison{credentialRotationPeriodKFC, modifiedCredentialRotationPeriodKFC}
+
 	for k, v := range successCases {
 		if !reflect.DeepEqual(v.original, v.modified) {
 			t.Errorf("[%s] expected success: original=%+v, modified=%+v", k, *v.original, *v.modified)
@@ -47,6 +47,40 @@ type FederatedTypeConfigSpec struct {
 	// Whether or not Status object should be populated.
 	// +optional
 	StatusCollection *StatusCollectionMode `json:"statusCollection,omitempty"`
+	// How resources should be written to member clusters. Update (the
+	// default) replaces the resource after retaining the fields managed
+	// in the member cluster. ServerSideApply uses server-side apply so
+	// that KubeFed only owns the fields set by the template and
+	// overrides.
+	// +optional
+	PropagationStrategy *PropagationStrategy `json:"propagationStrategy,omitempty"`
+	// Fields of the target type whose values in member clusters should
+	// be retained when resources are updated by the Update propagation
+	// strategy. These rules are applied in addition to the built-in
+	// rules for the target type (e.g. spec.clusterIP for services).
+	// +optional
+	RetainFields []RetainField `json:"retainFields,omitempty"`
+}
+
+// RetainField identifies a field of a resource in a member cluster whose
+// value should be retained when the resource is updated.
+type RetainField struct {
+	// Path of the field, either as a JSON pointer (e.g.
+	// /spec/clusterIP) or as a simple JSONPath expression (e.g.
+	// .spec.clusterIP or {.spec.clusterIP}). A segment of '*' (or
+	// '[*]' in JSONPath) selects every element of a list.
+	Path string `json:"path"`
+	// Whether the value should only be retained if the desired
+	// resource does not set the field. By default the value in the
+	// member cluster is retained even if the field is set by the
+	// template or overrides.
+	// +optional
+	RetainOnlyIfDesiredEmpty bool `json:"retainOnlyIfDesiredEmpty,omitempty"`
+	// Fields used to match the elements of a list selected with '*'
+	// in the desired resource with those in the member cluster. If not
+	// provided, elements are matched by index.
+	// +optional
+	ListKeys []string `json:"listKeys,omitempty"`
 }
 
 // APIResource defines how to configure the dynamic client for an API resource.
@@ -77,6 +111,14 @@ const (
 	PropagationDisabled PropagationMode = "Disabled"
 )
 
+// PropagationStrategy defines how resources are written to member clusters.
+type PropagationStrategy string
+
+const (
+	PropagationStrategyUpdate          PropagationStrategy = "Update"
+	PropagationStrategyServerSideApply PropagationStrategy = "ServerSideApply"
+)
+
 // StatusCollectionMode defines the state of status collection.
 type StatusCollectionMode string
 
@@ -223,6 +265,15 @@ func (f *FederatedTypeConfig) GetStatusEnabled() bool {
 		*f.Spec.StatusCollection == StatusCollectionEnabled
 }
 
+func (f *FederatedTypeConfig) GetServerSideApplyEnabled() bool {
+	return f.Spec.PropagationStrategy != nil &&
+		*f.Spec.PropagationStrategy == PropagationStrategyServerSideApply
+}
+
+func (f *FederatedTypeConfig) GetRetainFields() []RetainField {
+	return f.Spec.RetainFields
+}
+
 // TODO(font): This method should be removed from the interface i.e. remove
 // special-case handling for namespaces, in favor of checking the namespaced
 // property of the appropriate APIResource (TargetType, FederatedType)
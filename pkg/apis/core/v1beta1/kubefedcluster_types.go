@@ -41,10 +41,28 @@ type KubeFedClusterSpec struct {
 	// +optional
 	CABundle []byte `json:"caBundle,omitempty"`
 
-	// Name of the secret containing the token required to access the
-	// member cluster. The secret needs to exist in the same namespace
-	// as the control plane and should have a "token" key.
-	SecretRef LocalSecretReference `json:"secretRef"`
+	// CABundleSecretRef is the name of a secret containing the
+	// certificate authority information under the "ca.crt" key. The
+	// secret needs to exist in the same namespace as the control
+	// plane. It may not be set together with CABundle.
+	// +optional
+	CABundleSecretRef *LocalSecretReference `json:"caBundleSecretRef,omitempty"`
+
+	// Name of the secret containing the credentials required to access
+	// the member cluster. The secret needs to exist in the same
+	// namespace as the control plane and should have a "token" key
+	// containing a bearer token, or "tls.crt" and "tls.key" keys
+	// containing a client certificate and key. Required unless Exec is
+	// set or Mode is Pull.
+	// +optional
+	SecretRef LocalSecretReference `json:"secretRef,omitempty"`
+
+	// Exec configures a command that is run by the control plane to
+	// obtain credentials for the member cluster, e.g. from the IAM
+	// service of a cloud provider. The command must be available to
+	// the controller manager.
+	// +optional
+	Exec *ExecConfig `json:"exec,omitempty"`
 
 	// DisabledTLSValidations defines a list of checks to ignore when validating
 	// the TLS connection to the member cluster.  This can be any of *, SubjectName, or ValidityPeriod.
@@ -55,6 +73,121 @@ type KubeFedClusterSpec struct {
 	// ProxyURL allows to set proxy URL for the cluster.
 	// +optional
 	ProxyURL string `json:"proxyURL"`
+
+	// ClientConfig tunes the clients used by KubeFed controllers to
+	// access the member cluster. Defaults are used for fields that are
+	// not set.
+	// +optional
+	ClientConfig *ClusterClientConfig `json:"clientConfig,omitempty"`
+
+	// Mode determines how resources are propagated to the member
+	// cluster. In Push mode, the default, the control plane accesses
+	// the API server of the member cluster. In Pull mode, an agent
+	// running in the member cluster retrieves the resources placed on
+	// it from the control plane and reports their status, and the
+	// control plane does not use APIEndpoint or credentials to access
+	// the member cluster.
+	// +optional
+	Mode ClusterMode `json:"mode,omitempty"`
+
+	// Transport determines how the control plane connects to the API
+	// server of the member cluster. With Direct, the default, the
+	// control plane dials APIEndpoint, or ProxyURL if set. With Tunnel,
+	// the control plane dials the API server through a reverse tunnel
+	// opened to the control plane by a connector running in the member
+	// cluster. APIEndpoint is still used to verify the certificate of
+	// the API server, and ProxyURL may not be set.
+	// +optional
+	Transport ClusterTransport `json:"transport,omitempty"`
+
+	// Taints prevent the placement of federated resources on the
+	// member cluster unless they tolerate the taints. A NoSchedule
+	// taint prevents new placement, and a NoExecute taint also removes
+	// the federated resources already propagated to the cluster.
+	// +optional
+	Taints []apiv1.Taint `json:"taints,omitempty"`
+}
+
+type ClusterMode string
+
+const (
+	ClusterModePush ClusterMode = "Push"
+	ClusterModePull ClusterMode = "Pull"
+)
+
+type ClusterTransport string
+
+const (
+	ClusterTransportDirect ClusterTransport = "Direct"
+	ClusterTransportTunnel ClusterTransport = "Tunnel"
+)
+
+// ClusterClientConfig defines how clients of a member cluster are
+// configured.
+type ClusterClientConfig struct {
+	// QPS is the maximum sustained rate of requests per second sent to
+	// the API server of the member cluster by each client. Defaults to 20.
+	// +optional
+	QPS *int32 `json:"qps,omitempty"`
+
+	// Burst is the maximum number of requests that a client may send to
+	// the API server of the member cluster in a burst above QPS.
+	// Defaults to 30.
+	// +optional
+	Burst *int32 `json:"burst,omitempty"`
+
+	// RequestTimeout is the time to wait for a response to a request
+	// before giving up. Watches are not subject to the timeout. Requests
+	// do not time out if not set.
+	// +optional
+	RequestTimeout *metav1.Duration `json:"requestTimeout,omitempty"`
+
+	// ContentType is the content type preferred for requests to the
+	// member cluster. Protobuf only applies to built-in types; custom
+	// resources are always sent as JSON. Defaults to the client default.
+	// +optional
+	ContentType ClientContentType `json:"contentType,omitempty"`
+}
+
+type ClientContentType string
+
+const (
+	ClientContentTypeJSON     ClientContentType = "application/json"
+	ClientContentTypeProtobuf ClientContentType = "application/vnd.kubernetes.protobuf"
+)
+
+// ExecConfig specifies a command that provides credentials in the
+// format of the client.authentication.k8s.io API group.
+type ExecConfig struct {
+	// Command to execute.
+	Command string `json:"command"`
+
+	// Arguments to pass to the command when executing it.
+	// +optional
+	Args []string `json:"args,omitempty"`
+
+	// Env defines additional environment variables to expose to the
+	// command.
+	// +optional
+	Env []ExecEnvVar `json:"env,omitempty"`
+
+	// APIVersion of the ExecCredential returned by the command, one of
+	// client.authentication.k8s.io/v1 or
+	// client.authentication.k8s.io/v1beta1.
+	APIVersion string `json:"apiVersion"`
+
+	// ProvideClusterInfo determines whether information about the
+	// member cluster is passed to the command in the
+	// KUBERNETES_EXEC_INFO environment variable.
+	// +optional
+	ProvideClusterInfo bool `json:"provideClusterInfo,omitempty"`
+}
+
+// ExecEnvVar is an environment variable used by an exec credential
+// command.
+type ExecEnvVar struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
 }
 
 // LocalSecretReference is a reference to a secret within the enclosing
@@ -79,6 +212,24 @@ type KubeFedClusterStatus struct {
 	// Region is the name of the region in which all of the nodes in the cluster exist.  e.g. 'us-east1'.
 	// +optional
 	Region *string `json:"region,omitempty"`
+	// ResourceSummary summarizes the compute resources of the cluster.
+	// +optional
+	ResourceSummary *ClusterResourceSummary `json:"resourceSummary,omitempty"`
+}
+
+// ClusterResourceSummary summarizes the CPU and memory of the ready,
+// schedulable nodes of a cluster.
+type ClusterResourceSummary struct {
+	// Nodes is the number of ready, schedulable nodes.
+	Nodes int32 `json:"nodes"`
+	// Allocatable is the total of the resources of the nodes that can
+	// be allocated to pods.
+	// +optional
+	Allocatable apiv1.ResourceList `json:"allocatable,omitempty"`
+	// Requested is the total of the resource requests of the pods that
+	// are not terminated on the nodes.
+	// +optional
+	Requested apiv1.ResourceList `json:"requested,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -102,7 +253,8 @@ type KubeFedCluster struct {
 
 // ClusterCondition describes current state of a cluster.
 type ClusterCondition struct {
-	// Type of cluster condition, Ready or Offline.
+	// Type of cluster condition, Ready, Offline, CredentialsRotated,
+	// TunnelConnected or RBACUpToDate.
 	Type common.ClusterConditionType `json:"type"`
 	// Status of the condition, one of True, False, Unknown.
 	Status apiv1.ConditionStatus `json:"status"`
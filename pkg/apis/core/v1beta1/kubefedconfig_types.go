@@ -39,6 +39,14 @@ type KubeFedConfigSpec struct {
 	SyncController *SyncControllerConfig `json:"syncController,omitempty"`
 	// +optional
 	StatusController *StatusControllerConfig `json:"statusController,omitempty"`
+	// +optional
+	CredentialRotation *CredentialRotationConfig `json:"credentialRotation,omitempty"`
+	// Absolute paths of the commands that KubeFedClusters may configure
+	// to obtain credentials for their member clusters. The commands are
+	// run by the controller manager, so a KubeFedCluster whose command
+	// is not listed is rejected. Exec credentials are disabled if empty.
+	// +optional
+	ExecCredentialCommands []string `json:"execCredentialCommands,omitempty"`
 }
 
 type DurationConfig struct {
@@ -118,6 +126,28 @@ type SyncControllerConfig struct {
 	// "Enabled".
 	// +optional
 	AdoptResources *ResourceAdoption `json:"adoptResources,omitempty"`
+	// Time allowed for an operation on a resource in a member cluster to
+	// complete. Defaults to 30s.
+	// +optional
+	ClusterOperationTimeout *metav1.Duration `json:"clusterOperationTimeout,omitempty"`
+	// Initial delay before retrying a failed operation on a resource in
+	// a member cluster. The delay doubles for each consecutive failure
+	// of operations on the resource in that cluster. Defaults to 5s.
+	// +optional
+	ClusterInitialBackoff *metav1.Duration `json:"clusterInitialBackoff,omitempty"`
+	// Maximum delay before retrying a failed operation on a resource in
+	// a member cluster. Defaults to 5m.
+	// +optional
+	ClusterMaxBackoff *metav1.Duration `json:"clusterMaxBackoff,omitempty"`
+	// The maximum number of operations on resources in member clusters
+	// that can run concurrently across all clusters. Further operations
+	// are queued. Defaults to 100.
+	// +optional
+	MaxConcurrentClusterOperations *int64 `json:"maxConcurrentClusterOperations,omitempty"`
+	// The maximum number of operations on resources in a single member
+	// cluster that can run concurrently. Defaults to 10.
+	// +optional
+	MaxConcurrentOperationsPerCluster *int64 `json:"maxConcurrentOperationsPerCluster,omitempty"`
 }
 
 type ResourceAdoption string
@@ -134,6 +164,14 @@ type StatusControllerConfig struct {
 	MaxConcurrentReconciles *int64 `json:"maxConcurrentReconciles,omitempty"`
 }
 
+type CredentialRotationConfig struct {
+	// How often the service account tokens used to access member
+	// clusters are rotated when the CredentialRotation feature is
+	// enabled. Defaults to 24h.
+	// +optional
+	Period *metav1.Duration `json:"period,omitempty"`
+}
+
 // +kubebuilder:object:root=true
 // +kubebuilder:resource:path=kubefedconfigs
 
@@ -17,8 +17,10 @@ limitations under the License.
 package validation
 
 import (
+	"crypto/tls"
 	"fmt"
 	"net/url"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -32,6 +34,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/leaderelection"
+	certutil "k8s.io/client-go/util/cert"
 
 	"sigs.k8s.io/kubefed/pkg/apis/core/common"
 	"sigs.k8s.io/kubefed/pkg/apis/core/typeconfig"
@@ -72,6 +75,32 @@ func ValidateFederatedTypeConfigSpec(spec *v1beta1.FederatedTypeConfigSpec, fldP
 		allErrs = append(allErrs, validateEnumStrings(fldPath.Child("statusCollection"), string(*spec.StatusCollection), []string{string(v1beta1.StatusCollectionEnabled), string(v1beta1.StatusCollectionDisabled)})...)
 	}
 
+	if spec.PropagationStrategy != nil {
+		allErrs = append(allErrs, validateEnumStrings(fldPath.Child("propagationStrategy"), string(*spec.PropagationStrategy), []string{string(v1beta1.PropagationStrategyUpdate), string(v1beta1.PropagationStrategyServerSideApply)})...)
+	}
+
+	for i := range spec.RetainFields {
+		allErrs = append(allErrs, ValidateRetainField(&spec.RetainFields[i], fldPath.Child("retainFields").Index(i))...)
+	}
+
+	return allErrs
+}
+
+func ValidateRetainField(retainField *v1beta1.RetainField, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if len(retainField.Path) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("path"), ""))
+	} else if _, err := common.ParseFieldPath(retainField.Path); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("path"), retainField.Path, err.Error()))
+	}
+
+	for i, key := range retainField.ListKeys {
+		if len(key) == 0 {
+			allErrs = append(allErrs, field.Required(fldPath.Child("listKeys").Index(i), ""))
+		}
+	}
+
 	return allErrs
 }
 
@@ -150,23 +179,140 @@ func ValidateFederatedTypeConfigStatus(status *v1beta1.FederatedTypeConfigStatus
 	return allErrs
 }
 
-func ValidateKubeFedCluster(obj *v1beta1.KubeFedCluster, statusSubResource bool) field.ErrorList {
+// ValidateKubeFedCluster validates the given KubeFedCluster. Credentials
+// may only be obtained by running one of the allowed exec commands,
+// which are configured by the KubeFedConfig of the control plane.
+func ValidateKubeFedCluster(obj *v1beta1.KubeFedCluster, statusSubResource bool, allowedExecCommands []string) field.ErrorList {
 	var allErrs field.ErrorList
 	if !statusSubResource {
-		allErrs = validateKubeFedClusterSpec(&obj.Spec, field.NewPath("spec"))
+		allErrs = validateKubeFedClusterSpec(&obj.Spec, field.NewPath("spec"), allowedExecCommands)
 	} else {
 		allErrs = validateKubeFedClusterStatus(&obj.Status, field.NewPath("status"))
 	}
 	return allErrs
 }
 
-func validateKubeFedClusterSpec(spec *v1beta1.KubeFedClusterSpec, path *field.Path) field.ErrorList {
+func validateKubeFedClusterSpec(spec *v1beta1.KubeFedClusterSpec, path *field.Path, allowedExecCommands []string) field.ErrorList {
 	allErrs := validateAPIEndpoint(spec.APIEndpoint, path.Child("apiEndpoint"))
-	allErrs = append(allErrs, validateLocalSecretReference(&spec.SecretRef, path.Child("secretRef"))...)
+	// The credentials of a pull-mode cluster are not used.
+	pullMode := spec.Mode == v1beta1.ClusterModePull
+	if (spec.Exec == nil && !pullMode) || spec.SecretRef.Name != "" {
+		allErrs = append(allErrs, validateLocalSecretReference(&spec.SecretRef, path.Child("secretRef"))...)
+	}
+	if spec.CABundleSecretRef != nil {
+		if len(spec.CABundle) != 0 {
+			allErrs = append(allErrs, field.Forbidden(path.Child("caBundleSecretRef"), "may not be set together with caBundle"))
+		}
+		allErrs = append(allErrs, validateLocalSecretReference(spec.CABundleSecretRef, path.Child("caBundleSecretRef"))...)
+	}
+	if spec.Exec != nil {
+		allErrs = append(allErrs, validateExecConfig(spec.Exec, path.Child("exec"), allowedExecCommands)...)
+		// Credentials obtained by a command cannot be combined with the
+		// custom transport used to disable TLS validations.
+		if len(spec.DisabledTLSValidations) != 0 {
+			allErrs = append(allErrs, field.Forbidden(path.Child("exec"), "may not be set together with disabledTLSValidations"))
+		}
+	}
 	allErrs = append(allErrs, validateDisabledTLSValidations(spec.DisabledTLSValidations, path.Child("disabledTLSValidations"))...)
 	if spec.ProxyURL != "" {
 		allErrs = append(allErrs, validateProxyURL(spec.ProxyURL, path.Child("proxyURL"))...)
 	}
+	if spec.ClientConfig != nil {
+		allErrs = append(allErrs, validateClusterClientConfig(spec.ClientConfig, path.Child("clientConfig"))...)
+	}
+	if spec.Mode != "" {
+		allErrs = append(allErrs, validateEnumStrings(path.Child("mode"), string(spec.Mode),
+			[]string{string(v1beta1.ClusterModePush), string(v1beta1.ClusterModePull)})...)
+	}
+	if spec.Transport != "" {
+		allErrs = append(allErrs, validateEnumStrings(path.Child("transport"), string(spec.Transport),
+			[]string{string(v1beta1.ClusterTransportDirect), string(v1beta1.ClusterTransportTunnel)})...)
+	}
+	allErrs = append(allErrs, validateTaints(spec.Taints, path.Child("taints"))...)
+	if spec.Transport == v1beta1.ClusterTransportTunnel {
+		if spec.ProxyURL != "" {
+			allErrs = append(allErrs, field.Forbidden(path.Child("proxyURL"), "may not be set when transport is Tunnel"))
+		}
+		if pullMode {
+			allErrs = append(allErrs, field.Forbidden(path.Child("transport"), "may not be Tunnel when mode is Pull"))
+		}
+	}
+	return allErrs
+}
+
+func validateClusterClientConfig(clientConfig *v1beta1.ClusterClientConfig, path *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if clientConfig.QPS != nil {
+		allErrs = append(allErrs, validateGreaterThan0(path.Child("qps"), int64(*clientConfig.QPS))...)
+	}
+	if clientConfig.Burst != nil {
+		allErrs = append(allErrs, validateGreaterThan0(path.Child("burst"), int64(*clientConfig.Burst))...)
+	}
+	if clientConfig.RequestTimeout != nil {
+		allErrs = append(allErrs, validateDurationGreaterThan0(path.Child("requestTimeout"), clientConfig.RequestTimeout)...)
+	}
+	if clientConfig.ContentType != "" {
+		allErrs = append(allErrs, validateEnumStrings(path.Child("contentType"), string(clientConfig.ContentType),
+			[]string{string(v1beta1.ClientContentTypeJSON), string(v1beta1.ClientContentTypeProtobuf)})...)
+	}
+	return allErrs
+}
+
+func validateExecConfig(execConfig *v1beta1.ExecConfig, path *field.Path, allowedExecCommands []string) field.ErrorList {
+	allErrs := field.ErrorList{}
+	switch {
+	case execConfig.Command == "":
+		allErrs = append(allErrs, field.Required(path.Child("command"), ""))
+	case len(allowedExecCommands) == 0:
+		allErrs = append(allErrs, field.Forbidden(path, "exec credentials are not enabled by the execCredentialCommands of the KubeFedConfig"))
+	case !slices.Contains(allowedExecCommands, execConfig.Command):
+		allErrs = append(allErrs, field.NotSupported(path.Child("command"), execConfig.Command, allowedExecCommands))
+	}
+	allErrs = append(allErrs, validateEnumStrings(path.Child("apiVersion"), execConfig.APIVersion,
+		[]string{"client.authentication.k8s.io/v1", "client.authentication.k8s.io/v1beta1"})...)
+	for i, envVar := range execConfig.Env {
+		if envVar.Name == "" {
+			allErrs = append(allErrs, field.Required(path.Child("env").Index(i).Child("name"), ""))
+		}
+	}
+	return allErrs
+}
+
+// ValidateKubeFedClusterSecrets validates the contents of the secrets
+// referenced by a KubeFedCluster. A nil secret is not validated.
+func ValidateKubeFedClusterSecrets(spec *v1beta1.KubeFedClusterSpec, secret, caBundleSecret *corev1.Secret) field.ErrorList {
+	allErrs := field.ErrorList{}
+	path := field.NewPath("spec")
+
+	if secret != nil {
+		secretPath := path.Child("secretRef")
+		token := secret.Data[corev1.ServiceAccountTokenKey]
+		certData := secret.Data[corev1.TLSCertKey]
+		keyData := secret.Data[corev1.TLSPrivateKeyKey]
+		switch {
+		case len(certData) != 0 || len(keyData) != 0:
+			if _, err := tls.X509KeyPair(certData, keyData); err != nil {
+				allErrs = append(allErrs, field.Invalid(secretPath, secret.Name,
+					fmt.Sprintf("secret does not contain a valid client certificate and key under %q and %q: %v", corev1.TLSCertKey, corev1.TLSPrivateKeyKey, err)))
+			}
+		case len(token) == 0 && spec.Exec == nil:
+			allErrs = append(allErrs, field.Invalid(secretPath, secret.Name,
+				fmt.Sprintf("secret must contain a non-empty value for %q, or for %q and %q", corev1.ServiceAccountTokenKey, corev1.TLSCertKey, corev1.TLSPrivateKeyKey)))
+		}
+	}
+
+	if caBundleSecret != nil {
+		caPath := path.Child("caBundleSecretRef")
+		caBundle := caBundleSecret.Data[corev1.ServiceAccountRootCAKey]
+		if len(caBundle) == 0 {
+			allErrs = append(allErrs, field.Invalid(caPath, caBundleSecret.Name,
+				fmt.Sprintf("secret must contain a non-empty value for %q", corev1.ServiceAccountRootCAKey)))
+		} else if _, err := certutil.ParseCertsPEM(caBundle); err != nil {
+			allErrs = append(allErrs, field.Invalid(caPath, caBundleSecret.Name,
+				fmt.Sprintf("secret does not contain a valid certificate bundle under %q: %v", corev1.ServiceAccountRootCAKey, err)))
+		}
+	}
+
 	return allErrs
 }
 
@@ -222,6 +368,33 @@ func validateAPIEndpoint(endpoint string, path *field.Path) field.ErrorList {
 	return allErrs
 }
 
+func validateTaints(taints []corev1.Taint, path *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	type taintID struct {
+		key    string
+		effect corev1.TaintEffect
+	}
+	seen := make(map[taintID]bool)
+	for i, taint := range taints {
+		idxPath := path.Index(i)
+		for _, msg := range valutil.IsQualifiedName(taint.Key) {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("key"), taint.Key, msg))
+		}
+		for _, msg := range valutil.IsValidLabelValue(taint.Value) {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("value"), taint.Value, msg))
+		}
+		allErrs = append(allErrs, validateEnumStrings(idxPath.Child("effect"), string(taint.Effect),
+			[]string{string(corev1.TaintEffectNoSchedule), string(corev1.TaintEffectNoExecute)})...)
+
+		id := taintID{key: taint.Key, effect: taint.Effect}
+		if seen[id] {
+			allErrs = append(allErrs, field.Duplicate(idxPath, taint.Key+":"+string(taint.Effect)))
+		}
+		seen[id] = true
+	}
+	return allErrs
+}
+
 func validateLocalSecretReference(secretRef *v1beta1.LocalSecretReference, path *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 	if secretRef.Name == "" {
@@ -255,7 +428,7 @@ func validateDisabledTLSValidations(disabledTLSValidations []v1beta1.TLSValidati
 func validateClusterCondition(cc *v1beta1.ClusterCondition, path *field.Path) field.ErrorList {
 	var allErrs field.ErrorList
 
-	allErrs = append(allErrs, validateEnumStrings(path.Child("type"), string(cc.Type), []string{string(common.ClusterReady), string(common.ClusterOffline), string(common.ClusterConfigMalformed)})...)
+	allErrs = append(allErrs, validateEnumStrings(path.Child("type"), string(cc.Type), []string{string(common.ClusterReady), string(common.ClusterOffline), string(common.ClusterConfigMalformed), string(common.ClusterCredentialsRotated), string(common.ClusterTunnelConnected), string(common.ClusterRBACUpToDate)})...)
 	allErrs = append(allErrs, validateEnumStrings(path.Child("status"), string(cc.Status), []string{string(corev1.ConditionTrue), string(corev1.ConditionFalse), string(corev1.ConditionUnknown)})...)
 
 	if cc.LastProbeTime.IsZero() {
@@ -330,7 +503,7 @@ func ValidateKubeFedConfig(kubeFedConfig, oldKubeFedConfig *v1beta1.KubeFedConfi
 			existingNames[gate.Name] = true
 
 			allErrs = append(allErrs, validateEnumStrings(gatesPath.Child("name"), string(gate.Name),
-				[]string{string(features.PushReconciler), string(features.RawResourceStatusCollection), string(features.SchedulerPreferences)})...)
+				[]string{string(features.PushReconciler), string(features.RawResourceStatusCollection), string(features.SchedulerPreferences), string(features.CredentialRotation)})...)
 
 			allErrs = append(allErrs, validateEnumStrings(gatesPath.Child("configuration"), string(gate.Configuration),
 				[]string{string(v1beta1.ConfigurationEnabled), string(v1beta1.ConfigurationDisabled)})...)
@@ -360,6 +533,16 @@ func ValidateKubeFedConfig(kubeFedConfig, oldKubeFedConfig *v1beta1.KubeFedConfi
 		allErrs = append(allErrs, validateIntPtrGreaterThan0(syncPath.Child("maxConcurrentReconciles"), sync.MaxConcurrentReconciles)...)
 		allErrs = append(allErrs, validateEnumStrings(adoptPath, string(*sync.AdoptResources),
 			[]string{string(v1beta1.AdoptResourcesEnabled), string(v1beta1.AdoptResourcesDisabled)})...)
+		allErrs = append(allErrs, validateDurationGreaterThan0(syncPath.Child("clusterOperationTimeout"), sync.ClusterOperationTimeout)...)
+		allErrs = append(allErrs, validateDurationGreaterThan0(syncPath.Child("clusterInitialBackoff"), sync.ClusterInitialBackoff)...)
+		allErrs = append(allErrs, validateDurationGreaterThan0(syncPath.Child("clusterMaxBackoff"), sync.ClusterMaxBackoff)...)
+		allErrs = append(allErrs, validateIntPtrGreaterThan0(syncPath.Child("maxConcurrentClusterOperations"), sync.MaxConcurrentClusterOperations)...)
+		allErrs = append(allErrs, validateIntPtrGreaterThan0(syncPath.Child("maxConcurrentOperationsPerCluster"), sync.MaxConcurrentOperationsPerCluster)...)
+		if sync.ClusterInitialBackoff != nil && sync.ClusterMaxBackoff != nil &&
+			sync.ClusterMaxBackoff.Duration < sync.ClusterInitialBackoff.Duration {
+			allErrs = append(allErrs, field.Invalid(syncPath.Child("clusterMaxBackoff"), sync.ClusterMaxBackoff,
+				"clusterMaxBackoff must not be less than clusterInitialBackoff"))
+		}
 	}
 
 	statusController := spec.StatusController
@@ -370,6 +553,21 @@ func ValidateKubeFedConfig(kubeFedConfig, oldKubeFedConfig *v1beta1.KubeFedConfi
 		allErrs = append(allErrs, validateIntPtrGreaterThan0(statusControllerPath.Child("maxConcurrentReconciles"), statusController.MaxConcurrentReconciles)...)
 	}
 
+	rotation := spec.CredentialRotation
+	rotationPath := specPath.Child("credentialRotation")
+	if rotation == nil {
+		allErrs = append(allErrs, field.Required(rotationPath, ""))
+	} else {
+		allErrs = append(allErrs, validateDurationGreaterThan0(rotationPath.Child("period"), rotation.Period)...)
+	}
+
+	commandsPath := specPath.Child("execCredentialCommands")
+	for i, command := range spec.ExecCredentialCommands {
+		if !strings.HasPrefix(command, "/") {
+			allErrs = append(allErrs, field.Invalid(commandsPath.Index(i), command, "must be an absolute path"))
+		}
+	}
+
 	return allErrs
 }
 
@@ -26,6 +26,7 @@ import (
 	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	certutil "k8s.io/client-go/util/cert"
 
 	"sigs.k8s.io/kubefed/pkg/apis/core/common"
 	"sigs.k8s.io/kubefed/pkg/apis/core/v1beta1"
@@ -120,6 +121,23 @@ func TestValidateFederatedTypeConfigSpec(t *testing.T) {
 	invalidStatusCollection.Spec.StatusCollection = &invalidStatusCollectionMode
 	errorCases["spec.statusCollection: Unsupported value"] = invalidStatusCollection
 
+	invalidPropagationStrategy := validFederatedTypeConfig()
+	var invalidPropagationStrategyValue v1beta1.PropagationStrategy = "InvalidPropagationStrategy"
+	invalidPropagationStrategy.Spec.PropagationStrategy = &invalidPropagationStrategyValue
+	errorCases["spec.propagationStrategy: Unsupported value"] = invalidPropagationStrategy
+
+	missingRetainFieldPath := validFederatedTypeConfig()
+	missingRetainFieldPath.Spec.RetainFields = []v1beta1.RetainField{{}}
+	errorCases["spec.retainFields[0].path: Required value"] = missingRetainFieldPath
+
+	invalidRetainFieldPath := validFederatedTypeConfig()
+	invalidRetainFieldPath.Spec.RetainFields = []v1beta1.RetainField{{Path: "/spec//clusterIP"}}
+	errorCases["spec.retainFields[0].path: Invalid value"] = invalidRetainFieldPath
+
+	emptyRetainFieldListKey := validFederatedTypeConfig()
+	emptyRetainFieldListKey.Spec.RetainFields = []v1beta1.RetainField{{Path: "/spec/ports/*/nodePort", ListKeys: []string{""}}}
+	errorCases["spec.retainFields[0].listKeys[0]: Required value"] = emptyRetainFieldListKey
+
 	for k, v := range errorCases {
 		errs := ValidateFederatedTypeConfigSpec(&v.Spec, field.NewPath("spec"))
 		if len(errs) == 0 {
@@ -338,12 +356,57 @@ func TestValidateKubeFedCluster(t *testing.T) {
 	// functions are wired correctly.
 	statusSubResource := []bool{true, false}
 	validKFC := testcommon.ValidKubeFedCluster()
+	allowedExecCommands := []string{"/usr/local/bin/aws"}
 	for _, status := range statusSubResource {
-		if errs := ValidateKubeFedCluster(validKFC, status); len(errs) != 0 {
+		if errs := ValidateKubeFedCluster(validKFC, status, nil); len(errs) != 0 {
 			t.Errorf("expected success: %v", errs)
 		}
 	}
 
+	// A secret is not required if credentials are provided by a command.
+	execKFC := testcommon.ValidKubeFedCluster()
+	execKFC.Spec.SecretRef.Name = ""
+	execKFC.Spec.Exec = &v1beta1.ExecConfig{Command: "/usr/local/bin/aws", APIVersion: "client.authentication.k8s.io/v1beta1"}
+	if errs := ValidateKubeFedCluster(execKFC, false, allowedExecCommands); len(errs) != 0 {
+		t.Errorf("expected success: %v", errs)
+	}
+
+	// Exec credentials are rejected unless commands are allowed.
+	if errs := ValidateKubeFedCluster(execKFC, false, nil); len(errs) == 0 {
+		t.Errorf("expected failure without allowed exec commands")
+	} else if expected := "exec: Forbidden"; !strings.Contains(errs[0].Error(), expected) {
+		t.Errorf("unexpected error: %q, expected: %q", errs[0].Error(), expected)
+	}
+
+	// Credentials are not required for a pull-mode cluster.
+	pullKFC := testcommon.ValidKubeFedCluster()
+	pullKFC.Spec.SecretRef.Name = ""
+	pullKFC.Spec.Mode = v1beta1.ClusterModePull
+	if errs := ValidateKubeFedCluster(pullKFC, false, nil); len(errs) != 0 {
+		t.Errorf("expected success: %v", errs)
+	}
+
+	taintedKFC := testcommon.ValidKubeFedCluster()
+	taintedKFC.Spec.Taints = []corev1.Taint{
+		{Key: "example.com/maintenance", Effect: corev1.TaintEffectNoSchedule},
+		{Key: "example.com/maintenance", Value: "drain", Effect: corev1.TaintEffectNoExecute},
+	}
+	if errs := ValidateKubeFedCluster(taintedKFC, false, nil); len(errs) != 0 {
+		t.Errorf("expected success: %v", errs)
+	}
+
+	tunnelKFC := testcommon.ValidKubeFedCluster()
+	tunnelKFC.Spec.Transport = v1beta1.ClusterTransportTunnel
+	if errs := ValidateKubeFedCluster(tunnelKFC, false, nil); len(errs) != 0 {
+		t.Errorf("expected success: %v", errs)
+	}
+	// The status of a tunneled cluster reports whether its tunnel is
+	// connected.
+	tunnelKFC.Status.Conditions[1].Type = common.ClusterTunnelConnected
+	if errs := ValidateKubeFedCluster(tunnelKFC, true, nil); len(errs) != 0 {
+		t.Errorf("expected success: %v", errs)
+	}
+
 	// Validate single error case for spec and status to ensure validation
 	// functions are wired correctly.
 	type KFCAndStatusSubResource struct {
@@ -359,6 +422,85 @@ func TestValidateKubeFedCluster(t *testing.T) {
 		false,
 	}
 
+	invalidQPS := int32(0)
+	invalidKFCClientConfig := testcommon.ValidKubeFedCluster()
+	invalidKFCClientConfig.Spec.ClientConfig = &v1beta1.ClusterClientConfig{QPS: &invalidQPS}
+	errorCases["clientConfig.qps: Invalid value"] = KFCAndStatusSubResource{
+		invalidKFCClientConfig,
+		false,
+	}
+
+	invalidKFCCABundle := testcommon.ValidKubeFedCluster()
+	invalidKFCCABundle.Spec.CABundle = []byte("ca")
+	invalidKFCCABundle.Spec.CABundleSecretRef = &v1beta1.LocalSecretReference{Name: "ca"}
+	errorCases["caBundleSecretRef: Forbidden: may not be set together with caBundle"] = KFCAndStatusSubResource{
+		invalidKFCCABundle,
+		false,
+	}
+
+	invalidKFCExec := testcommon.ValidKubeFedCluster()
+	invalidKFCExec.Spec.SecretRef.Name = ""
+	invalidKFCExec.Spec.Exec = &v1beta1.ExecConfig{Command: "/usr/local/bin/aws", APIVersion: "v1"}
+	errorCases["exec.apiVersion: Unsupported value"] = KFCAndStatusSubResource{
+		invalidKFCExec,
+		false,
+	}
+
+	invalidKFCExecTLS := testcommon.ValidKubeFedCluster()
+	invalidKFCExecTLS.Spec.Exec = &v1beta1.ExecConfig{Command: "/usr/local/bin/aws", APIVersion: "client.authentication.k8s.io/v1"}
+	invalidKFCExecTLS.Spec.DisabledTLSValidations = []v1beta1.TLSValidation{v1beta1.TLSAll}
+	errorCases["exec: Forbidden: may not be set together with disabledTLSValidations"] = KFCAndStatusSubResource{
+		invalidKFCExecTLS,
+		false,
+	}
+
+	invalidKFCExecCommand := testcommon.ValidKubeFedCluster()
+	invalidKFCExecCommand.Spec.SecretRef.Name = ""
+	invalidKFCExecCommand.Spec.Exec = &v1beta1.ExecConfig{Command: "/bin/sh", APIVersion: "client.authentication.k8s.io/v1"}
+	errorCases["exec.command: Unsupported value"] = KFCAndStatusSubResource{
+		invalidKFCExecCommand,
+		false,
+	}
+
+	invalidKFCMode := testcommon.ValidKubeFedCluster()
+	invalidKFCMode.Spec.Mode = "Poll"
+	errorCases["mode: Unsupported value"] = KFCAndStatusSubResource{
+		invalidKFCMode,
+		false,
+	}
+
+	invalidKFCTransport := testcommon.ValidKubeFedCluster()
+	invalidKFCTransport.Spec.Transport = "Relay"
+	errorCases["transport: Unsupported value"] = KFCAndStatusSubResource{
+		invalidKFCTransport,
+		false,
+	}
+
+	invalidKFCTunnelProxy := testcommon.ValidKubeFedCluster()
+	invalidKFCTunnelProxy.Spec.Transport = v1beta1.ClusterTransportTunnel
+	invalidKFCTunnelProxy.Spec.ProxyURL = "http://proxy.example.com"
+	errorCases["proxyURL: Forbidden"] = KFCAndStatusSubResource{
+		invalidKFCTunnelProxy,
+		false,
+	}
+
+	invalidKFCTaintEffect := testcommon.ValidKubeFedCluster()
+	invalidKFCTaintEffect.Spec.Taints = []corev1.Taint{{Key: "example.com/maintenance", Effect: corev1.TaintEffectPreferNoSchedule}}
+	errorCases["taints[0].effect: Unsupported value"] = KFCAndStatusSubResource{
+		invalidKFCTaintEffect,
+		false,
+	}
+
+	duplicateKFCTaint := testcommon.ValidKubeFedCluster()
+	duplicateKFCTaint.Spec.Taints = []corev1.Taint{
+		{Key: "example.com/maintenance", Effect: corev1.TaintEffectNoSchedule},
+		{Key: "example.com/maintenance", Value: "drain", Effect: corev1.TaintEffectNoSchedule},
+	}
+	errorCases["taints[1]: Duplicate value"] = KFCAndStatusSubResource{
+		duplicateKFCTaint,
+		false,
+	}
+
 	invalidKFCStatus := testcommon.ValidKubeFedCluster()
 	invalidKFCStatus.Status.Conditions[1].Type = ""
 	errorCases["conditions[1].type: Required value"] = KFCAndStatusSubResource{
@@ -367,7 +509,7 @@ func TestValidateKubeFedCluster(t *testing.T) {
 	}
 
 	for k, v := range errorCases {
-		errs := ValidateKubeFedCluster(v.kfc, v.status)
+		errs := ValidateKubeFedCluster(v.kfc, v.status, allowedExecCommands)
 		if len(errs) == 0 {
 			t.Errorf("[%s] expected failure", k)
 		} else if !strings.Contains(errs[0].Error(), k) {
@@ -530,6 +672,117 @@ func TestProxyURL(t *testing.T) {
 	}
 }
 
+func TestValidateClusterClientConfig(t *testing.T) {
+	positive := int32(10)
+	zero := int32(0)
+	tests := []struct {
+		clientConfig   v1beta1.ClusterClientConfig
+		expectedErrMsg string
+	}{
+		{
+			clientConfig: v1beta1.ClusterClientConfig{},
+		},
+		{
+			clientConfig: v1beta1.ClusterClientConfig{
+				QPS:            &positive,
+				Burst:          &positive,
+				RequestTimeout: &metav1.Duration{Duration: time.Second},
+				ContentType:    v1beta1.ClientContentTypeProtobuf,
+			},
+		},
+		{
+			clientConfig:   v1beta1.ClusterClientConfig{QPS: &zero},
+			expectedErrMsg: "clientConfig.qps: Invalid value: 0: should be greater than 0",
+		},
+		{
+			clientConfig:   v1beta1.ClusterClientConfig{Burst: &zero},
+			expectedErrMsg: "clientConfig.burst: Invalid value: 0: should be greater than 0",
+		},
+		{
+			clientConfig:   v1beta1.ClusterClientConfig{RequestTimeout: &metav1.Duration{}},
+			expectedErrMsg: "clientConfig.requestTimeout: Invalid value: 0: should be greater than 0",
+		},
+		{
+			clientConfig:   v1beta1.ClusterClientConfig{ContentType: "application/yaml"},
+			expectedErrMsg: "clientConfig.contentType: Unsupported value: \"application/yaml\"",
+		},
+	}
+
+	for _, test := range tests {
+		errs := validateClusterClientConfig(&test.clientConfig, field.NewPath("clientConfig"))
+		if len(errs) == 0 && test.expectedErrMsg == "" {
+			continue
+		}
+		if len(errs) == 0 {
+			t.Errorf("[%s] expected failure", test.expectedErrMsg)
+		} else if test.expectedErrMsg == "" || !strings.Contains(errs[0].Error(), test.expectedErrMsg) {
+			t.Errorf("unexpected error: %v, expected: %q", errs, test.expectedErrMsg)
+		}
+	}
+}
+
+func TestValidateKubeFedClusterSecrets(t *testing.T) {
+	certData, keyData, err := certutil.GenerateSelfSignedCertKey("example.com", nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error generating certificate: %v", err)
+	}
+	newSecret := func(data map[string][]byte) *corev1.Secret {
+		return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "secret"}, Data: data}
+	}
+
+	testCases := map[string]struct {
+		exec           *v1beta1.ExecConfig
+		secret         *corev1.Secret
+		caBundleSecret *corev1.Secret
+		expectedErrMsg string
+	}{
+		"Token is valid": {
+			secret: newSecret(map[string][]byte{"token": []byte("token")}),
+		},
+		"Client certificate is valid": {
+			secret: newSecret(map[string][]byte{"tls.crt": certData, "tls.key": keyData}),
+		},
+		"Secret without credentials is valid with exec": {
+			exec:   &v1beta1.ExecConfig{Command: "aws"},
+			secret: newSecret(nil),
+		},
+		"CA bundle is valid": {
+			caBundleSecret: newSecret(map[string][]byte{"ca.crt": certData}),
+		},
+		"Missing secrets are not validated": {},
+		"Secret without credentials is invalid": {
+			secret:         newSecret(map[string][]byte{"token": nil}),
+			expectedErrMsg: `spec.secretRef: Invalid value: "secret": secret must contain a non-empty value for "token"`,
+		},
+		"Client certificate without key is invalid": {
+			secret:         newSecret(map[string][]byte{"tls.crt": certData}),
+			expectedErrMsg: `spec.secretRef: Invalid value: "secret": secret does not contain a valid client certificate and key`,
+		},
+		"Invalid CA bundle": {
+			caBundleSecret: newSecret(map[string][]byte{"ca.crt": []byte("not a certificate")}),
+			expectedErrMsg: `spec.caBundleSecretRef: Invalid value: "secret": secret does not contain a valid certificate bundle`,
+		},
+	}
+
+	for testName, tc := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			spec := &v1beta1.KubeFedClusterSpec{Exec: tc.exec}
+			errs := ValidateKubeFedClusterSecrets(spec, tc.secret, tc.caBundleSecret)
+			if tc.expectedErrMsg == "" {
+				if len(errs) != 0 {
+					t.Errorf("expected success: %v", errs)
+				}
+				return
+			}
+			if len(errs) == 0 {
+				t.Errorf("[%s] expected failure", tc.expectedErrMsg)
+			} else if !strings.Contains(errs[0].Error(), tc.expectedErrMsg) {
+				t.Errorf("unexpected error: %v, expected: %q", errs, tc.expectedErrMsg)
+			}
+		})
+	}
+}
+
 func TestValidateLocalSecretReference(t *testing.T) {
 	testCases := []struct {
 		secretName     string
@@ -864,6 +1117,26 @@ func TestValidateKubeFedConfig(t *testing.T) {
 	invalidAdoptResourcesNil.Spec.SyncController.AdoptResources = nil
 	errorCases["spec.syncController.adoptResources: Required value"] = invalidAdoptResourcesNil
 
+	invalidClusterOperationTimeoutNil := testcommon.ValidKubeFedConfig()
+	invalidClusterOperationTimeoutNil.Spec.SyncController.ClusterOperationTimeout = nil
+	errorCases["spec.syncController.clusterOperationTimeout: Required value"] = invalidClusterOperationTimeoutNil
+
+	invalidClusterInitialBackoffGreaterThan0 := testcommon.ValidKubeFedConfig()
+	invalidClusterInitialBackoffGreaterThan0.Spec.SyncController.ClusterInitialBackoff.Duration = 0
+	errorCases["spec.syncController.clusterInitialBackoff: Invalid value"] = invalidClusterInitialBackoffGreaterThan0
+
+	invalidClusterMaxBackoffLessThanInitial := testcommon.ValidKubeFedConfig()
+	invalidClusterMaxBackoffLessThanInitial.Spec.SyncController.ClusterMaxBackoff.Duration = time.Second
+	errorCases["spec.syncController.clusterMaxBackoff: Invalid value"] = invalidClusterMaxBackoffLessThanInitial
+
+	invalidMaxConcurrentClusterOperationsGreaterThan0 := testcommon.ValidKubeFedConfig()
+	invalidMaxConcurrentClusterOperationsGreaterThan0.Spec.SyncController.MaxConcurrentClusterOperations = zeroIntPtr
+	errorCases["spec.syncController.maxConcurrentClusterOperations: Invalid value"] = invalidMaxConcurrentClusterOperationsGreaterThan0
+
+	invalidMaxConcurrentOperationsPerClusterNil := testcommon.ValidKubeFedConfig()
+	invalidMaxConcurrentOperationsPerClusterNil.Spec.SyncController.MaxConcurrentOperationsPerCluster = nil
+	errorCases["spec.syncController.maxConcurrentOperationsPerCluster: Required value"] = invalidMaxConcurrentOperationsPerClusterNil
+
 	invalidAdoptResources := testcommon.ValidKubeFedConfig()
 	invalidAdoptResourcesValue := v1beta1.ResourceAdoption("NeitherEnableOrDisable")
 	invalidAdoptResources.Spec.SyncController.AdoptResources = &invalidAdoptResourcesValue
@@ -881,6 +1154,18 @@ func TestValidateKubeFedConfig(t *testing.T) {
 	invalidStatusControllerMaxConcurrentReconcilesGreaterThan0.Spec.StatusController.MaxConcurrentReconciles = zeroIntPtr
 	errorCases["spec.statusController.maxConcurrentReconciles: Invalid value"] = invalidStatusControllerMaxConcurrentReconcilesGreaterThan0
 
+	invalidCredentialRotationNil := testcommon.ValidKubeFedConfig()
+	invalidCredentialRotationNil.Spec.CredentialRotation = nil
+	errorCases["spec.credentialRotation: Required value"] = invalidCredentialRotationNil
+
+	invalidCredentialRotationPeriodGreaterThan0 := testcommon.ValidKubeFedConfig()
+	invalidCredentialRotationPeriodGreaterThan0.Spec.CredentialRotation.Period.Duration = 0
+	errorCases["spec.credentialRotation.period: Invalid value"] = invalidCredentialRotationPeriodGreaterThan0
+
+	invalidExecCredentialCommand := testcommon.ValidKubeFedConfig()
+	invalidExecCredentialCommand.Spec.ExecCredentialCommands = []string{"/usr/local/bin/aws", "aws"}
+	errorCases["spec.execCredentialCommands[1]: Invalid value"] = invalidExecCredentialCommand
+
 	for k, v := range errorCases {
 		errs := ValidateKubeFedConfig(v, testcommon.ValidKubeFedConfig())
 		if len(errs) == 0 {
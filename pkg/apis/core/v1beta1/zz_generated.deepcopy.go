@@ -21,7 +21,8 @@ limitations under the License.
 package v1beta1
 
 import (
-	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -40,6 +41,36 @@ func (in *APIResource) DeepCopy() *APIResource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterClientConfig) DeepCopyInto(out *ClusterClientConfig) {
+	*out = *in
+	if in.QPS != nil {
+		in, out := &in.QPS, &out.QPS
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Burst != nil {
+		in, out := &in.Burst, &out.Burst
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RequestTimeout != nil {
+		in, out := &in.RequestTimeout, &out.RequestTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterClientConfig.
+func (in *ClusterClientConfig) DeepCopy() *ClusterClientConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterClientConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterCondition) DeepCopyInto(out *ClusterCondition) {
 	*out = *in
@@ -75,7 +106,7 @@ func (in *ClusterHealthCheckConfig) DeepCopyInto(out *ClusterHealthCheckConfig)
 	*out = *in
 	if in.Period != nil {
 		in, out := &in.Period, &out.Period
-		*out = new(v1.Duration)
+		*out = new(metav1.Duration)
 		**out = **in
 	}
 	if in.FailureThreshold != nil {
@@ -90,7 +121,7 @@ func (in *ClusterHealthCheckConfig) DeepCopyInto(out *ClusterHealthCheckConfig)
 	}
 	if in.Timeout != nil {
 		in, out := &in.Timeout, &out.Timeout
-		*out = new(v1.Duration)
+		*out = new(metav1.Duration)
 		**out = **in
 	}
 }
@@ -105,22 +136,71 @@ func (in *ClusterHealthCheckConfig) DeepCopy() *ClusterHealthCheckConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterResourceSummary) DeepCopyInto(out *ClusterResourceSummary) {
+	*out = *in
+	if in.Allocatable != nil {
+		in, out := &in.Allocatable, &out.Allocatable
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.Requested != nil {
+		in, out := &in.Requested, &out.Requested
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterResourceSummary.
+func (in *ClusterResourceSummary) DeepCopy() *ClusterResourceSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterResourceSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CredentialRotationConfig) DeepCopyInto(out *CredentialRotationConfig) {
+	*out = *in
+	if in.Period != nil {
+		in, out := &in.Period, &out.Period
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CredentialRotationConfig.
+func (in *CredentialRotationConfig) DeepCopy() *CredentialRotationConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CredentialRotationConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DurationConfig) DeepCopyInto(out *DurationConfig) {
 	*out = *in
 	if in.AvailableDelay != nil {
 		in, out := &in.AvailableDelay, &out.AvailableDelay
-		*out = new(v1.Duration)
+		*out = new(metav1.Duration)
 		**out = **in
 	}
 	if in.UnavailableDelay != nil {
 		in, out := &in.UnavailableDelay, &out.UnavailableDelay
-		*out = new(v1.Duration)
+		*out = new(metav1.Duration)
 		**out = **in
 	}
 	if in.CacheSyncTimeout != nil {
 		in, out := &in.CacheSyncTimeout, &out.CacheSyncTimeout
-		*out = new(v1.Duration)
+		*out = new(metav1.Duration)
 		**out = **in
 	}
 }
@@ -135,6 +215,46 @@ func (in *DurationConfig) DeepCopy() *DurationConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExecConfig) DeepCopyInto(out *ExecConfig) {
+	*out = *in
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]ExecEnvVar, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExecConfig.
+func (in *ExecConfig) DeepCopy() *ExecConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ExecConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExecEnvVar) DeepCopyInto(out *ExecEnvVar) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExecEnvVar.
+func (in *ExecEnvVar) DeepCopy() *ExecEnvVar {
+	if in == nil {
+		return nil
+	}
+	out := new(ExecEnvVar)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FeatureGatesConfig) DeepCopyInto(out *FeatureGatesConfig) {
 	*out = *in
@@ -224,6 +344,18 @@ func (in *FederatedTypeConfigSpec) DeepCopyInto(out *FederatedTypeConfigSpec) {
 		*out = new(StatusCollectionMode)
 		**out = **in
 	}
+	if in.PropagationStrategy != nil {
+		in, out := &in.PropagationStrategy, &out.PropagationStrategy
+		*out = new(PropagationStrategy)
+		**out = **in
+	}
+	if in.RetainFields != nil {
+		in, out := &in.RetainFields, &out.RetainFields
+		*out = make([]RetainField, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FederatedTypeConfigSpec.
@@ -323,12 +455,34 @@ func (in *KubeFedClusterSpec) DeepCopyInto(out *KubeFedClusterSpec) {
 		*out = make([]byte, len(*in))
 		copy(*out, *in)
 	}
+	if in.CABundleSecretRef != nil {
+		in, out := &in.CABundleSecretRef, &out.CABundleSecretRef
+		*out = new(LocalSecretReference)
+		**out = **in
+	}
 	out.SecretRef = in.SecretRef
+	if in.Exec != nil {
+		in, out := &in.Exec, &out.Exec
+		*out = new(ExecConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.DisabledTLSValidations != nil {
 		in, out := &in.DisabledTLSValidations, &out.DisabledTLSValidations
 		*out = make([]TLSValidation, len(*in))
 		copy(*out, *in)
 	}
+	if in.ClientConfig != nil {
+		in, out := &in.ClientConfig, &out.ClientConfig
+		*out = new(ClusterClientConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Taints != nil {
+		in, out := &in.Taints, &out.Taints
+		*out = make([]v1.Taint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeFedClusterSpec.
@@ -361,6 +515,11 @@ func (in *KubeFedClusterStatus) DeepCopyInto(out *KubeFedClusterStatus) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.ResourceSummary != nil {
+		in, out := &in.ResourceSummary, &out.ResourceSummary
+		*out = new(ClusterResourceSummary)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeFedClusterStatus.
@@ -464,6 +623,16 @@ func (in *KubeFedConfigSpec) DeepCopyInto(out *KubeFedConfigSpec) {
 		*out = new(StatusControllerConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.CredentialRotation != nil {
+		in, out := &in.CredentialRotation, &out.CredentialRotation
+		*out = new(CredentialRotationConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExecCredentialCommands != nil {
+		in, out := &in.ExecCredentialCommands, &out.ExecCredentialCommands
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeFedConfigSpec.
@@ -481,17 +650,17 @@ func (in *LeaderElectConfig) DeepCopyInto(out *LeaderElectConfig) {
 	*out = *in
 	if in.LeaseDuration != nil {
 		in, out := &in.LeaseDuration, &out.LeaseDuration
-		*out = new(v1.Duration)
+		*out = new(metav1.Duration)
 		**out = **in
 	}
 	if in.RenewDeadline != nil {
 		in, out := &in.RenewDeadline, &out.RenewDeadline
-		*out = new(v1.Duration)
+		*out = new(metav1.Duration)
 		**out = **in
 	}
 	if in.RetryPeriod != nil {
 		in, out := &in.RetryPeriod, &out.RetryPeriod
-		*out = new(v1.Duration)
+		*out = new(metav1.Duration)
 		**out = **in
 	}
 	if in.ResourceLock != nil {
@@ -526,6 +695,26 @@ func (in *LocalSecretReference) DeepCopy() *LocalSecretReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetainField) DeepCopyInto(out *RetainField) {
+	*out = *in
+	if in.ListKeys != nil {
+		in, out := &in.ListKeys, &out.ListKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetainField.
+func (in *RetainField) DeepCopy() *RetainField {
+	if in == nil {
+		return nil
+	}
+	out := new(RetainField)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StatusControllerConfig) DeepCopyInto(out *StatusControllerConfig) {
 	*out = *in
@@ -559,6 +748,31 @@ func (in *SyncControllerConfig) DeepCopyInto(out *SyncControllerConfig) {
 		*out = new(ResourceAdoption)
 		**out = **in
 	}
+	if in.ClusterOperationTimeout != nil {
+		in, out := &in.ClusterOperationTimeout, &out.ClusterOperationTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ClusterInitialBackoff != nil {
+		in, out := &in.ClusterInitialBackoff, &out.ClusterInitialBackoff
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.ClusterMaxBackoff != nil {
+		in, out := &in.ClusterMaxBackoff, &out.ClusterMaxBackoff
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MaxConcurrentClusterOperations != nil {
+		in, out := &in.MaxConcurrentClusterOperations, &out.MaxConcurrentClusterOperations
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxConcurrentOperationsPerCluster != nil {
+		in, out := &in.MaxConcurrentOperationsPerCluster, &out.MaxConcurrentOperationsPerCluster
+		*out = new(int64)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyncControllerConfig.
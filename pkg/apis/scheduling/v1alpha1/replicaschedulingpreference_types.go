@@ -59,6 +59,77 @@ type ReplicaSchedulingPreferenceSpec struct {
 	// If omitted, clusters without explicit preferences should not have any replicas scheduled.
 	// +optional
 	Clusters map[string]ClusterPreferences `json:"clusters,omitempty"`
+
+	// Topology distributes the replicas over the topology domains of
+	// the clusters, e.g. their regions or zones.
+	// +optional
+	Topology *ReplicaTopology `json:"topology,omitempty"`
+
+	// Autoscaling scales the total replicas with the load of the
+	// workload in all clusters. If set, TotalReplicas is updated by the
+	// autoscaler.
+	// +optional
+	Autoscaling *ReplicaAutoscaling `json:"autoscaling,omitempty"`
+}
+
+// ReplicaTopology configures the distribution of the replicas of a
+// ReplicaSchedulingPreference over the topology domains of clusters.
+// Clusters whose domain is unknown are not assigned a minimum per
+// domain and, if the replicas are spread, only get the replicas that do
+// not fit the clusters of known domains.
+type ReplicaTopology struct {
+	// TopologyKey is the cluster label whose values are the topology
+	// domains of clusters. The region and zone of a cluster are also
+	// determined from its status for topology.kubernetes.io/region and
+	// topology.kubernetes.io/zone.
+	// +kubebuilder:validation:MinLength=1
+	TopologyKey string `json:"topologyKey"`
+
+	// Spread distributes the replicas evenly over the domains, and
+	// within a domain according to the cluster preferences. Otherwise
+	// the replicas beyond MinReplicasPerDomain are distributed according
+	// to the cluster preferences regardless of domain.
+	// +optional
+	Spread bool `json:"spread,omitempty"`
+
+	// MinReplicasPerDomain is the number of replicas assigned to each
+	// domain before the remaining replicas are distributed, as far as
+	// the total replicas and the limits of the clusters of the domain
+	// allow. 0 by default.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MinReplicasPerDomain int64 `json:"minReplicasPerDomain,omitempty"`
+}
+
+// ReplicaAutoscaling configures the scaling of the total replicas of a
+// ReplicaSchedulingPreference.
+// +kubebuilder:validation:XValidation:rule="!has(self.minReplicas) || self.minReplicas <= self.maxReplicas",message="minReplicas must not exceed maxReplicas"
+type ReplicaAutoscaling struct {
+	// MinReplicas is the lower bound of the total replicas. 1 by default.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+
+	// MaxReplicas is the upper bound of the total replicas.
+	// +kubebuilder:validation:Minimum=1
+	MaxReplicas int32 `json:"maxReplicas"`
+
+	// TargetCPUUtilizationPercentage is the target average CPU
+	// utilization of the pods in all clusters, as a percentage of their
+	// CPU requests, read from the metrics API of the clusters. If not
+	// set, the total replicas are the sum of the desired replicas of the
+	// HorizontalPodAutoscalers with the name of the target in the
+	// clusters.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	TargetCPUUtilizationPercentage *int32 `json:"targetCPUUtilizationPercentage,omitempty"`
+
+	// ScaleDownStabilizationSeconds is the minimum time since the total
+	// replicas were last changed before they are scaled down. 300 by
+	// default.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	ScaleDownStabilizationSeconds *int32 `json:"scaleDownStabilizationSeconds,omitempty"`
 }
 
 // Preferences regarding number of replicas assigned to a cluster workload object (dep, rs, ..) within
@@ -76,14 +147,109 @@ type ClusterPreferences struct {
 	// A number expressing the preference to put an additional replica to this cluster workload object.
 	// 0 by default.
 	Weight int64 `json:"weight,omitempty"`
+
+	// Clusters with a higher priority are assigned replicas up to their maximum or estimated
+	// capacity before clusters with a lower priority get replicas beyond their minimum.
+	// Replicas are distributed by weight among clusters of the same priority. 0 by default.
+	// +optional
+	Priority int64 `json:"priority,omitempty"`
 }
 
+// Condition types of a ReplicaSchedulingPreference.
+const (
+	// Scheduled indicates whether the replicas of the target were
+	// scheduled to clusters.
+	Scheduled = "Scheduled"
+	// InsufficientCapacity indicates whether some of the total replicas
+	// do not fit the preferences and estimated capacity of the clusters.
+	InsufficientCapacity = "InsufficientCapacity"
+	// TargetNotFound indicates whether the target federated resource
+	// does not exist.
+	TargetNotFound = "TargetNotFound"
+	// ScalingActive indicates whether the autoscaler is able to
+	// compute the total replicas.
+	ScalingActive = "ScalingActive"
+)
+
 // ReplicaSchedulingPreferenceStatus defines the observed state of ReplicaSchedulingPreference
 type ReplicaSchedulingPreferenceStatus struct {
+	// ObservedGeneration is the generation of the preference that was
+	// last scheduled.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Clusters are the names of the clusters considered for scheduling.
+	// +optional
+	Clusters []string `json:"clusters,omitempty"`
+
+	// ClusterStatuses are the scheduling details of the clusters that
+	// were considered for scheduling or run replicas of the target.
+	// +optional
+	ClusterStatuses []ClusterReplicaStatus `json:"clusterStatuses,omitempty"`
+
+	// Autoscaling is the status of the scaling of the total replicas.
+	// +optional
+	Autoscaling *ReplicaAutoscalingStatus `json:"autoscaling,omitempty"`
+
+	// Conditions are the Scheduled, InsufficientCapacity,
+	// TargetNotFound and ScalingActive conditions of the preference.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// ReplicaAutoscalingStatus describes the scaling of the total replicas
+// of a ReplicaSchedulingPreference.
+type ReplicaAutoscalingStatus struct {
+	// DesiredReplicas is the total replicas last computed by the
+	// autoscaler, before stabilization.
+	DesiredReplicas int32 `json:"desiredReplicas"`
+
+	// CurrentCPUUtilizationPercentage is the average CPU utilization of
+	// the pods in all clusters, as a percentage of their CPU requests.
+	// +optional
+	CurrentCPUUtilizationPercentage *int32 `json:"currentCPUUtilizationPercentage,omitempty"`
+
+	// LastScaleTime is the last time the autoscaler changed the total
+	// replicas.
+	// +optional
+	LastScaleTime *metav1.Time `json:"lastScaleTime,omitempty"`
+}
+
+// ClusterReplicaStatus describes the scheduling of the replicas of the
+// target to a cluster.
+type ClusterReplicaStatus struct {
+	// ClusterName is the name of the cluster.
+	ClusterName string `json:"clusterName"`
+
+	// DesiredReplicas is the number of replicas scheduled to the
+	// cluster, including overflow replicas.
+	DesiredReplicas int64 `json:"desiredReplicas"`
+
+	// OverflowReplicas is the number of replicas scheduled to the
+	// cluster beyond its estimated capacity, in case they can be
+	// scheduled after all.
+	// +optional
+	OverflowReplicas int64 `json:"overflowReplicas,omitempty"`
+
+	// CurrentReplicas is the number of replicas of the workload in the
+	// cluster.
+	CurrentReplicas int64 `json:"currentReplicas"`
+
+	// ReadyReplicas is the number of running and ready replicas of the
+	// workload in the cluster.
+	ReadyReplicas int64 `json:"readyReplicas"`
+
+	// EstimatedCapacity is the estimated number of replicas that the
+	// cluster can run, from its unschedulable replicas or from the
+	// resource summary of the cluster.
+	// +optional
+	EstimatedCapacity *int64 `json:"estimatedCapacity,omitempty"`
 }
 
 // +kubebuilder:object:root=true
+// +kubebuilder:printcolumn:name=scheduled,type=string,JSONPath=.status.conditions[?(@.type=='Scheduled')].status
 // +kubebuilder:resource:path=replicaschedulingpreferences,shortName=rsp
+// +kubebuilder:subresource:status
 
 type ReplicaSchedulingPreference struct {
 	metav1.TypeMeta   `json:",inline"`
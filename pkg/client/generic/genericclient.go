@@ -27,7 +27,7 @@ import (
 )
 
 type Client interface {
-	Create(ctx context.Context, obj runtimeclient.Object) error
+	Create(ctx context.Context, obj runtimeclient.Object, opts ...runtimeclient.CreateOption) error
 	Get(ctx context.Context, obj runtimeclient.Object, namespace, name string) error
 	Update(ctx context.Context, obj runtimeclient.Object) error
 	Delete(ctx context.Context, obj runtimeclient.Object, namespace, name string, opts ...runtimeclient.DeleteOption) error
@@ -59,8 +59,8 @@ func NewForConfigOrDieWithUserAgent(config *rest.Config, userAgent string) Clien
 	return NewForConfigOrDie(configCopy)
 }
 
-func (c *genericClient) Create(ctx context.Context, obj runtimeclient.Object) error {
-	return c.client.Create(ctx, obj)
+func (c *genericClient) Create(ctx context.Context, obj runtimeclient.Object, opts ...runtimeclient.CreateOption) error {
+	return c.client.Create(ctx, obj, opts...)
 }
 
 func (c *genericClient) Get(ctx context.Context, obj runtimeclient.Object, namespace, name string) error {
@@ -0,0 +1,381 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package agent
+
+import (
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	kubeclient "k8s.io/client-go/kubernetes"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	restclient "k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+
+	fedv1b1 "sigs.k8s.io/kubefed/pkg/apis/core/v1beta1"
+	genericclient "sigs.k8s.io/kubefed/pkg/client/generic"
+	"sigs.k8s.io/kubefed/pkg/client/generic/scheme"
+	"sigs.k8s.io/kubefed/pkg/controller/util"
+	"sigs.k8s.io/kubefed/pkg/metrics"
+)
+
+const userAgent = "kubefed-agent"
+
+// Config defines the configuration of the agent of a pull-mode member
+// cluster.
+type Config struct {
+	// Configuration of the access to the cluster hosting the KubeFed
+	// control plane.
+	*util.ControllerConfig
+
+	// ClusterName is the name of the KubeFedCluster representing the
+	// member cluster.
+	ClusterName string
+
+	// ClusterConfig configures access to the member cluster.
+	ClusterConfig *restclient.Config
+
+	// HeartbeatPeriod is the interval at which the health of the
+	// member cluster is reported.
+	HeartbeatPeriod time.Duration
+}
+
+// Agent propagates the federated resources placed on a pull-mode
+// member cluster from the cluster hosting the KubeFed control plane to
+// the member cluster, and reports the status of the propagated
+// resources and the health of the member cluster to the host cluster.
+//
+// A propagator is run for each FederatedTypeConfig with propagation
+// enabled, in the same way as the sync controllers of the control
+// plane are run for push-mode clusters.
+type Agent struct {
+	config *Config
+
+	// Client for the host cluster
+	client genericclient.Client
+
+	// Client for the member cluster
+	clusterClient genericclient.Client
+
+	// Informer for the KubeFedCluster of the member cluster
+	clusterStore      cache.Store
+	clusterController cache.Controller
+
+	// Informer for FederatedTypeConfigs
+	typeConfigStore      cache.Store
+	typeConfigController cache.Controller
+
+	worker util.ReconcileWorker
+
+	eventRecorder record.EventRecorder
+
+	// Running propagators keyed by the name of their type config
+	propagators map[string]*runningPropagator
+	lock        sync.RWMutex
+}
+
+type runningPropagator struct {
+	propagator *propagator
+	// Generation of the type config the propagator was started for
+	generation int64
+	stopChan   chan struct{}
+}
+
+// StartAgent starts the agent of a pull-mode member cluster.
+func StartAgent(config *Config, stopChan <-chan struct{}) error {
+	agent, err := newAgent(config)
+	if err != nil {
+		return err
+	}
+	klog.Infof("Starting agent for cluster %q", config.ClusterName)
+	agent.Run(stopChan)
+	return nil
+}
+
+func newAgent(config *Config) (*Agent, error) {
+	kubeConfig := restclient.CopyConfig(config.KubeConfig)
+	restclient.AddUserAgent(kubeConfig, userAgent)
+	client, err := genericclient.New(kubeConfig)
+	if err != nil {
+		return nil, err
+	}
+	clusterConfig := restclient.CopyConfig(config.ClusterConfig)
+	restclient.AddUserAgent(clusterConfig, userAgent)
+	clusterClient, err := genericclient.New(clusterConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	kubeClient := kubeclient.NewForConfigOrDie(kubeConfig)
+	broadcaster := record.NewBroadcaster()
+	broadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: kubeClient.CoreV1().Events("")})
+	recorder := broadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: userAgent})
+
+	a := &Agent{
+		config:        config,
+		client:        client,
+		clusterClient: clusterClient,
+		eventRecorder: recorder,
+		propagators:   make(map[string]*runningPropagator),
+	}
+
+	a.worker = util.NewReconcileWorker("agent", a.reconcile, util.WorkerOptions{})
+
+	a.clusterStore, a.clusterController, err = util.NewGenericInformerWithEventHandler(
+		kubeConfig,
+		config.KubeFedNamespace,
+		&fedv1b1.KubeFedCluster{},
+		util.NoResyncPeriod,
+		&cache.ResourceEventHandlerFuncs{
+			// The placement constraints of a federated resource
+			// depend on all clusters eligible for its placement.
+			AddFunc: func(obj interface{}) {
+				a.reconcileOnClusterChange()
+			},
+			UpdateFunc: func(oldObj, newObj interface{}) {
+				oldCluster := oldObj.(*fedv1b1.KubeFedCluster)
+				newCluster := newObj.(*fedv1b1.KubeFedCluster)
+				// Placement and cluster variables depend on the
+				// metadata, region and zones of the cluster, and other
+				// changes to the status are ignored to avoid reconciling
+				// all resources on every heartbeat.
+				if !reflect.DeepEqual(oldCluster.Labels, newCluster.Labels) || !reflect.DeepEqual(oldCluster.Annotations, newCluster.Annotations) ||
+					!reflect.DeepEqual(oldCluster.Spec, newCluster.Spec) ||
+					util.IsClusterReady(&oldCluster.Status) != util.IsClusterReady(&newCluster.Status) ||
+					!reflect.DeepEqual(oldCluster.Status.Region, newCluster.Status.Region) ||
+					!reflect.DeepEqual(oldCluster.Status.Zones, newCluster.Status.Zones) {
+					a.reconcileOnClusterChange()
+				}
+			},
+			DeleteFunc: func(obj interface{}) {
+				a.reconcileOnClusterChange()
+			},
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	a.typeConfigStore, a.typeConfigController, err = util.NewGenericInformer(
+		kubeConfig,
+		config.KubeFedNamespace,
+		&fedv1b1.FederatedTypeConfig{},
+		util.NoResyncPeriod,
+		a.worker.EnqueueObject,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
+// Run runs the agent.
+func (a *Agent) Run(stopChan <-chan struct{}) {
+	go a.clusterController.Run(stopChan)
+	go a.typeConfigController.Run(stopChan)
+
+	// wait for the caches to synchronize before starting the worker
+	if !cache.WaitForCacheSync(stopChan, a.clusterController.HasSynced, a.typeConfigController.HasSynced) {
+		runtime.HandleError(errors.New("Timed out waiting for cache to sync"))
+		return
+	}
+
+	go a.runHeartbeat(stopChan)
+	a.worker.Run(stopChan)
+
+	// Ensure all goroutines are cleaned up when the stop channel closes
+	go func() {
+		<-stopChan
+		a.shutDown()
+	}()
+}
+
+// reconcile ensures that a propagator is running for the given
+// FederatedTypeConfig if propagation is enabled for its type.
+func (a *Agent) reconcile(qualifiedName util.QualifiedName) util.ReconciliationStatus {
+	key := qualifiedName.String()
+	defer metrics.UpdateControllerReconcileDurationFromStart("agent", time.Now())
+
+	klog.V(3).Infof("Running reconcile FederatedTypeConfig for %q", key)
+
+	cachedObj, exists, err := a.typeConfigStore.GetByKey(key)
+	if err != nil {
+		runtime.HandleError(errors.Wrapf(err, "Failed to query FederatedTypeConfig store for %q", key))
+		return util.StatusError
+	}
+
+	var typeConfig *fedv1b1.FederatedTypeConfig
+	enabled := false
+	if exists {
+		typeConfig = cachedObj.(*fedv1b1.FederatedTypeConfig).DeepCopy()
+		fedv1b1.SetFederatedTypeConfigDefaults(typeConfig)
+		// Cluster-scoped resources are not propagated by a namespaced
+		// KubeFed control plane.
+		enabled = typeConfig.DeletionTimestamp == nil && typeConfig.GetPropagationEnabled() &&
+			(!a.config.LimitedScope() || typeConfig.GetNamespaced())
+	}
+
+	// A namespaced type cannot be propagated without the type config
+	// for namespaces.
+	var fedNamespaceAPIResource *metav1.APIResource
+	if enabled && typeConfig.GetNamespaced() {
+		fedNamespaceAPIResource, err = a.getFederatedNamespaceAPIResource()
+		if err != nil {
+			klog.V(2).Infof("Not propagating %q: %v", key, err)
+			enabled = false
+		}
+	}
+
+	if qualifiedName.Name == util.NamespaceName {
+		a.reconcileNamespacedTypeConfigs()
+	}
+
+	running, ok := a.getPropagator(qualifiedName.Name)
+	if ok && (!enabled || running.generation != typeConfig.Generation) {
+		a.stopPropagator(qualifiedName.Name)
+		ok = false
+	}
+	if !enabled || ok {
+		return util.StatusAllOK
+	}
+
+	if err := a.startPropagator(typeConfig, fedNamespaceAPIResource); err != nil {
+		runtime.HandleError(err)
+		return util.StatusError
+	}
+	return util.StatusAllOK
+}
+
+func (a *Agent) startPropagator(typeConfig *fedv1b1.FederatedTypeConfig, fedNamespaceAPIResource *metav1.APIResource) error {
+	kind := typeConfig.GetFederatedType().Kind
+	p, err := newPropagator(a.config, typeConfig, fedNamespaceAPIResource, a.client, a.clusterClient, a.getCluster, a.getPlacementClusters, a.eventRecorder)
+	if err != nil {
+		return errors.Wrapf(err, "Error starting propagator for %q", kind)
+	}
+	stopChan := make(chan struct{})
+	p.Run(stopChan)
+	klog.Infof("Started propagator for %q", kind)
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	a.propagators[typeConfig.Name] = &runningPropagator{
+		propagator: p,
+		generation: typeConfig.Generation,
+		stopChan:   stopChan,
+	}
+	return nil
+}
+
+func (a *Agent) stopPropagator(name string) {
+	klog.Infof("Stopping propagator for %q", name)
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	if running, ok := a.propagators[name]; ok {
+		close(running.stopChan)
+		delete(a.propagators, name)
+	}
+}
+
+func (a *Agent) getPropagator(name string) (*runningPropagator, bool) {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+	running, ok := a.propagators[name]
+	return running, ok
+}
+
+func (a *Agent) shutDown() {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	for name, running := range a.propagators {
+		close(running.stopChan)
+		delete(a.propagators, name)
+	}
+}
+
+func (a *Agent) getFederatedNamespaceAPIResource() (*metav1.APIResource, error) {
+	qualifiedName := util.QualifiedName{
+		Namespace: a.config.KubeFedNamespace,
+		Name:      util.NamespaceName,
+	}
+	key := qualifiedName.String()
+	cachedObj, exists, err := a.typeConfigStore.GetByKey(key)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error retrieving %q from the informer cache", key)
+	}
+	if !exists {
+		return nil, errors.Errorf("Unable to find %q in the informer cache", key)
+	}
+	namespaceTypeConfig := cachedObj.(*fedv1b1.FederatedTypeConfig)
+	apiResource := namespaceTypeConfig.GetFederatedType()
+	return &apiResource, nil
+}
+
+// reconcileNamespacedTypeConfigs ensures that the propagators of
+// namespaced types are started or stopped in response to a change to
+// the type config for namespaces.
+func (a *Agent) reconcileNamespacedTypeConfigs() {
+	for _, cachedObj := range a.typeConfigStore.List() {
+		typeConfig := cachedObj.(*fedv1b1.FederatedTypeConfig)
+		if typeConfig.GetNamespaced() && !typeConfig.IsNamespace() {
+			a.worker.EnqueueObject(typeConfig)
+		}
+	}
+}
+
+// reconcileOnClusterChange reconciles all federated resources in
+// response to a change that may affect their placement.
+func (a *Agent) reconcileOnClusterChange() {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+	for _, running := range a.propagators {
+		running.propagator.reconcileAll()
+	}
+}
+
+func (a *Agent) isOwnCluster(obj interface{}) bool {
+	cluster, ok := obj.(*fedv1b1.KubeFedCluster)
+	return ok && cluster.Name == a.config.ClusterName
+}
+
+// getCluster returns the KubeFedCluster of the member cluster, or nil
+// if it does not exist.
+func (a *Agent) getCluster() (*fedv1b1.KubeFedCluster, error) {
+	qualifiedName := util.QualifiedName{
+		Namespace: a.config.KubeFedNamespace,
+		Name:      a.config.ClusterName,
+	}
+	cachedObj, exists, err := a.clusterStore.GetByKey(qualifiedName.String())
+	if err != nil || !exists {
+		return nil, err
+	}
+	return cachedObj.(*fedv1b1.KubeFedCluster), nil
+}
+
+// getPlacementClusters returns the clusters that the sync controller
+// computes placement for.
+func (a *Agent) getPlacementClusters() ([]*fedv1b1.KubeFedCluster, error) {
+	return util.PlacementClusters(a.clusterStore.List())
+}
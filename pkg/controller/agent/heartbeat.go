@@ -0,0 +1,63 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package agent
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+
+	fedv1b1 "sigs.k8s.io/kubefed/pkg/apis/core/v1beta1"
+	"sigs.k8s.io/kubefed/pkg/controller/kubefedcluster"
+)
+
+// runHeartbeat periodically reports the health of the member cluster
+// in the status of its KubeFedCluster. The control plane considers the
+// cluster offline if the health is not reported.
+func (a *Agent) runHeartbeat(stopChan <-chan struct{}) {
+	clusterClient, err := kubefedcluster.NewClusterClientForConfig(a.config.ClusterConfig, a.config.ClusterName)
+	if err != nil {
+		klog.Errorf("Failed to create a client to check the health of cluster %q: %v", a.config.ClusterName, err)
+		return
+	}
+	wait.Until(func() {
+		if err := a.reportClusterStatus(clusterClient); err != nil {
+			klog.Errorf("Error reporting the status of cluster %q: %v", a.config.ClusterName, err)
+		}
+	}, a.config.HeartbeatPeriod, stopChan)
+}
+
+func (a *Agent) reportClusterStatus(clusterClient *kubefedcluster.ClusterClient) error {
+	probedStatus, err := clusterClient.GetClusterStatus()
+	if err != nil {
+		klog.Errorf("Failed to retrieve health of the cluster %s: %v", a.config.ClusterName, err)
+	}
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cluster := &fedv1b1.KubeFedCluster{}
+		err := a.client.Get(context.TODO(), cluster, a.config.KubeFedNamespace, a.config.ClusterName)
+		if err != nil {
+			return errors.Wrap(err, "failed to retrieve the KubeFedCluster")
+		}
+		cluster.Status = *kubefedcluster.AgentClusterStatus(&cluster.Status, probedStatus)
+		return a.client.UpdateStatus(context.TODO(), cluster)
+	})
+}
@@ -0,0 +1,363 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package agent
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"sigs.k8s.io/kubefed/pkg/apis/core/typeconfig"
+	fedv1b1 "sigs.k8s.io/kubefed/pkg/apis/core/v1beta1"
+	genericclient "sigs.k8s.io/kubefed/pkg/client/generic"
+	synccontroller "sigs.k8s.io/kubefed/pkg/controller/sync"
+	"sigs.k8s.io/kubefed/pkg/controller/sync/dispatch"
+	"sigs.k8s.io/kubefed/pkg/controller/sync/status"
+	"sigs.k8s.io/kubefed/pkg/controller/util"
+	"sigs.k8s.io/kubefed/pkg/metrics"
+)
+
+// propagator propagates the federated resources of a single type that
+// are placed on the member cluster of the agent, rendering them with
+// the same logic as the sync controller, and reports their status in
+// the entry of the member cluster in the status of the federated
+// resources.
+type propagator struct {
+	clusterName string
+
+	typeConfig typeconfig.Interface
+
+	fedAccessor synccontroller.FederatedResourceAccessor
+
+	// Client for the host cluster
+	hostClient genericclient.Client
+
+	// Client for the member cluster
+	clusterClient genericclient.Client
+
+	// Returns the KubeFedCluster of the member cluster
+	getCluster func() (*fedv1b1.KubeFedCluster, error)
+
+	// Returns the clusters placement is computed for
+	getPlacementClusters func() ([]*fedv1b1.KubeFedCluster, error)
+
+	// Informer for managed resources in the member cluster
+	targetStore      cache.Store
+	targetController cache.Controller
+
+	worker util.ReconcileWorker
+
+	// Versions of the resources propagated to the member cluster
+	versions *versionCache
+
+	skipAdoptingResources bool
+
+	rawResourceStatusCollection bool
+
+	// Time allowed for an operation in the member cluster to complete.
+	clusterOperationTimeout time.Duration
+}
+
+func newPropagator(config *Config, typeConfig typeconfig.Interface, fedNamespaceAPIResource *metav1.APIResource,
+	hostClient, clusterClient genericclient.Client, getCluster func() (*fedv1b1.KubeFedCluster, error),
+	getPlacementClusters func() ([]*fedv1b1.KubeFedCluster, error), eventRecorder record.EventRecorder) (*propagator, error) {
+	p := &propagator{
+		clusterName:                 config.ClusterName,
+		typeConfig:                  typeConfig,
+		hostClient:                  hostClient,
+		clusterClient:               clusterClient,
+		getCluster:                  getCluster,
+		getPlacementClusters:        getPlacementClusters,
+		versions:                    newVersionCache(),
+		skipAdoptingResources:       config.SkipAdoptingResources,
+		rawResourceStatusCollection: config.RawResourceStatusCollection && typeConfig.GetStatusEnabled(),
+		clusterOperationTimeout:     config.ClusterOperationTimeout,
+	}
+
+	kind := typeConfig.GetFederatedType().Kind
+	p.worker = util.NewReconcileWorker(strings.ToLower(kind)+"-agent", p.reconcile, util.WorkerOptions{
+		MaxConcurrentReconciles: int(config.MaxConcurrentSyncReconciles),
+	})
+
+	targetAPIResource := typeConfig.GetTargetType()
+	targetClient, err := util.NewResourceClient(config.ClusterConfig, &targetAPIResource)
+	if err != nil {
+		return nil, err
+	}
+	targetNamespace := util.NamespaceForCluster(config.ClusterName, config.TargetNamespace)
+	p.targetStore, p.targetController = util.NewManagedResourceInformer(targetClient, targetNamespace, &targetAPIResource, p.worker.EnqueueObject)
+
+	p.fedAccessor, err = synccontroller.NewFederatedResourceAccessor(
+		config.ControllerConfig, typeConfig, fedNamespaceAPIResource,
+		hostClient, p.worker.EnqueueObject, eventRecorder, getPlacementClusters)
+	if err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+func (p *propagator) Run(stopChan <-chan struct{}) {
+	p.fedAccessor.Run(stopChan)
+	go p.targetController.Run(stopChan)
+
+	go func() {
+		// wait for the caches to synchronize before starting the worker
+		if !cache.WaitForCacheSync(stopChan, p.fedAccessor.HasSynced, p.targetController.HasSynced) {
+			runtime.HandleError(errors.Errorf("Timed out waiting for caches of %q to sync", p.typeConfig.GetFederatedType().Kind))
+			return
+		}
+		p.worker.Run(stopChan)
+	}()
+}
+
+// reconcileAll reconciles all federated resources of the type.
+func (p *propagator) reconcileAll() {
+	p.fedAccessor.VisitFederatedResources(func(obj interface{}) {
+		p.worker.EnqueueObject(obj.(runtimeclient.Object))
+	})
+}
+
+func (p *propagator) reconcile(qualifiedName util.QualifiedName) util.ReconciliationStatus {
+	kind := p.typeConfig.GetFederatedType().Kind
+	defer metrics.UpdateControllerReconcileDurationFromStart("agentpropagator", time.Now())
+
+	fedResource, possibleOrphan, err := p.fedAccessor.FederatedResource(qualifiedName)
+	if err != nil {
+		runtime.HandleError(errors.Wrapf(err, "Error creating FederatedResource helper for %s %q", kind, qualifiedName))
+		return util.StatusError
+	}
+	if possibleOrphan {
+		return p.removeManagedLabel(qualifiedName)
+	}
+	if fedResource == nil {
+		return util.StatusAllOK
+	}
+
+	cluster, err := p.getCluster()
+	if err != nil {
+		runtime.HandleError(errors.Wrapf(err, "Failed to retrieve cluster %q", p.clusterName))
+		return util.StatusError
+	}
+	if cluster == nil {
+		klog.V(2).Infof("Not reconciling %s %q since cluster %q is not registered", kind, qualifiedName, p.clusterName)
+		return util.StatusAllOK
+	}
+
+	klog.V(4).Infof("Reconciling %s %q for cluster %q", kind, qualifiedName, p.clusterName)
+
+	obj := fedResource.Object()
+	if obj.GetDeletionTimestamp() != nil {
+		opts, err := util.GetDeleteOptions(obj)
+		if err != nil {
+			runtime.HandleError(errors.Wrapf(err, "failed to deserialize delete options of %s %q", kind, qualifiedName))
+			return util.StatusError
+		}
+		return p.ensureRemoved(fedResource, util.IsOrphaningEnabled(obj), opts...)
+	}
+
+	// Placement constraints may choose among all clusters, so
+	// placement is computed for the same clusters as by the sync
+	// controller.
+	placementClusters, err := p.getPlacementClusters()
+	if err != nil {
+		runtime.HandleError(errors.Wrap(err, "Failed to retrieve the list of clusters"))
+		return util.StatusError
+	}
+	selectedClusters, err := fedResource.ComputePlacement(placementClusters)
+	if err != nil {
+		fedResource.RecordError(string(status.ComputePlacementFailed), errors.Wrap(err, "Failed to compute placement"))
+		runtime.HandleError(errors.Wrapf(err, "failed to compute placement"))
+		return util.StatusError
+	}
+	if !selectedClusters.Has(p.clusterName) {
+		return p.ensureRemoved(fedResource, false)
+	}
+	return p.propagate(fedResource)
+}
+
+// propagate ensures that the given federated resource is propagated to
+// the member cluster and reports the result.
+func (p *propagator) propagate(fedResource synccontroller.FederatedResource) util.ReconciliationStatus {
+	clusterObj, err := p.clusterObject(fedResource.TargetName())
+	if err != nil {
+		runtime.HandleError(err)
+		return util.StatusError
+	}
+
+	resource := &pulledResource{FederatedResource: fedResource, versions: p.versions}
+	dispatcher := dispatch.NewManagedDispatcher(p.clientForCluster, resource, p.skipAdoptingResources,
+		p.rawResourceStatusCollection, p.typeConfig.GetServerSideApplyEnabled(), p.typeConfig.GetRetainFields(),
+		p.clusterOperationTimeout, dispatch.InlineExecutor{})
+	switch {
+	case clusterObj == nil:
+		dispatcher.Create(p.clusterName)
+	case clusterObj.GetDeletionTimestamp() != nil:
+		dispatcher.RecordStatus(p.clusterName, status.WaitingForRemoval, clusterObj.Object[util.StatusField])
+	default:
+		dispatcher.Update(p.clusterName, clusterObj)
+	}
+	_, timeoutErr := dispatcher.Wait()
+	if timeoutErr != nil {
+		runtime.HandleError(timeoutErr)
+	}
+
+	if version, ok := dispatcher.VersionMap()[p.clusterName]; ok {
+		p.versions.set(fedResource, version)
+	}
+
+	collectedStatus, collectedResourceStatus := dispatcher.CollectedStatus()
+	propStatus := collectedStatus.StatusMap[p.clusterName]
+	clusterStatus := &status.GenericClusterStatus{
+		Status:       propStatus,
+		DriftedPaths: collectedStatus.DriftedPaths[p.clusterName],
+	}
+	if p.rawResourceStatusCollection {
+		clusterStatus.RemoteStatus = collectedResourceStatus.StatusMap[p.clusterName]
+	}
+	if result := p.setClusterStatus(fedResource, clusterStatus); result != util.StatusAllOK {
+		return result
+	}
+	if timeoutErr != nil || status.IsRecoverableError(propStatus) {
+		return util.StatusError
+	}
+	return util.StatusAllOK
+}
+
+// ensureRemoved ensures that the managed resource of the given
+// federated resource is removed from the member cluster, or is no
+// longer labeled as managed if orphan is true. The entry of the member
+// cluster in the status of the federated resource is removed once the
+// removal has been observed, which allows the sync controller to
+// remove its finalizer from a deleted federated resource.
+func (p *propagator) ensureRemoved(fedResource synccontroller.FederatedResource, orphan bool, opts ...runtimeclient.DeleteOption) util.ReconciliationStatus {
+	targetName := fedResource.TargetName()
+	clusterObj, err := p.clusterObject(targetName)
+	if err != nil {
+		runtime.HandleError(err)
+		return util.StatusError
+	}
+	if clusterObj == nil {
+		p.versions.delete(fedResource)
+		return p.setClusterStatus(fedResource, nil)
+	}
+
+	if clusterObj.GetDeletionTimestamp() == nil {
+		dispatcher := dispatch.NewUnmanagedDispatcher(p.clientForCluster, p.targetGVK(), targetName, dispatch.InlineExecutor{})
+		if orphan {
+			dispatcher.RemoveManagedLabel(p.clusterName, clusterObj)
+		} else {
+			dispatcher.Delete(p.clusterName, opts...)
+		}
+		ok, err := dispatcher.Wait()
+		if err != nil || !ok {
+			runtime.HandleError(errors.Errorf("failed to remove %s %q from cluster %q", p.typeConfig.GetTargetType().Kind, targetName, p.clusterName))
+			return util.StatusError
+		}
+	}
+
+	// The resource is reconciled again when its removal is observed.
+	return p.setClusterStatus(fedResource, &status.GenericClusterStatus{Status: status.WaitingForRemoval})
+}
+
+// removeManagedLabel removes the managed label from a resource in the
+// member cluster whose federated resource no longer exists.
+func (p *propagator) removeManagedLabel(qualifiedName util.QualifiedName) util.ReconciliationStatus {
+	clusterObj, err := p.clusterObject(qualifiedName)
+	if err != nil {
+		runtime.HandleError(err)
+		return util.StatusError
+	}
+	if clusterObj == nil || clusterObj.GetDeletionTimestamp() != nil {
+		return util.StatusAllOK
+	}
+
+	klog.V(2).Infof("Removing the label %q from %s %q in cluster %q.", util.ManagedByKubeFedLabelKey, clusterObj.GetKind(), qualifiedName, p.clusterName)
+	dispatcher := dispatch.NewUnmanagedDispatcher(p.clientForCluster, p.targetGVK(), qualifiedName, dispatch.InlineExecutor{})
+	dispatcher.RemoveManagedLabel(p.clusterName, clusterObj)
+	ok, err := dispatcher.Wait()
+	if err != nil || !ok {
+		runtime.HandleError(errors.Errorf("failed to remove the label %q from %s %q in cluster %q", util.ManagedByKubeFedLabelKey, clusterObj.GetKind(), qualifiedName, p.clusterName))
+		return util.StatusError
+	}
+	return util.StatusAllOK
+}
+
+// setClusterStatus sets the entry of the member cluster in the status
+// of the given federated resource, or removes the entry if
+// clusterStatus is nil.
+func (p *propagator) setClusterStatus(fedResource synccontroller.FederatedResource, clusterStatus *status.GenericClusterStatus) util.ReconciliationStatus {
+	obj := fedResource.Object().DeepCopy()
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		updateRequired, err := status.SetClusterStatus(obj, p.clusterName, clusterStatus)
+		if err != nil || !updateRequired {
+			return err
+		}
+		err = p.hostClient.UpdateStatus(context.TODO(), obj)
+		if apierrors.IsConflict(err) {
+			if getErr := p.hostClient.Get(context.TODO(), obj, obj.GetNamespace(), obj.GetName()); getErr != nil {
+				return getErr
+			}
+		}
+		return err
+	})
+	if apierrors.IsNotFound(err) {
+		return util.StatusAllOK
+	}
+	if err != nil {
+		runtime.HandleError(errors.Wrapf(err, "failed to set the status of cluster %q for %s %q",
+			p.clusterName, fedResource.FederatedKind(), fedResource.FederatedName()))
+		return util.StatusError
+	}
+	return util.StatusAllOK
+}
+
+// clusterObject returns the managed resource with the given name in
+// the member cluster, or nil if it does not exist.
+func (p *propagator) clusterObject(targetName util.QualifiedName) (*unstructured.Unstructured, error) {
+	key := util.QualifiedNameForCluster(p.clusterName, targetName).String()
+	return util.ObjFromCache(p.targetStore, p.typeConfig.GetTargetType().Kind, key)
+}
+
+func (p *propagator) clientForCluster(clusterName string) (genericclient.Client, error) {
+	if clusterName != p.clusterName {
+		return nil, errors.Errorf("cluster %q is not accessible by the agent of cluster %q", clusterName, p.clusterName)
+	}
+	return p.clusterClient, nil
+}
+
+func (p *propagator) targetGVK() schema.GroupVersionKind {
+	apiResource := p.typeConfig.GetTargetType()
+	return schema.GroupVersionKind{
+		Group:   apiResource.Group,
+		Version: apiResource.Version,
+		Kind:    apiResource.Kind,
+	}
+}
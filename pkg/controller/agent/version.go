@@ -0,0 +1,108 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package agent
+
+import (
+	"sync"
+
+	synccontroller "sigs.k8s.io/kubefed/pkg/controller/sync"
+)
+
+// pulledResource is a federated resource whose version in the member
+// cluster is tracked by the agent rather than by the PropagatedVersion
+// resources maintained by the sync controller for push-mode clusters.
+type pulledResource struct {
+	synccontroller.FederatedResource
+
+	versions *versionCache
+}
+
+func (r *pulledResource) VersionForCluster(clusterName string) (string, error) {
+	return r.versions.get(r.FederatedResource)
+}
+
+// propagatedVersion records the version of a resource propagated to
+// the member cluster for a given template and overrides.
+type propagatedVersion struct {
+	templateVersion string
+	overrideVersion string
+	version         string
+}
+
+// versionCache holds the versions of the resources propagated to the
+// member cluster in memory. Resources are updated once after the agent
+// starts, since the versions are lost on restart.
+type versionCache struct {
+	sync.RWMutex
+	versions map[string]propagatedVersion
+}
+
+func newVersionCache() *versionCache {
+	return &versionCache{versions: make(map[string]propagatedVersion)}
+}
+
+// get returns the version propagated to the member cluster for the
+// current template and overrides of the given federated resource, or
+// an empty string if the resource has not been propagated since they
+// changed.
+func (c *versionCache) get(fedResource synccontroller.FederatedResource) (string, error) {
+	templateVersion, overrideVersion, err := resourceVersions(fedResource)
+	if err != nil {
+		return "", err
+	}
+	c.RLock()
+	defer c.RUnlock()
+	propagated, ok := c.versions[fedResource.FederatedName().String()]
+	if !ok || propagated.templateVersion != templateVersion || propagated.overrideVersion != overrideVersion {
+		return "", nil
+	}
+	return propagated.version, nil
+}
+
+func (c *versionCache) set(fedResource synccontroller.FederatedResource, version string) {
+	templateVersion, overrideVersion, err := resourceVersions(fedResource)
+	if err != nil {
+		// Failure to record a version only results in an unnecessary
+		// update.
+		return
+	}
+	c.Lock()
+	defer c.Unlock()
+	c.versions[fedResource.FederatedName().String()] = propagatedVersion{
+		templateVersion: templateVersion,
+		overrideVersion: overrideVersion,
+		version:         version,
+	}
+}
+
+func (c *versionCache) delete(fedResource synccontroller.FederatedResource) {
+	c.Lock()
+	defer c.Unlock()
+	delete(c.versions, fedResource.FederatedName().String())
+}
+
+func resourceVersions(fedResource synccontroller.FederatedResource) (string, string, error) {
+	templateVersion, err := fedResource.TemplateVersion()
+	if err != nil {
+		return "", "", err
+	}
+	overrideVersion, err := fedResource.OverrideVersion()
+	if err != nil {
+		return "", "", err
+	}
+	return templateVersion, overrideVersion, nil
+}
@@ -0,0 +1,342 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterrbac
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	kubeclientset "k8s.io/client-go/kubernetes"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	restclient "k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	fedcommon "sigs.k8s.io/kubefed/pkg/apis/core/common"
+	"sigs.k8s.io/kubefed/pkg/apis/core/typeconfig"
+	fedv1b1 "sigs.k8s.io/kubefed/pkg/apis/core/v1beta1"
+	genericclient "sigs.k8s.io/kubefed/pkg/client/generic"
+	genscheme "sigs.k8s.io/kubefed/pkg/client/generic/scheme"
+	"sigs.k8s.io/kubefed/pkg/controller/util"
+	"sigs.k8s.io/kubefed/pkg/features"
+	"sigs.k8s.io/kubefed/pkg/metrics"
+)
+
+const (
+	userAgentName = "ClusterRBAC"
+
+	// Reasons of the RBACUpToDate condition. RoleUpdateForbidden is
+	// also the reason of the event recorded for a KubeFedCluster whose
+	// role is out of date and cannot be updated with the credentials of
+	// the control plane.
+	RoleUpToDate        = "RoleUpToDate"
+	RoleUpdateForbidden = "RoleUpdateForbidden"
+)
+
+// Controller keeps the least-privilege roles granted to the KubeFed
+// control plane in member clusters in step with the enabled
+// FederatedTypeConfigs. Only the roles of clusters annotated with
+// RoleAnnotation are managed. The control plane is not granted
+// permission to update its own role, so the role is not reconciled
+// automatically. An out-of-date role is instead reported by the
+// RBACUpToDate condition of the KubeFedCluster and an event until it is
+// updated manually by kubefedctl update-rbac.
+type Controller struct {
+	controllerConfig *util.ControllerConfig
+
+	client genericclient.Client
+
+	eventRecorder record.EventRecorder
+
+	// Store and informer for FederatedTypeConfig objects
+	typeConfigStore      cache.Store
+	typeConfigController cache.Controller
+
+	// Store and informer for KubeFedCluster objects
+	clusterStore      cache.Store
+	clusterController cache.Controller
+
+	worker util.ReconcileWorker
+}
+
+// StartController starts the Controller for managing the roles of
+// member clusters.
+func StartController(config *util.ControllerConfig, stopChan <-chan struct{}) error {
+	controller, err := newController(config)
+	if err != nil {
+		return err
+	}
+	klog.Infof("Starting cluster RBAC controller")
+	controller.Run(stopChan)
+	return nil
+}
+
+// newController returns a new controller to manage the roles of
+// member clusters.
+func newController(config *util.ControllerConfig) (*Controller, error) {
+	kubeConfig := restclient.CopyConfig(config.KubeConfig)
+	restclient.AddUserAgent(kubeConfig, userAgentName)
+	client, err := genericclient.New(kubeConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Controller{
+		controllerConfig: config,
+		client:           client,
+	}
+
+	kubeClient, err := kubeclientset.NewForConfig(kubeConfig)
+	if err != nil {
+		return nil, err
+	}
+	broadcaster := record.NewBroadcaster()
+	broadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: kubeClient.CoreV1().Events("")})
+	c.eventRecorder = broadcaster.NewRecorder(genscheme.Scheme, corev1.EventSource{Component: "clusterrbac-controller"})
+
+	c.worker = util.NewReconcileWorker("clusterrbac", c.reconcile, util.WorkerOptions{})
+
+	c.typeConfigStore, c.typeConfigController, err = util.NewGenericInformer(
+		kubeConfig,
+		config.KubeFedNamespace,
+		&fedv1b1.FederatedTypeConfig{},
+		util.NoResyncPeriod,
+		func(runtimeclient.Object) {
+			c.enqueueAllClusters()
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// The status of a cluster is updated by every health check, so
+	// only changes relevant to its role trigger reconciliation.
+	c.clusterStore, c.clusterController, err = util.NewGenericInformerWithEventHandler(
+		kubeConfig,
+		config.KubeFedNamespace,
+		&fedv1b1.KubeFedCluster{},
+		util.NoResyncPeriod,
+		&cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) {
+				c.worker.EnqueueObject(obj.(runtimeclient.Object))
+			},
+			UpdateFunc: func(old, cur interface{}) {
+				oldCluster := old.(*fedv1b1.KubeFedCluster)
+				curCluster := cur.(*fedv1b1.KubeFedCluster)
+				if util.IsClusterReady(&oldCluster.Status) != util.IsClusterReady(&curCluster.Status) ||
+					!reflect.DeepEqual(oldCluster.Spec, curCluster.Spec) ||
+					oldCluster.Annotations[RoleAnnotation] != curCluster.Annotations[RoleAnnotation] {
+					c.worker.EnqueueObject(curCluster)
+				}
+			},
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// Run runs the Controller.
+func (c *Controller) Run(stopChan <-chan struct{}) {
+	go c.typeConfigController.Run(stopChan)
+	go c.clusterController.Run(stopChan)
+
+	// wait for the caches to synchronize before starting the worker
+	if !cache.WaitForCacheSync(stopChan, c.typeConfigController.HasSynced, c.clusterController.HasSynced) {
+		runtime.HandleError(errors.New("Timed out waiting for caches to sync"))
+		return
+	}
+
+	c.worker.Run(stopChan)
+}
+
+func (c *Controller) enqueueAllClusters() {
+	for _, obj := range c.clusterStore.List() {
+		c.worker.EnqueueObject(obj.(runtimeclient.Object))
+	}
+}
+
+func (c *Controller) reconcile(qualifiedName util.QualifiedName) util.ReconciliationStatus {
+	key := qualifiedName.String()
+	defer metrics.UpdateControllerReconcileDurationFromStart("clusterrbaccontroller", time.Now())
+
+	obj, exists, err := c.clusterStore.GetByKey(key)
+	if err != nil {
+		runtime.HandleError(errors.Wrapf(err, "Failed to query KubeFedCluster store for %q", key))
+		return util.StatusError
+	}
+	if !exists {
+		return util.StatusAllOK
+	}
+	cluster := obj.(*fedv1b1.KubeFedCluster)
+	roleName := cluster.Annotations[RoleAnnotation]
+	if roleName == "" || !util.IsClusterReady(&cluster.Status) || util.IsPullModeCluster(cluster) {
+		return util.StatusAllOK
+	}
+
+	klog.V(3).Infof("Reconciling role %q of cluster %q", roleName, cluster.Name)
+
+	scope := apiextv1.ClusterScoped
+	if c.controllerConfig.LimitedScope() {
+		scope = apiextv1.NamespaceScoped
+	}
+	rotateCredentials := utilfeature.DefaultFeatureGate.Enabled(features.CredentialRotation)
+	rules := PolicyRules(c.typeConfigs(), scope, roleName, rotateCredentials)
+
+	clusterConfig, err := util.BuildClusterConfig(cluster, c.client, c.controllerConfig.KubeFedNamespace)
+	if err != nil {
+		runtime.HandleError(errors.Wrapf(err, "Failed to build config for cluster %q", cluster.Name))
+		return util.StatusError
+	}
+	clientset, err := kubeclientset.NewForConfig(restclient.AddUserAgent(clusterConfig, userAgentName))
+	if err != nil {
+		runtime.HandleError(errors.Wrapf(err, "Failed to create client for cluster %q", cluster.Name))
+		return util.StatusError
+	}
+
+	err = UpdateRoleRules(clientset, scope, c.controllerConfig.KubeFedNamespace, roleName, rules)
+	if apierrors.IsForbidden(err) {
+		// Retrying will not help until the role is updated by an
+		// identity that is permitted to do so, which will requeue the
+		// cluster if the enabled types change again.
+		message := fmt.Sprintf("Role %q lacks permissions required by the enabled types. The control plane is not permitted to update it, so it must be updated manually by running \"kubefedctl update-rbac %s\" with the credentials of a cluster administrator.",
+			roleName, cluster.Name)
+		c.eventRecorder.Event(cluster, corev1.EventTypeWarning, RoleUpdateForbidden, message)
+		return c.setCondition(cluster, corev1.ConditionFalse, RoleUpdateForbidden, message)
+	}
+	if err != nil {
+		runtime.HandleError(errors.Wrapf(err, "Failed to update role %q of cluster %q", roleName, cluster.Name))
+		return util.StatusError
+	}
+	return c.setCondition(cluster, corev1.ConditionTrue, RoleUpToDate,
+		fmt.Sprintf("Role %q grants the permissions required by the enabled types", roleName))
+}
+
+// setCondition sets the RBACUpToDate condition of the given cluster if
+// it has changed.
+func (c *Controller) setCondition(cluster *fedv1b1.KubeFedCluster, status corev1.ConditionStatus, reason, message string) util.ReconciliationStatus {
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cluster := cluster.DeepCopy()
+		err := c.client.Get(context.TODO(), cluster, cluster.Namespace, cluster.Name)
+		if err != nil {
+			return err
+		}
+		if !setClusterCondition(&cluster.Status, status, reason, message, metav1.Now()) {
+			return nil
+		}
+		return c.client.UpdateStatus(context.TODO(), cluster)
+	})
+	if apierrors.IsNotFound(err) {
+		return util.StatusAllOK
+	}
+	if err != nil {
+		runtime.HandleError(errors.Wrapf(err, "Failed to update the status of cluster %q", cluster.Name))
+		return util.StatusError
+	}
+	return util.StatusAllOK
+}
+
+// setClusterCondition sets the RBACUpToDate condition of the given
+// status. Returns a boolean indication of whether the condition was
+// changed.
+func setClusterCondition(clusterStatus *fedv1b1.KubeFedClusterStatus, status corev1.ConditionStatus, reason, message string, now metav1.Time) bool {
+	condition := fedv1b1.ClusterCondition{
+		Type:               fedcommon.ClusterRBACUpToDate,
+		Status:             status,
+		LastProbeTime:      now,
+		LastTransitionTime: &now,
+		Reason:             &reason,
+		Message:            &message,
+	}
+	for i, existing := range clusterStatus.Conditions {
+		if existing.Type != fedcommon.ClusterRBACUpToDate {
+			continue
+		}
+		if existing.Status == status && existing.Reason != nil && *existing.Reason == reason &&
+			existing.Message != nil && *existing.Message == message {
+			return false
+		}
+		if existing.Status == status && existing.LastTransitionTime != nil {
+			condition.LastTransitionTime = existing.LastTransitionTime
+		}
+		clusterStatus.Conditions[i] = condition
+		return true
+	}
+	clusterStatus.Conditions = append(clusterStatus.Conditions, condition)
+	return true
+}
+
+// typeConfigs returns the FederatedTypeConfigs that are not being
+// deleted.
+func (c *Controller) typeConfigs() []typeconfig.Interface {
+	var typeConfigs []typeconfig.Interface
+	for _, obj := range c.typeConfigStore.List() {
+		typeConfig := obj.(*fedv1b1.FederatedTypeConfig).DeepCopy()
+		if typeConfig.DeletionTimestamp != nil {
+			continue
+		}
+		fedv1b1.SetFederatedTypeConfigDefaults(typeConfig)
+		typeConfigs = append(typeConfigs, typeConfig)
+	}
+	return typeConfigs
+}
+
+// UpdateRoleRules updates the rules of the named role in a member
+// cluster if they differ from the given rules. For a namespace-scoped
+// control plane the role is a Role in the given namespace, otherwise
+// it is a ClusterRole.
+func UpdateRoleRules(clientset kubeclientset.Interface, scope apiextv1.ResourceScope, namespace, roleName string, rules []rbacv1.PolicyRule) error {
+	if scope == apiextv1.NamespaceScoped {
+		role, err := clientset.RbacV1().Roles(namespace).Get(context.Background(), roleName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if reflect.DeepEqual(role.Rules, rules) {
+			return nil
+		}
+		role.Rules = rules
+		_, err = clientset.RbacV1().Roles(namespace).Update(context.Background(), role, metav1.UpdateOptions{})
+		return err
+	}
+
+	role, err := clientset.RbacV1().ClusterRoles().Get(context.Background(), roleName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if reflect.DeepEqual(role.Rules, rules) {
+		return nil
+	}
+	role.Rules = rules
+	_, err = clientset.RbacV1().ClusterRoles().Update(context.Background(), role, metav1.UpdateOptions{})
+	return err
+}
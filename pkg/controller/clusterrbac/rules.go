@@ -0,0 +1,160 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterrbac
+
+import (
+	rbacv1 "k8s.io/api/rbac/v1"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"sigs.k8s.io/kubefed/pkg/apis/core/typeconfig"
+	"sigs.k8s.io/kubefed/pkg/schedulingtypes"
+)
+
+// RoleAnnotation is set on a KubeFedCluster to the name of the role
+// in the member cluster that grants the control plane least-privilege
+// access. The rules of an annotated role are checked against the
+// enabled FederatedTypeConfigs.
+const RoleAnnotation = "kubefed.io/rbac-role"
+
+var (
+	// Verbs required to propagate resources of a target type.
+	targetVerbs = []string{"get", "list", "watch", "create", "update", "patch", "delete"}
+
+	// Verbs required to read resources.
+	readVerbs = []string{"get", "list", "watch"}
+)
+
+// PolicyRules returns the rules that grant the KubeFed control plane
+// the access it requires to a member cluster: full access to the
+// target types of the given FederatedTypeConfigs with propagation
+// enabled, read access to pods, their metrics and horizontal pod
+// autoscalers if any of those types is scheduled by preference, the
+// access to secrets required to rotate the credentials of the control
+// plane if rotateCredentials is true, and read access to the role
+// identified by roleName that contains the rules.
+//
+// For a cluster-scoped control plane the rules also cover the cluster
+// health check and the implicit dependencies of propagated resources.
+// For a namespace-scoped control plane the rules are intended for a
+// role in the target namespace, and cluster-scoped target types are
+// ignored since they are not propagated.
+func PolicyRules(typeConfigs []typeconfig.Interface, scope apiextv1.ResourceScope, roleName string, rotateCredentials bool) []rbacv1.PolicyRule {
+	limitedScope := scope == apiextv1.NamespaceScoped
+
+	resourcesByGroup := make(map[string]sets.Set[string])
+	readPods := false
+	for _, typeConfig := range typeConfigs {
+		if !typeConfig.GetPropagationEnabled() {
+			continue
+		}
+		targetType := typeConfig.GetTargetType()
+		if limitedScope && !targetType.Namespaced {
+			continue
+		}
+		if _, ok := resourcesByGroup[targetType.Group]; !ok {
+			resourcesByGroup[targetType.Group] = sets.New[string]()
+		}
+		resourcesByGroup[targetType.Group].Insert(targetType.Name)
+		// The pods of scalable types are read to schedule their
+		// replicas, and their load to autoscale them.
+		typeConfigName := typeConfig.GetObjectMeta().Name
+		if schedulingtypes.GetSchedulingType(typeConfigName) != nil || schedulingtypes.BuiltinScaleSubresource(typeConfigName) != nil {
+			readPods = true
+		}
+	}
+
+	var rules []rbacv1.PolicyRule
+	for _, group := range sets.List(sets.KeySet(resourcesByGroup)) {
+		rules = append(rules, rbacv1.PolicyRule{
+			Verbs:     targetVerbs,
+			APIGroups: []string{group},
+			Resources: sets.List(resourcesByGroup[group]),
+		})
+	}
+	if readPods {
+		rules = append(rules, rbacv1.PolicyRule{
+			Verbs:     readVerbs,
+			APIGroups: []string{""},
+			Resources: []string{"pods"},
+		}, rbacv1.PolicyRule{
+			Verbs:     readVerbs,
+			APIGroups: []string{"metrics.k8s.io"},
+			Resources: []string{"pods"},
+		}, rbacv1.PolicyRule{
+			Verbs:     readVerbs,
+			APIGroups: []string{"autoscaling"},
+			Resources: []string{"horizontalpodautoscalers"},
+		})
+	}
+
+	if rotateCredentials {
+		// Credentials are rotated by creating a token secret for the
+		// service account of the control plane, waiting for it to be
+		// populated, and deleting the secret of the replaced token.
+		rules = append(rules, rbacv1.PolicyRule{
+			Verbs:     []string{"create", "get", "delete"},
+			APIGroups: []string{""},
+			Resources: []string{"secrets"},
+		})
+	}
+
+	roleResource := "roles"
+	if !limitedScope {
+		roleResource = "clusterroles"
+		rules = append(rules,
+			// The implicit dependencies of propagated resources are
+			// their namespace and, for custom resources, their
+			// definition.
+			rbacv1.PolicyRule{
+				Verbs:     []string{"get"},
+				APIGroups: []string{""},
+				Resources: []string{"namespaces"},
+			},
+			rbacv1.PolicyRule{
+				Verbs:     []string{"get"},
+				APIGroups: []string{apiextv1.GroupName},
+				Resources: []string{"customresourcedefinitions"},
+			},
+			// The cluster health check retrieves zone and region details
+			// from nodes, and summarizes the resources of nodes and the
+			// requests of pods.
+			rbacv1.PolicyRule{
+				Verbs:     []string{"list"},
+				APIGroups: []string{""},
+				Resources: []string{"nodes", "pods"},
+			},
+			rbacv1.PolicyRule{
+				Verbs:           []string{"get"},
+				NonResourceURLs: []string{"/healthz", "/version"},
+			},
+		)
+	}
+
+	// The role is read to detect whether its rules are out of date.
+	// Permission to update the role would allow the control plane to
+	// grant itself any access, so it is updated with separately
+	// privileged credentials by kubefedctl update-rbac.
+	rules = append(rules, rbacv1.PolicyRule{
+		Verbs:         []string{"get"},
+		APIGroups:     []string{rbacv1.GroupName},
+		Resources:     []string{roleResource},
+		ResourceNames: []string{roleName},
+	})
+
+	return rules
+}
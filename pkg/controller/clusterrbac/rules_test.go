@@ -0,0 +1,177 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterrbac
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/kubefed/pkg/apis/core/typeconfig"
+	fedv1b1 "sigs.k8s.io/kubefed/pkg/apis/core/v1beta1"
+)
+
+func TestPolicyRules(t *testing.T) {
+	typeConfigs := []typeconfig.Interface{
+		newTypeConfig("configmaps", "", "ConfigMap", apiextv1.NamespaceScoped, fedv1b1.PropagationEnabled),
+		newTypeConfig("deployments.apps", "apps", "Deployment", apiextv1.NamespaceScoped, fedv1b1.PropagationEnabled),
+		newTypeConfig("namespaces", "", "Namespace", apiextv1.ClusterScoped, fedv1b1.PropagationEnabled),
+		newTypeConfig("secrets", "", "Secret", apiextv1.NamespaceScoped, fedv1b1.PropagationDisabled),
+	}
+	podRule := rbacv1.PolicyRule{
+		Verbs:     readVerbs,
+		APIGroups: []string{""},
+		Resources: []string{"pods"},
+	}
+	podMetricsRule := rbacv1.PolicyRule{
+		Verbs:     readVerbs,
+		APIGroups: []string{"metrics.k8s.io"},
+		Resources: []string{"pods"},
+	}
+	hpaRule := rbacv1.PolicyRule{
+		Verbs:     readVerbs,
+		APIGroups: []string{"autoscaling"},
+		Resources: []string{"horizontalpodautoscalers"},
+	}
+	appsRule := rbacv1.PolicyRule{
+		Verbs:     targetVerbs,
+		APIGroups: []string{"apps"},
+		Resources: []string{"deployments"},
+	}
+
+	secretsRule := rbacv1.PolicyRule{
+		Verbs:     []string{"create", "get", "delete"},
+		APIGroups: []string{""},
+		Resources: []string{"secrets"},
+	}
+
+	testCases := map[string]struct {
+		scope             apiextv1.ResourceScope
+		rotateCredentials bool
+		expectedRules     []rbacv1.PolicyRule
+	}{
+		"Cluster-scoped control plane": {
+			scope: apiextv1.ClusterScoped,
+			expectedRules: []rbacv1.PolicyRule{
+				{
+					Verbs:     targetVerbs,
+					APIGroups: []string{""},
+					Resources: []string{"configmaps", "namespaces"},
+				},
+				appsRule,
+				podRule,
+				podMetricsRule,
+				hpaRule,
+				{
+					Verbs:     []string{"get"},
+					APIGroups: []string{""},
+					Resources: []string{"namespaces"},
+				},
+				{
+					Verbs:     []string{"get"},
+					APIGroups: []string{apiextv1.GroupName},
+					Resources: []string{"customresourcedefinitions"},
+				},
+				{
+					Verbs:     []string{"list"},
+					APIGroups: []string{""},
+					Resources: []string{"nodes", "pods"},
+				},
+				{
+					Verbs:           []string{"get"},
+					NonResourceURLs: []string{"/healthz", "/version"},
+				},
+				{
+					Verbs:         []string{"get"},
+					APIGroups:     []string{rbacv1.GroupName},
+					Resources:     []string{"clusterroles"},
+					ResourceNames: []string{"role"},
+				},
+			},
+		},
+		"Namespace-scoped control plane rotating its credentials": {
+			scope:             apiextv1.NamespaceScoped,
+			rotateCredentials: true,
+			expectedRules: []rbacv1.PolicyRule{
+				{
+					Verbs:     targetVerbs,
+					APIGroups: []string{""},
+					Resources: []string{"configmaps"},
+				},
+				appsRule,
+				podRule,
+				podMetricsRule,
+				hpaRule,
+				secretsRule,
+				{
+					Verbs:         []string{"get"},
+					APIGroups:     []string{rbacv1.GroupName},
+					Resources:     []string{"roles"},
+					ResourceNames: []string{"role"},
+				},
+			},
+		},
+		"Namespace-scoped control plane": {
+			scope: apiextv1.NamespaceScoped,
+			expectedRules: []rbacv1.PolicyRule{
+				{
+					Verbs:     targetVerbs,
+					APIGroups: []string{""},
+					Resources: []string{"configmaps"},
+				},
+				appsRule,
+				podRule,
+				podMetricsRule,
+				hpaRule,
+				{
+					Verbs:         []string{"get"},
+					APIGroups:     []string{rbacv1.GroupName},
+					Resources:     []string{"roles"},
+					ResourceNames: []string{"role"},
+				},
+			},
+		},
+	}
+
+	for testName, tc := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			rules := PolicyRules(typeConfigs, tc.scope, "role", tc.rotateCredentials)
+			assert.Equal(t, tc.expectedRules, rules)
+		})
+	}
+}
+
+func newTypeConfig(name, group, kind string, scope apiextv1.ResourceScope, propagation fedv1b1.PropagationMode) *fedv1b1.FederatedTypeConfig {
+	typeConfig := &fedv1b1.FederatedTypeConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: fedv1b1.FederatedTypeConfigSpec{
+			TargetType: fedv1b1.APIResource{
+				Group:   group,
+				Version: "v1",
+				Kind:    kind,
+				Scope:   scope,
+			},
+			Propagation: propagation,
+		},
+	}
+	fedv1b1.SetFederatedTypeConfigDefaults(typeConfig)
+	return typeConfig
+}
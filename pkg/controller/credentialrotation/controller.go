@@ -0,0 +1,466 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentialrotation
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	kubeclientset "k8s.io/client-go/kubernetes"
+	restclient "k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	fedcommon "sigs.k8s.io/kubefed/pkg/apis/core/common"
+	fedv1b1 "sigs.k8s.io/kubefed/pkg/apis/core/v1beta1"
+	genericclient "sigs.k8s.io/kubefed/pkg/client/generic"
+	"sigs.k8s.io/kubefed/pkg/controller/util"
+	"sigs.k8s.io/kubefed/pkg/metrics"
+)
+
+const (
+	userAgentName = "CredentialRotation"
+
+	// RotatedAtAnnotation records when the credentials of a
+	// KubeFedCluster were last rotated. Since controllers rebuild the
+	// clients of a cluster when its annotations change, updating it
+	// ensures that the new credentials are used.
+	RotatedAtAnnotation = "kubefed.io/credentials-rotated-at"
+
+	// RevocationAnnotation identifies the service account token secret,
+	// in the form <namespace>/<name>, that issued the credentials
+	// replaced by the last rotation. It is recorded before the
+	// credentials are replaced, and the secret is deleted, revoking
+	// the credentials, once clients have had time to be rebuilt and
+	// only if the credentials of the cluster were issued by another
+	// secret.
+	RevocationAnnotation = "kubefed.io/credentials-pending-revocation"
+
+	// Reasons of the CredentialsRotated condition.
+	CredentialsRotated  = "CredentialsRotated"
+	RotationFailed      = "RotationFailed"
+	RotationUnsupported = "RotationUnsupported"
+
+	// Time allowed for clients to switch to new credentials before
+	// the replaced credentials are revoked.
+	revocationDelay = time.Minute
+
+	tokenPollInterval = time.Second
+	tokenPollTimeout  = 30 * time.Second
+)
+
+// Controller periodically replaces the service account tokens used by
+// the KubeFed control plane to access member clusters. A new token is
+// issued in the member cluster using the current token, stored in the
+// secret referenced by the KubeFedCluster and, once clients have been
+// rebuilt to use it, the current token is revoked.
+type Controller struct {
+	controllerConfig *util.ControllerConfig
+
+	client genericclient.Client
+
+	// Store and informer for KubeFedCluster objects
+	clusterStore      cache.Store
+	clusterController cache.Controller
+
+	worker util.ReconcileWorker
+}
+
+// StartController starts the Controller for rotating the credentials
+// of member clusters.
+func StartController(config *util.ControllerConfig, stopChan <-chan struct{}) error {
+	controller, err := newController(config)
+	if err != nil {
+		return err
+	}
+	klog.Infof("Starting credential rotation controller")
+	controller.Run(stopChan)
+	return nil
+}
+
+// newController returns a new controller to rotate the credentials of
+// member clusters.
+func newController(config *util.ControllerConfig) (*Controller, error) {
+	kubeConfig := restclient.CopyConfig(config.KubeConfig)
+	restclient.AddUserAgent(kubeConfig, userAgentName)
+	client, err := genericclient.New(kubeConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Controller{
+		controllerConfig: config,
+		client:           client,
+	}
+
+	c.worker = util.NewReconcileWorker("credentialrotation", c.reconcile, util.WorkerOptions{})
+
+	// The status of a cluster is updated by every health check, so
+	// only changes relevant to rotation trigger reconciliation. A
+	// cluster is otherwise reconciled when its rotation is due.
+	c.clusterStore, c.clusterController, err = util.NewGenericInformerWithEventHandler(
+		kubeConfig,
+		config.KubeFedNamespace,
+		&fedv1b1.KubeFedCluster{},
+		util.NoResyncPeriod,
+		&cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) {
+				c.worker.EnqueueObject(obj.(runtimeclient.Object))
+			},
+			UpdateFunc: func(old, cur interface{}) {
+				oldCluster := old.(*fedv1b1.KubeFedCluster)
+				curCluster := cur.(*fedv1b1.KubeFedCluster)
+				if util.IsClusterReady(&oldCluster.Status) != util.IsClusterReady(&curCluster.Status) ||
+					!reflect.DeepEqual(oldCluster.Spec, curCluster.Spec) ||
+					!reflect.DeepEqual(oldCluster.Annotations, curCluster.Annotations) {
+					c.worker.EnqueueObject(curCluster)
+				}
+			},
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// Run runs the Controller.
+func (c *Controller) Run(stopChan <-chan struct{}) {
+	go c.clusterController.Run(stopChan)
+
+	// wait for the cache to synchronize before starting the worker
+	if !cache.WaitForCacheSync(stopChan, c.clusterController.HasSynced) {
+		runtime.HandleError(errors.New("Timed out waiting for cache to sync"))
+		return
+	}
+
+	c.worker.Run(stopChan)
+}
+
+func (c *Controller) reconcile(qualifiedName util.QualifiedName) util.ReconciliationStatus {
+	key := qualifiedName.String()
+	defer metrics.UpdateControllerReconcileDurationFromStart("credentialrotationcontroller", time.Now())
+
+	obj, exists, err := c.clusterStore.GetByKey(key)
+	if err != nil {
+		runtime.HandleError(errors.Wrapf(err, "Failed to query KubeFedCluster store for %q", key))
+		return util.StatusError
+	}
+	if !exists {
+		return util.StatusAllOK
+	}
+	cluster := obj.(*fedv1b1.KubeFedCluster)
+	if cluster.DeletionTimestamp != nil || !util.IsClusterReady(&cluster.Status) || util.IsPullModeCluster(cluster) {
+		return util.StatusAllOK
+	}
+
+	now := time.Now()
+	rotatedAt := lastRotation(cluster)
+
+	if revokedSecret, ok := cluster.Annotations[RevocationAnnotation]; ok {
+		if delay := rotatedAt.Add(revocationDelay).Sub(now); delay > 0 {
+			c.worker.EnqueueWithDelay(qualifiedName, delay)
+			return util.StatusAllOK
+		}
+		klog.V(2).Infof("Revoking replaced credentials of cluster %q", cluster.Name)
+		if err := c.revoke(cluster, revokedSecret); err != nil {
+			c.recordFailure(cluster, errors.Wrap(err, "failed to revoke replaced credentials"))
+			return util.StatusError
+		}
+		c.setCondition(cluster.Name, corev1.ConditionTrue, CredentialsRotated, "Credentials were rotated")
+		return util.StatusAllOK
+	}
+
+	if delay := rotatedAt.Add(c.controllerConfig.CredentialRotationPeriod).Sub(now); delay > 0 {
+		c.worker.EnqueueWithDelay(qualifiedName, delay)
+		return util.StatusAllOK
+	}
+
+	klog.V(2).Infof("Rotating credentials of cluster %q", cluster.Name)
+	unsupported, err := c.rotate(cluster, now)
+	switch {
+	case unsupported != "":
+		c.setCondition(cluster.Name, corev1.ConditionFalse, RotationUnsupported, unsupported)
+		c.worker.EnqueueWithDelay(qualifiedName, c.controllerConfig.CredentialRotationPeriod)
+		return util.StatusAllOK
+	case err != nil:
+		c.recordFailure(cluster, err)
+		return util.StatusError
+	}
+	return util.StatusAllOK
+}
+
+// lastRotation returns when the credentials of the cluster were last
+// rotated, or when the cluster was joined if they never were.
+func lastRotation(cluster *fedv1b1.KubeFedCluster) time.Time {
+	if value, ok := cluster.Annotations[RotatedAtAnnotation]; ok {
+		if rotatedAt, err := time.Parse(time.RFC3339, value); err == nil {
+			return rotatedAt
+		}
+	}
+	return cluster.CreationTimestamp.Time
+}
+
+// rotate issues a new service account token in the member cluster,
+// stores it in the secret referenced by the cluster and marks the
+// replaced token for revocation. A non-empty message is returned if
+// the credentials of the cluster cannot be rotated.
+func (c *Controller) rotate(cluster *fedv1b1.KubeFedCluster, now time.Time) (string, error) {
+	if cluster.Spec.Exec != nil || cluster.Spec.SecretRef.Name == "" {
+		return "Credentials provided by an exec plugin are not rotated", nil
+	}
+
+	secret := &corev1.Secret{}
+	err := c.client.Get(context.TODO(), secret, c.controllerConfig.KubeFedNamespace, cluster.Spec.SecretRef.Name)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get secret %q", cluster.Spec.SecretRef.Name)
+	}
+	token, ok := secret.Data[util.TokenKey]
+	if !ok {
+		return "Only service account token credentials are rotated", nil
+	}
+	claims, err := parseTokenClaims(string(token))
+	if err != nil {
+		return fmt.Sprintf("The service account token cannot be rotated: %v", err), nil
+	}
+
+	clusterConfig, err := util.BuildClusterConfig(cluster, c.client, c.controllerConfig.KubeFedNamespace)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to build cluster config")
+	}
+	restclient.AddUserAgent(clusterConfig, userAgentName)
+	clientset, err := kubeclientset.NewForConfig(clusterConfig)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to create cluster client")
+	}
+
+	tokenSecret, newToken, err := issueToken(clientset, claims)
+	if err != nil {
+		return "", err
+	}
+
+	// Ensure the new token is accepted before relying on it.
+	newConfig := restclient.CopyConfig(clusterConfig)
+	newConfig.BearerToken = string(newToken)
+	newConfig.BearerTokenFile = ""
+	newClientset, err := kubeclientset.NewForConfig(newConfig)
+	if err == nil {
+		_, err = newClientset.CoreV1().Secrets(claims.Namespace).Get(context.TODO(), tokenSecret.Name, metav1.GetOptions{})
+	}
+	// Record the token to revoke before replacing it so that it is
+	// revoked even if the rotation is interrupted once replaced.
+	if err == nil {
+		err = c.updateAnnotations(cluster.Name, func(annotations map[string]string) {
+			annotations[RotatedAtAnnotation] = now.UTC().Format(time.RFC3339)
+			annotations[RevocationAnnotation] = claims.secretKey()
+		})
+	}
+	if err == nil {
+		err = retry.OnError(retry.DefaultBackoff, func(error) bool { return true }, func() error {
+			current := &corev1.Secret{}
+			err := c.client.Get(context.TODO(), current, secret.Namespace, secret.Name)
+			if err != nil {
+				return err
+			}
+			current.Data[util.TokenKey] = newToken
+			return c.client.Update(context.TODO(), current)
+		})
+		if err != nil {
+			// The replaced token is still in use and must not be revoked.
+			clearErr := c.updateAnnotations(cluster.Name, func(annotations map[string]string) {
+				delete(annotations, RevocationAnnotation)
+			})
+			if clearErr != nil {
+				klog.Errorf("Failed to clear the pending revocation of cluster %q: %v", cluster.Name, clearErr)
+			}
+		}
+	}
+	if err != nil {
+		deleteErr := clientset.CoreV1().Secrets(claims.Namespace).Delete(context.TODO(), tokenSecret.Name, metav1.DeleteOptions{})
+		if deleteErr != nil && !apierrors.IsNotFound(deleteErr) {
+			klog.Errorf("Failed to delete unused token secret %s/%s of cluster %q: %v", claims.Namespace, tokenSecret.Name, cluster.Name, deleteErr)
+		}
+		return "", errors.Wrap(err, "failed to replace service account token")
+	}
+
+	// Update the rotation time again so that clients are rebuilt with
+	// the new token and the revocation is delayed from the replacement.
+	err = c.updateAnnotations(cluster.Name, func(annotations map[string]string) {
+		annotations[RotatedAtAnnotation] = time.Now().UTC().Format(time.RFC3339)
+	})
+	return "", errors.Wrap(err, "failed to record rotation")
+}
+
+// issueToken creates a service account token secret for the service
+// account identified by the given claims and waits for the token to
+// be populated.
+func issueToken(clientset kubeclientset.Interface, claims *tokenClaims) (*corev1.Secret, []byte, error) {
+	tokenSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: fmt.Sprintf("%s-token-", claims.ServiceAccountName),
+			Namespace:    claims.Namespace,
+			Annotations: map[string]string{
+				corev1.ServiceAccountNameKey: claims.ServiceAccountName,
+			},
+		},
+		Type: corev1.SecretTypeServiceAccountToken,
+	}
+	tokenSecret, err := clientset.CoreV1().Secrets(claims.Namespace).Create(context.TODO(), tokenSecret, metav1.CreateOptions{})
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to create service account token secret")
+	}
+
+	var token []byte
+	err = wait.PollImmediate(tokenPollInterval, tokenPollTimeout, func() (bool, error) {
+		secret, err := clientset.CoreV1().Secrets(claims.Namespace).Get(context.TODO(), tokenSecret.Name, metav1.GetOptions{})
+		if err != nil {
+			return false, nil
+		}
+		token = secret.Data[corev1.ServiceAccountTokenKey]
+		return len(token) > 0, nil
+	})
+	if err != nil {
+		deleteErr := clientset.CoreV1().Secrets(claims.Namespace).Delete(context.TODO(), tokenSecret.Name, metav1.DeleteOptions{})
+		if deleteErr != nil && !apierrors.IsNotFound(deleteErr) {
+			klog.Errorf("Failed to delete unused token secret %s/%s: %v", claims.Namespace, tokenSecret.Name, deleteErr)
+		}
+		return nil, nil, errors.Wrapf(err, "timed out waiting for token of secret %s/%s", claims.Namespace, tokenSecret.Name)
+	}
+	return tokenSecret, token, nil
+}
+
+// revoke deletes the service account token secret that issued the
+// replaced credentials of the cluster, using its current credentials.
+// The secret is not deleted if the current credentials of the cluster
+// were issued by it, which is the case if the rotation was interrupted
+// before they were replaced.
+func (c *Controller) revoke(cluster *fedv1b1.KubeFedCluster, revokedSecret string) error {
+	inUse, err := c.issuedCredentials(cluster, revokedSecret)
+	if err != nil {
+		return err
+	}
+	parts := strings.SplitN(revokedSecret, "/", 2)
+	if inUse {
+		klog.Warningf("Not revoking credentials of cluster %q issued by secret %q since they were not replaced", cluster.Name, revokedSecret)
+	} else if len(parts) == 2 {
+		clusterConfig, err := util.BuildClusterConfig(cluster, c.client, c.controllerConfig.KubeFedNamespace)
+		if err != nil {
+			return errors.Wrap(err, "failed to build cluster config")
+		}
+		clientset, err := kubeclientset.NewForConfig(restclient.AddUserAgent(clusterConfig, userAgentName))
+		if err != nil {
+			return errors.Wrap(err, "failed to create cluster client")
+		}
+		err = clientset.CoreV1().Secrets(parts[0]).Delete(context.TODO(), parts[1], metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return c.updateAnnotations(cluster.Name, func(annotations map[string]string) {
+		delete(annotations, RevocationAnnotation)
+	})
+}
+
+// issuedCredentials returns whether the current credentials of the
+// cluster were issued by the given service account token secret.
+func (c *Controller) issuedCredentials(cluster *fedv1b1.KubeFedCluster, tokenSecret string) (bool, error) {
+	secret := &corev1.Secret{}
+	err := c.client.Get(context.TODO(), secret, c.controllerConfig.KubeFedNamespace, cluster.Spec.SecretRef.Name)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to get secret %q", cluster.Spec.SecretRef.Name)
+	}
+	claims, err := parseTokenClaims(string(secret.Data[util.TokenKey]))
+	if err != nil {
+		return false, nil
+	}
+	return claims.secretKey() == tokenSecret, nil
+}
+
+func (c *Controller) updateAnnotations(clusterName string, update func(map[string]string)) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cluster := &fedv1b1.KubeFedCluster{}
+		err := c.client.Get(context.TODO(), cluster, c.controllerConfig.KubeFedNamespace, clusterName)
+		if err != nil {
+			return err
+		}
+		if cluster.Annotations == nil {
+			cluster.Annotations = make(map[string]string)
+		}
+		update(cluster.Annotations)
+		return c.client.Update(context.TODO(), cluster)
+	})
+}
+
+func (c *Controller) recordFailure(cluster *fedv1b1.KubeFedCluster, err error) {
+	runtime.HandleError(errors.Wrapf(err, "Failed to rotate credentials of cluster %q", cluster.Name))
+	c.setCondition(cluster.Name, corev1.ConditionFalse, RotationFailed, err.Error())
+}
+
+// setCondition records the outcome of rotation as the
+// CredentialsRotated condition of the named cluster.
+func (c *Controller) setCondition(clusterName string, status corev1.ConditionStatus, reason, message string) {
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cluster := &fedv1b1.KubeFedCluster{}
+		err := c.client.Get(context.TODO(), cluster, c.controllerConfig.KubeFedNamespace, clusterName)
+		if err != nil {
+			return err
+		}
+		setClusterCondition(&cluster.Status, status, reason, message, metav1.Now())
+		return c.client.UpdateStatus(context.TODO(), cluster)
+	})
+	if err != nil {
+		klog.Warningf("Failed to update the status of cluster %q: %v", clusterName, err)
+	}
+}
+
+// setClusterCondition sets the CredentialsRotated condition of the
+// given status, preserving its transition time if the condition status
+// is unchanged.
+func setClusterCondition(clusterStatus *fedv1b1.KubeFedClusterStatus, status corev1.ConditionStatus, reason, message string, now metav1.Time) {
+	condition := fedv1b1.ClusterCondition{
+		Type:               fedcommon.ClusterCredentialsRotated,
+		Status:             status,
+		LastProbeTime:      now,
+		LastTransitionTime: &now,
+		Reason:             &reason,
+		Message:            &message,
+	}
+	for i, existing := range clusterStatus.Conditions {
+		if existing.Type != fedcommon.ClusterCredentialsRotated {
+			continue
+		}
+		if existing.Status == status && existing.LastTransitionTime != nil {
+			condition.LastTransitionTime = existing.LastTransitionTime
+		}
+		clusterStatus.Conditions[i] = condition
+		return
+	}
+	clusterStatus.Conditions = append(clusterStatus.Conditions, condition)
+}
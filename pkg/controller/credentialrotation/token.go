@@ -0,0 +1,63 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentialrotation
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// tokenClaims identifies the service account token secret from which
+// a legacy service account token was issued.
+type tokenClaims struct {
+	Namespace          string `json:"kubernetes.io/serviceaccount/namespace"`
+	SecretName         string `json:"kubernetes.io/serviceaccount/secret.name"`
+	ServiceAccountName string `json:"kubernetes.io/serviceaccount/service-account.name"`
+}
+
+// secretKey returns the secret that issued the token in the form
+// <namespace>/<name>.
+func (c *tokenClaims) secretKey() string {
+	return c.Namespace + "/" + c.SecretName
+}
+
+// parseTokenClaims returns the claims of a legacy service account
+// token. The signature of the token is not verified since the token is
+// only used to locate the secret it was issued from. Tokens that were
+// not issued from a secret, such as bound tokens, cannot be rotated by
+// replacing the secret and are rejected.
+func parseTokenClaims(token string) (*tokenClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("token is not a JSON Web Token")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode token payload")
+	}
+	claims := &tokenClaims{}
+	if err := json.Unmarshal(payload, claims); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal token claims")
+	}
+	if claims.Namespace == "" || claims.SecretName == "" || claims.ServiceAccountName == "" {
+		return nil, errors.New("token was not issued from a service account token secret")
+	}
+	return claims, nil
+}
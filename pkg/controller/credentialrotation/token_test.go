@@ -0,0 +1,73 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentialrotation
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTokenClaims(t *testing.T) {
+	testCases := map[string]struct {
+		token          string
+		expectedClaims *tokenClaims
+		expectedErr    bool
+	}{
+		"Legacy service account token": {
+			token: newToken(`{"iss":"kubernetes/serviceaccount",` +
+				`"kubernetes.io/serviceaccount/namespace":"kube-federation-system",` +
+				`"kubernetes.io/serviceaccount/secret.name":"cluster1-host",` +
+				`"kubernetes.io/serviceaccount/service-account.name":"cluster1-host"}`),
+			expectedClaims: &tokenClaims{
+				Namespace:          "kube-federation-system",
+				SecretName:         "cluster1-host",
+				ServiceAccountName: "cluster1-host",
+			},
+		},
+		"Bound service account token": {
+			token: newToken(`{"iss":"https://kubernetes.default.svc",` +
+				`"kubernetes.io":{"namespace":"kube-federation-system","serviceaccount":{"name":"cluster1-host"}}}`),
+			expectedErr: true,
+		},
+		"Malformed payload": {
+			token:       "header.!payload.signature",
+			expectedErr: true,
+		},
+		"Opaque token": {
+			token:       "opaque-token",
+			expectedErr: true,
+		},
+	}
+
+	for testName, tc := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			claims, err := parseTokenClaims(tc.token)
+			if tc.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedClaims, claims)
+		})
+	}
+}
+
+func newToken(payload string) string {
+	return "eyJhbGciOiJSUzI1NiJ9." + base64.RawURLEncoding.EncodeToString([]byte(payload)) + ".signature"
+}
@@ -25,10 +25,12 @@ import (
 	"github.com/pkg/errors"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
 	kubeclientset "k8s.io/client-go/kubernetes"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	restclient "k8s.io/client-go/rest"
 	"k8s.io/klog/v2"
 
@@ -37,6 +39,7 @@ import (
 	"sigs.k8s.io/kubefed/pkg/client/generic"
 	"sigs.k8s.io/kubefed/pkg/controller/util"
 	"sigs.k8s.io/kubefed/pkg/metrics"
+	"sigs.k8s.io/kubefed/pkg/tunnel"
 )
 
 const (
@@ -57,6 +60,16 @@ const (
 	ClusterReachableMsg          = "cluster is reachable"
 	ClusterConfigMalformedReason = "ClusterConfigMalformed"
 	ClusterConfigMalformedMsg    = "cluster's configuration may be malformed"
+	AgentNotReportingReason      = "AgentNotReporting"
+	AgentNotReportingMsg         = "the agent of the pull-mode cluster has not reported the health of the cluster"
+	TunnelConnectedReason        = "TunnelConnected"
+	TunnelConnectedMsg           = "the connector of the cluster has opened the tunnel"
+	TunnelDisconnectedReason     = "TunnelDisconnected"
+	TunnelDisconnectedMsg        = "the connector of the cluster has not opened the tunnel"
+
+	// Listing all pods is more expensive than the rest of the health
+	// check, so the resource summary is collected less often.
+	resourceSummaryPeriod = time.Minute
 )
 
 // ClusterClient provides methods for determining the status and zones of a
@@ -64,13 +77,22 @@ const (
 type ClusterClient struct {
 	kubeClient  *kubeclientset.Clientset
 	clusterName string
+	// Whether the cluster is accessed through a reverse tunnel
+	tunneled bool
+
+	// The last collected resource summary and when it was collected
+	resourceSummary          *fedv1b1.ClusterResourceSummary
+	resourceSummaryCollected time.Time
 }
 
 // NewClusterClientSet returns a ClusterClient for the given KubeFedCluster.
 // The kubeClient is used to configure the ClusterClient's internal client
 // with information from a kubeconfig stored in a kubernetes secret.
 func NewClusterClientSet(c *fedv1b1.KubeFedCluster, client generic.Client, fedNamespace string, timeout time.Duration) (*ClusterClient, error) {
-	var clusterClientSet = ClusterClient{clusterName: c.Name}
+	var clusterClientSet = ClusterClient{
+		clusterName: c.Name,
+		tunneled:    c.Spec.Transport == fedv1b1.ClusterTransportTunnel,
+	}
 	clusterConfig, err := util.BuildClusterConfig(c, client, fedNamespace)
 	if err != nil {
 		return &clusterClientSet, err
@@ -80,6 +102,16 @@ func NewClusterClientSet(c *fedv1b1.KubeFedCluster, client generic.Client, fedNa
 	return &clusterClientSet, err
 }
 
+// NewClusterClientForConfig returns a ClusterClient that accesses the
+// named cluster with the given configuration. It allows the agent of a
+// pull-mode cluster to check the health of the cluster it runs in.
+func NewClusterClientForConfig(config *restclient.Config, clusterName string) (*ClusterClient, error) {
+	var clusterClientSet = ClusterClient{clusterName: clusterName}
+	var err error
+	clusterClientSet.kubeClient, err = kubeclientset.NewForConfig(restclient.AddUserAgent(restclient.CopyConfig(config), UserAgentName))
+	return &clusterClientSet, err
+}
+
 // GetClusterStatus gets the kubernetes cluster's health and version status
 func (c *ClusterClient) GetClusterStatus() (*fedv1b1.KubeFedClusterStatus, error) {
 	clusterStatus := fedv1b1.KubeFedClusterStatus{}
@@ -139,6 +171,16 @@ func (c *ClusterClient) GetClusterStatus() (*fedv1b1.KubeFedClusterStatus, error
 		metrics.RegisterKubefedClusterTotal(metrics.ClusterNotReady, c.clusterName)
 		return &clusterStatus, nil
 	}
+	// The connectivity of the tunnel of a cluster is reported
+	// separately from the health of the API server reached through it.
+	if c.tunneled && !tunnel.Connected(c.clusterName) {
+		tunnelCondition := tunnelConnectedCondition(false, currentTime)
+		newClusterOfflineCondition.Reason = tunnelCondition.Reason
+		newClusterOfflineCondition.Message = tunnelCondition.Message
+		clusterStatus.Conditions = append(clusterStatus.Conditions, newClusterOfflineCondition, tunnelCondition)
+		metrics.RegisterKubefedClusterTotal(metrics.ClusterOffline, c.clusterName)
+		return &clusterStatus, nil
+	}
 	body, err := c.kubeClient.DiscoveryClient.RESTClient().Get().AbsPath("/healthz").Do(context.Background()).Raw()
 	if err != nil {
 		runtime.HandleError(errors.Wrapf(err, "Failed to do cluster health check for cluster %q", c.clusterName))
@@ -160,23 +202,88 @@ func (c *ClusterClient) GetClusterStatus() (*fedv1b1.KubeFedClusterStatus, error
 			} else {
 				clusterStatus.KubernetesVersion = version.GitVersion
 			}
+
+			c.collectNodeStatus(c.kubeClient.CoreV1(), &clusterStatus, currentTime.Time)
 		}
 	}
+	if c.tunneled {
+		clusterStatus.Conditions = append(clusterStatus.Conditions, tunnelConnectedCondition(true, currentTime))
+	}
 
 	return &clusterStatus, err
 }
 
-// GetClusterZones gets the kubernetes cluster zones and region by inspecting labels on nodes in the cluster.
-func (c *ClusterClient) GetClusterZones() ([]string, string, error) {
-	nodes, err := c.kubeClient.CoreV1().Nodes().List(context.Background(), metav1.ListOptions{})
-	if err != nil {
-		klog.Errorf("Failed to list nodes while getting zone names: %v", err)
-		return nil, "", err
+// tunnelConnectedCondition returns the condition reporting whether the
+// tunnel of a cluster is connected.
+func tunnelConnectedCondition(connected bool, probeTime metav1.Time) fedv1b1.ClusterCondition {
+	status := corev1.ConditionTrue
+	reason := TunnelConnectedReason
+	msg := TunnelConnectedMsg
+	if !connected {
+		status = corev1.ConditionFalse
+		reason = TunnelDisconnectedReason
+		msg = TunnelDisconnectedMsg
+	}
+	return fedv1b1.ClusterCondition{
+		Type:               fedcommon.ClusterTunnelConnected,
+		Status:             status,
+		Reason:             &reason,
+		Message:            &msg,
+		LastProbeTime:      probeTime,
+		LastTransitionTime: &probeTime,
+	}
+}
+
+// collectNodeStatus sets the zones, region and resource summary of the
+// cluster in the given status from a single list of its nodes. The
+// resource summary additionally requires listing the pods of the
+// cluster, so it is only collected again once resourceSummaryPeriod
+// has elapsed. The topology and resources of the cluster cannot be
+// determined by a namespace-scoped control plane, which may not list
+// nodes and pods.
+func (c *ClusterClient) collectNodeStatus(client typedcorev1.CoreV1Interface, clusterStatus *fedv1b1.KubeFedClusterStatus, now time.Time) {
+	// Lists are served from the watch cache of the API server.
+	nodes, err := client.Nodes().List(context.Background(), metav1.ListOptions{ResourceVersion: "0"})
+	switch {
+	case apierrors.IsForbidden(err):
+		klog.V(4).Infof("Not permitted to list the nodes of cluster %q: %v", c.clusterName, err)
+		return
+	case err != nil:
+		runtime.HandleError(errors.Wrapf(err, "Failed to list the nodes of cluster %q", c.clusterName))
+		return
+	}
+
+	zones, region := clusterZones(nodes.Items)
+	clusterStatus.Zones = zones
+	if region != "" {
+		clusterStatus.Region = &region
+	}
+
+	if c.resourceSummary == nil || now.Sub(c.resourceSummaryCollected) >= resourceSummaryPeriod {
+		pods, err := client.Pods(metav1.NamespaceAll).List(context.Background(), metav1.ListOptions{
+			ResourceVersion: "0",
+			FieldSelector:   "status.phase!=Succeeded,status.phase!=Failed",
+		})
+		switch {
+		case apierrors.IsForbidden(err):
+			klog.V(4).Infof("Not permitted to list the pods of cluster %q: %v", c.clusterName, err)
+			return
+		case err != nil:
+			runtime.HandleError(errors.Wrapf(err, "Failed to collect the resource summary of cluster %q", c.clusterName))
+			return
+		}
+		c.resourceSummary = summarizeResources(nodes.Items, pods.Items)
+		c.resourceSummaryCollected = now
 	}
+	clusterStatus.ResourceSummary = c.resourceSummary
+}
 
+// clusterZones returns the zones and region of the cluster with the
+// given nodes by inspecting the labels of the nodes.
+func clusterZones(nodes []corev1.Node) ([]string, string) {
 	zones := sets.New[string]()
 	region := ""
-	for i, node := range nodes.Items {
+	for i, node := range nodes {
 		zone := getZoneNameForNode(node)
 		// region is same for all nodes in the cluster, so just pick the region from first node.
 		if i == 0 {
@@ -186,25 +293,66 @@ func (c *ClusterClient) GetClusterZones() ([]string, string, error) {
 			zones.Insert(zone)
 		}
 	}
-	return sets.List(zones), region, nil
+	return sets.List(zones), region
+}
+
+// summarizeResources returns the resource summary of the given nodes and
+// of the pods running on them.
+func summarizeResources(nodes []corev1.Node, pods []corev1.Pod) *fedv1b1.ClusterResourceSummary {
+	summary := &fedv1b1.ClusterResourceSummary{
+		Allocatable: corev1.ResourceList{},
+		Requested:   corev1.ResourceList{},
+	}
+	nodeNames := sets.New[string]()
+	for _, node := range nodes {
+		if node.Spec.Unschedulable || !isNodeReady(node) {
+			continue
+		}
+		nodeNames.Insert(node.Name)
+		summary.Nodes++
+		for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+			if quantity, ok := node.Status.Allocatable[name]; ok {
+				total := summary.Allocatable[name]
+				total.Add(quantity)
+				summary.Allocatable[name] = total
+			}
+		}
+	}
+	for i := range pods {
+		pod := &pods[i]
+		if !nodeNames.Has(pod.Spec.NodeName) || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		for name, quantity := range util.PodRequests(&pod.Spec) {
+			total := summary.Requested[name]
+			total.Add(quantity)
+			summary.Requested[name] = total
+		}
+	}
+	return summary
+}
+
+func isNodeReady(node corev1.Node) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
 }
 
 // Find the name of the zone in which a Node is running.
 func getZoneNameForNode(node corev1.Node) string {
-	for key, value := range node.Labels {
-		if key == LabelZoneFailureDomain {
-			return value
-		}
+	if zone, ok := node.Labels[corev1.LabelTopologyZone]; ok {
+		return zone
 	}
-	return ""
+	return node.Labels[LabelZoneFailureDomain]
 }
 
 // Find the name of the region in which a Node is running.
 func getRegionNameForNode(node corev1.Node) string {
-	for key, value := range node.Labels {
-		if key == LabelZoneRegion {
-			return value
-		}
+	if region, ok := node.Labels[corev1.LabelTopologyRegion]; ok {
+		return region
 	}
-	return ""
+	return node.Labels[LabelZoneRegion]
 }
@@ -0,0 +1,225 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubefedcluster
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubeclientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	restclient "k8s.io/client-go/rest"
+	clienttesting "k8s.io/client-go/testing"
+
+	"sigs.k8s.io/kubefed/pkg/apis/core/common"
+	fedv1b1 "sigs.k8s.io/kubefed/pkg/apis/core/v1beta1"
+)
+
+func TestGetClusterStatusTunnelDisconnected(t *testing.T) {
+	kubeClient, err := kubeclientset.NewForConfig(&restclient.Config{Host: "https://cluster1.example.com"})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	clusterClient := &ClusterClient{
+		kubeClient:  kubeClient,
+		clusterName: "cluster1",
+		tunneled:    true,
+	}
+
+	clusterStatus, err := clusterClient.GetClusterStatus()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := map[common.ClusterConditionType]corev1.ConditionStatus{
+		common.ClusterOffline:         corev1.ConditionTrue,
+		common.ClusterTunnelConnected: corev1.ConditionFalse,
+	}
+	if len(clusterStatus.Conditions) != len(expected) {
+		t.Fatalf("Expected %d conditions, got %v", len(expected), clusterStatus.Conditions)
+	}
+	for _, condition := range clusterStatus.Conditions {
+		if condition.Status != expected[condition.Type] {
+			t.Errorf("Expected condition %q to be %q, got %q", condition.Type, expected[condition.Type], condition.Status)
+		}
+		if condition.Reason == nil || *condition.Reason != TunnelDisconnectedReason {
+			t.Errorf("Expected reason %q for condition %q, got %v", TunnelDisconnectedReason, condition.Type, condition.Reason)
+		}
+	}
+}
+
+func TestCollectNodeStatus(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "node1",
+			Labels: map[string]string{
+				corev1.LabelTopologyZone:   "zone1",
+				corev1.LabelTopologyRegion: "region1",
+			},
+		},
+		Status: corev1.NodeStatus{
+			Allocatable: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("4")},
+			Conditions:  []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}},
+		},
+	}
+	now := time.Now()
+
+	testCases := map[string]struct {
+		collectedAgo   time.Duration
+		podsForbidden  bool
+		expectPodList  bool
+		expectedNodes  int32
+		expectsSummary bool
+	}{
+		"Summary is collected on the first check": {
+			expectPodList:  true,
+			expectedNodes:  1,
+			expectsSummary: true,
+		},
+		"Summary is reused within the collection period": {
+			collectedAgo:   resourceSummaryPeriod / 2,
+			expectedNodes:  7,
+			expectsSummary: true,
+		},
+		"Summary is collected again after the collection period": {
+			collectedAgo:   resourceSummaryPeriod,
+			expectPodList:  true,
+			expectedNodes:  1,
+			expectsSummary: true,
+		},
+		"Zones are reported if pods may not be listed": {
+			podsForbidden: true,
+			expectPodList: true,
+		},
+	}
+
+	for testName, tc := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			client := fake.NewSimpleClientset(node)
+			if tc.podsForbidden {
+				client.PrependReactor("list", "pods", func(clienttesting.Action) (bool, runtime.Object, error) {
+					return true, nil, apierrors.NewForbidden(corev1.Resource("pods"), "", errors.New("denied"))
+				})
+			}
+			clusterClient := &ClusterClient{clusterName: "cluster1"}
+			if tc.collectedAgo != 0 {
+				clusterClient.resourceSummary = &fedv1b1.ClusterResourceSummary{Nodes: 7}
+				clusterClient.resourceSummaryCollected = now.Add(-tc.collectedAgo)
+			}
+
+			clusterStatus := &fedv1b1.KubeFedClusterStatus{}
+			clusterClient.collectNodeStatus(client.CoreV1(), clusterStatus, now)
+
+			if len(clusterStatus.Zones) != 1 || clusterStatus.Zones[0] != "zone1" {
+				t.Errorf("Expected zones [zone1], got %v", clusterStatus.Zones)
+			}
+			if clusterStatus.Region == nil || *clusterStatus.Region != "region1" {
+				t.Errorf("Expected region region1, got %v", clusterStatus.Region)
+			}
+			podsListed := false
+			for _, action := range client.Actions() {
+				if action.Matches("list", "pods") {
+					podsListed = true
+				}
+			}
+			if podsListed != tc.expectPodList {
+				t.Errorf("Expected pods to be listed: %v, got %v", tc.expectPodList, podsListed)
+			}
+			if !tc.expectsSummary {
+				if clusterStatus.ResourceSummary != nil {
+					t.Errorf("Expected no resource summary, got %v", clusterStatus.ResourceSummary)
+				}
+				return
+			}
+			if clusterStatus.ResourceSummary == nil || clusterStatus.ResourceSummary.Nodes != tc.expectedNodes {
+				t.Errorf("Expected a resource summary of %d nodes, got %v", tc.expectedNodes, clusterStatus.ResourceSummary)
+			}
+		})
+	}
+}
+
+func TestSummarizeResources(t *testing.T) {
+	node := func(name string, ready corev1.ConditionStatus, unschedulable bool) corev1.Node {
+		return corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       corev1.NodeSpec{Unschedulable: unschedulable},
+			Status: corev1.NodeStatus{
+				Allocatable: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("4"),
+					corev1.ResourceMemory: resource.MustParse("16Gi"),
+				},
+				Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: ready}},
+			},
+		}
+	}
+	pod := func(nodeName string, phase corev1.PodPhase) corev1.Pod {
+		return corev1.Pod{
+			Spec: corev1.PodSpec{
+				NodeName: nodeName,
+				Containers: []corev1.Container{{
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("500m"),
+							corev1.ResourceMemory: resource.MustParse("1Gi"),
+						},
+					},
+				}},
+			},
+			Status: corev1.PodStatus{Phase: phase},
+		}
+	}
+
+	nodes := []corev1.Node{
+		node("ready", corev1.ConditionTrue, false),
+		node("also-ready", corev1.ConditionTrue, false),
+		node("not-ready", corev1.ConditionFalse, false),
+		node("cordoned", corev1.ConditionTrue, true),
+	}
+	pods := []corev1.Pod{
+		pod("ready", corev1.PodRunning),
+		pod("also-ready", corev1.PodRunning),
+		pod("ready", corev1.PodSucceeded),
+		pod("cordoned", corev1.PodRunning),
+		pod("", corev1.PodPending),
+	}
+
+	summary := summarizeResources(nodes, pods)
+	if summary.Nodes != 2 {
+		t.Errorf("Expected 2 nodes, got %d", summary.Nodes)
+	}
+	expected := map[string]corev1.ResourceList{
+		"allocatable": {corev1.ResourceCPU: resource.MustParse("8"), corev1.ResourceMemory: resource.MustParse("32Gi")},
+		"requested":   {corev1.ResourceCPU: resource.MustParse("1"), corev1.ResourceMemory: resource.MustParse("2Gi")},
+	}
+	actual := map[string]corev1.ResourceList{
+		"allocatable": summary.Allocatable,
+		"requested":   summary.Requested,
+	}
+	for kind, resources := range expected {
+		for name, quantity := range resources {
+			if actualQuantity := actual[kind][name]; actualQuantity.Cmp(quantity) != 0 {
+				t.Errorf("Expected %s %s %s, got %s", kind, name, quantity.String(), actualQuantity.String())
+			}
+		}
+	}
+}
@@ -35,6 +35,7 @@ import (
 	"k8s.io/klog/v2"
 	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 
+	fedcommon "sigs.k8s.io/kubefed/pkg/apis/core/common"
 	fedv1b1 "sigs.k8s.io/kubefed/pkg/apis/core/v1beta1"
 	genericclient "sigs.k8s.io/kubefed/pkg/client/generic"
 	genscheme "sigs.k8s.io/kubefed/pkg/client/generic/scheme"
@@ -173,6 +174,10 @@ func (cc *ClusterController) delFromClusterSet(obj *fedv1b1.KubeFedCluster) {
 
 // addToClusterSet creates a new client for the cluster and stores it in cluster data map.
 func (cc *ClusterController) addToClusterSet(obj *fedv1b1.KubeFedCluster) {
+	if util.IsPullModeCluster(obj) {
+		// A pull-mode cluster is not accessed by the control plane.
+		return
+	}
 	cc.mu.Lock()
 	defer cc.mu.Unlock()
 	clusterData := cc.clusterDataMap[obj.Name]
@@ -213,8 +218,13 @@ func (cc *ClusterController) updateClusterStatus() error {
 
 	var wg sync.WaitGroup
 	for _, obj := range clusters.Items {
-		cc.mu.RLock()
 		cluster := obj.DeepCopy()
+		if util.IsPullModeCluster(cluster) {
+			wg.Add(1)
+			go cc.updatePullClusterStatus(cluster, &wg)
+			continue
+		}
+		cc.mu.RLock()
 		clusterData := cc.clusterDataMap[cluster.Name]
 		cc.mu.RUnlock()
 		if clusterData == nil || clusterData.clusterKubeClient.kubeClient == nil {
@@ -252,7 +262,10 @@ func (cc *ClusterController) updateIndividualClusterStatus(cluster *fedv1b1.Kube
 	currentClusterStatus = thresholdAdjustedClusterStatus(currentClusterStatus, storedData, cc.clusterHealthCheckConfig)
 
 	storedData.clusterStatus = currentClusterStatus
-	cluster.Status = *currentClusterStatus
+	// Conditions maintained by other controllers are retained.
+	retainedConditions := unmonitoredConditions(cluster.Status.Conditions)
+	cluster.Status = *currentClusterStatus.DeepCopy()
+	cluster.Status.Conditions = append(cluster.Status.Conditions, retainedConditions...)
 	if err := cc.client.UpdateStatus(context.TODO(), cluster); err != nil {
 		klog.Warningf("Failed to update the status of cluster %q: %v", cluster.Name, err)
 	}
@@ -260,6 +273,61 @@ func (cc *ClusterController) updateIndividualClusterStatus(cluster *fedv1b1.Kube
 	wg.Done()
 }
 
+// updatePullClusterStatus marks a pull-mode cluster offline once its
+// agent has stopped reporting the health of the cluster.
+func (cc *ClusterController) updatePullClusterStatus(cluster *fedv1b1.KubeFedCluster, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	clusterStatus := pullClusterStatus(cluster, cc.clusterHealthCheckConfig, metav1.Now())
+	if clusterStatus == nil {
+		return
+	}
+	klog.Warningf("The agent of cluster %q has not reported the health of the cluster", cluster.Name)
+	cluster.Status = *clusterStatus
+	if err := cc.client.UpdateStatus(context.TODO(), cluster); err != nil {
+		klog.Warningf("Failed to update the status of cluster %q: %v", cluster.Name, err)
+	}
+}
+
+// pullClusterStatus returns the status of a pull-mode cluster whose
+// agent has not reported the health of the cluster within the time the
+// health check of a push-mode cluster would take to declare the cluster
+// offline, and nil if the status does not need to be updated.
+func pullClusterStatus(cluster *fedv1b1.KubeFedCluster, clusterHealthCheckConfig *util.ClusterHealthCheckConfig,
+	now metav1.Time) *fedv1b1.KubeFedClusterStatus {
+	// A cluster whose agent has never reported is given the same grace
+	// period from its creation.
+	lastReported := cluster.CreationTimestamp
+	for _, condition := range cluster.Status.Conditions {
+		switch condition.Type {
+		case fedcommon.ClusterReady:
+			lastReported = condition.LastProbeTime
+		case fedcommon.ClusterOffline:
+			if condition.Reason != nil && *condition.Reason == AgentNotReportingReason {
+				return nil
+			}
+		}
+	}
+	gracePeriod := time.Duration(clusterHealthCheckConfig.FailureThreshold)*clusterHealthCheckConfig.Period +
+		clusterHealthCheckConfig.Timeout
+	if now.Sub(lastReported.Time) <= gracePeriod {
+		return nil
+	}
+
+	reason := AgentNotReportingReason
+	msg := AgentNotReportingMsg
+	clusterStatus := cluster.Status.DeepCopy()
+	clusterStatus.Conditions = append([]fedv1b1.ClusterCondition{{
+		Type:               fedcommon.ClusterOffline,
+		Status:             corev1.ConditionTrue,
+		Reason:             &reason,
+		Message:            &msg,
+		LastProbeTime:      now,
+		LastTransitionTime: &now,
+	}}, unmonitoredConditions(cluster.Status.Conditions)...)
+	return clusterStatus
+}
+
 func (cc *ClusterController) RecordError(cluster runtimeclient.Object, errorCode string, err error) {
 	cc.eventRecorder.Eventf(cluster, corev1.EventTypeWarning, errorCode, err.Error())
 }
@@ -297,6 +365,41 @@ func thresholdAdjustedClusterStatus(clusterStatus *fedv1b1.KubeFedClusterStatus,
 	return clusterStatus
 }
 
+// unmonitoredConditions returns the conditions that are not determined
+// by the cluster health check.
+func unmonitoredConditions(conditions []fedv1b1.ClusterCondition) []fedv1b1.ClusterCondition {
+	var retained []fedv1b1.ClusterCondition
+	for _, condition := range conditions {
+		switch condition.Type {
+		case fedcommon.ClusterReady, fedcommon.ClusterOffline, fedcommon.ClusterConfigMalformed,
+			fedcommon.ClusterTunnelConnected:
+			continue
+		}
+		retained = append(retained, condition)
+	}
+	return retained
+}
+
+// AgentClusterStatus returns the status reported by the agent of a
+// pull-mode cluster given the current status of the cluster and the
+// status determined by probing the cluster. Conditions maintained by
+// controllers of the control plane are retained, as is the transition
+// time of conditions whose status has not changed.
+func AgentClusterStatus(current, probed *fedv1b1.KubeFedClusterStatus) *fedv1b1.KubeFedClusterStatus {
+	clusterStatus := probed.DeepCopy()
+	for i, condition := range clusterStatus.Conditions {
+		for _, currentCondition := range current.Conditions {
+			if currentCondition.Type == condition.Type && currentCondition.Status == condition.Status &&
+				currentCondition.LastTransitionTime != nil {
+				transitionTime := *currentCondition.LastTransitionTime
+				clusterStatus.Conditions[i].LastTransitionTime = &transitionTime
+			}
+		}
+	}
+	clusterStatus.Conditions = append(clusterStatus.Conditions, unmonitoredConditions(current.Conditions)...)
+	return clusterStatus
+}
+
 func clusterStatusEqual(newClusterStatus, oldClusterStatus *fedv1b1.KubeFedClusterStatus) bool {
 	return util.IsClusterReady(newClusterStatus) == util.IsClusterReady(oldClusterStatus)
 }
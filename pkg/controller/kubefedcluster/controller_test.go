@@ -102,6 +102,131 @@ func TestThresholdCheckedClusterStatus(t *testing.T) {
 	}
 }
 
+func TestPullClusterStatus(t *testing.T) {
+	epoch := metav1.Now()
+	now := metav1.Time{Time: epoch.Add(time.Minute)}
+	notReporting := AgentNotReportingReason
+
+	config := &util.ClusterHealthCheckConfig{
+		Period:           10 * time.Second,
+		FailureThreshold: 3,
+		SuccessThreshold: 1,
+		Timeout:          3 * time.Second,
+	}
+
+	testCases := map[string]struct {
+		creationTime   metav1.Time
+		clusterStatus  *fedv1b1.KubeFedClusterStatus
+		expectedUpdate bool
+	}{
+		"AgentReportedRecently": {
+			creationTime:  epoch,
+			clusterStatus: clusterStatus(corev1.ConditionTrue, metav1.Time{Time: now.Add(-20 * time.Second)}, epoch),
+		},
+		"AgentStoppedReporting": {
+			creationTime:   epoch,
+			clusterStatus:  clusterStatus(corev1.ConditionTrue, metav1.Time{Time: now.Add(-40 * time.Second)}, epoch),
+			expectedUpdate: true,
+		},
+		"AgentNeverReportedWithinGracePeriod": {
+			creationTime:  metav1.Time{Time: now.Add(-20 * time.Second)},
+			clusterStatus: &fedv1b1.KubeFedClusterStatus{},
+		},
+		"AgentNeverReported": {
+			creationTime:   epoch,
+			clusterStatus:  &fedv1b1.KubeFedClusterStatus{},
+			expectedUpdate: true,
+		},
+		"ClusterAlreadyMarkedOffline": {
+			creationTime: epoch,
+			clusterStatus: &fedv1b1.KubeFedClusterStatus{
+				Conditions: []fedv1b1.ClusterCondition{{
+					Type:               common.ClusterOffline,
+					Status:             corev1.ConditionTrue,
+					Reason:             &notReporting,
+					LastProbeTime:      epoch,
+					LastTransitionTime: &epoch,
+				}},
+			},
+		},
+	}
+
+	for testName, tc := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			cluster := &fedv1b1.KubeFedCluster{
+				ObjectMeta: metav1.ObjectMeta{CreationTimestamp: tc.creationTime},
+				Status:     *tc.clusterStatus,
+			}
+			newClusterStatus := pullClusterStatus(cluster, config, now)
+			if !tc.expectedUpdate {
+				if newClusterStatus != nil {
+					t.Fatalf("Unexpected status update: %v", newClusterStatus)
+				}
+				return
+			}
+			if newClusterStatus == nil || len(newClusterStatus.Conditions) != 1 {
+				t.Fatalf("Unexpected state, expected an offline condition, got: %v", newClusterStatus)
+			}
+			condition := newClusterStatus.Conditions[0]
+			if condition.Type != common.ClusterOffline || *condition.Reason != AgentNotReportingReason {
+				t.Fatalf("Unexpected condition, expected offline with reason %q, got: %v", AgentNotReportingReason, condition)
+			}
+		})
+	}
+}
+
+func TestAgentClusterStatus(t *testing.T) {
+	epoch := metav1.Now()
+	t1 := metav1.Time{Time: epoch.Add(1 * time.Second)}
+	t2 := metav1.Time{Time: epoch.Add(2 * time.Second)}
+	rotated := fedv1b1.ClusterCondition{
+		Type:               common.ClusterCredentialsRotated,
+		Status:             corev1.ConditionTrue,
+		LastProbeTime:      epoch,
+		LastTransitionTime: &epoch,
+	}
+
+	testCases := map[string]struct {
+		currentStatus         *fedv1b1.KubeFedClusterStatus
+		probedStatus          *fedv1b1.KubeFedClusterStatus
+		expectedClusterStatus *fedv1b1.KubeFedClusterStatus
+	}{
+		"ClusterReadyAtBegining": {
+			currentStatus:         &fedv1b1.KubeFedClusterStatus{},
+			probedStatus:          clusterStatus(corev1.ConditionTrue, t1, t1),
+			expectedClusterStatus: clusterStatus(corev1.ConditionTrue, t1, t1),
+		},
+		"ClusterRemainsReady": {
+			currentStatus:         clusterStatus(corev1.ConditionTrue, t1, epoch),
+			probedStatus:          clusterStatus(corev1.ConditionTrue, t2, t2),
+			expectedClusterStatus: clusterStatus(corev1.ConditionTrue, t2, epoch),
+		},
+		"ClusterBecomesNotReady": {
+			currentStatus:         clusterStatus(corev1.ConditionTrue, t1, epoch),
+			probedStatus:          clusterStatus(corev1.ConditionFalse, t2, t2),
+			expectedClusterStatus: clusterStatus(corev1.ConditionFalse, t2, t2),
+		},
+		"UnmonitoredConditionIsRetained": {
+			currentStatus: &fedv1b1.KubeFedClusterStatus{
+				Conditions: append(clusterStatus(corev1.ConditionTrue, t1, epoch).Conditions, rotated),
+			},
+			probedStatus: clusterStatus(corev1.ConditionTrue, t2, t2),
+			expectedClusterStatus: &fedv1b1.KubeFedClusterStatus{
+				Conditions: append(clusterStatus(corev1.ConditionTrue, t2, epoch).Conditions, rotated),
+			},
+		},
+	}
+
+	for testName, tc := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			newClusterStatus := AgentClusterStatus(tc.currentStatus, tc.probedStatus)
+			if !reflect.DeepEqual(tc.expectedClusterStatus, newClusterStatus) {
+				t.Fatalf("Unexpected state, expected: %v, got:%v", tc.expectedClusterStatus, newClusterStatus)
+			}
+		})
+	}
+}
+
 func clusterStatus(status corev1.ConditionStatus, lastProbeTime, lastTransitionTime metav1.Time) *fedv1b1.KubeFedClusterStatus {
 	return &fedv1b1.KubeFedClusterStatus{
 		Conditions: []fedv1b1.ClusterCondition{{
@@ -17,17 +17,22 @@ limitations under the License.
 package schedulingmanager
 
 import (
+	"reflect"
 	"time"
 
 	"github.com/pkg/errors"
 
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/runtime"
 	restclient "k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/klog/v2"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 
 	corev1b1 "sigs.k8s.io/kubefed/pkg/apis/core/v1beta1"
+	genericclient "sigs.k8s.io/kubefed/pkg/client/generic"
 	"sigs.k8s.io/kubefed/pkg/controller/schedulingpreference"
 	"sigs.k8s.io/kubefed/pkg/controller/util"
 	"sigs.k8s.io/kubefed/pkg/metrics"
@@ -45,6 +50,15 @@ type SchedulingManager struct {
 	schedulers *util.SafeMap
 
 	config *util.ControllerConfig
+
+	// For discovering the scale subresources of target types
+	client genericclient.Client
+
+	// Informer for the CustomResourceDefinitions whose scale
+	// subresources may change. Nil for a namespace-scoped control
+	// plane, which does not discover the scale subresources of custom
+	// resources.
+	crdController cache.Controller
 }
 
 type SchedulerWrapper struct {
@@ -54,16 +68,33 @@ type SchedulerWrapper struct {
 	// This is needed because typeconfig could be of any name and we run plugins
 	// by federated kinds (eg FederatedDeployment). This also avoids running multiple
 	// plugins in case multiple typeconfigs are created for same federated kind.
+	// Values are of type pluginInfo.
 	pluginMap *util.SafeMap
 	// Actual scheduler.
 	schedulingtypes.Scheduler
 }
 
+// pluginInfo describes the plugin running for a FederatedTypeConfig.
+type pluginInfo struct {
+	federatedKind string
+	// The scale subresource of the target type the plugin was
+	// started with.
+	scale schedulingtypes.ScaleSubresource
+}
+
 func (s *SchedulerWrapper) HasPlugin(typeConfigName string) bool {
 	_, ok := s.pluginMap.Get(typeConfigName)
 	return ok
 }
 
+func (s *SchedulerWrapper) getPlugin(typeConfigName string) (pluginInfo, bool) {
+	info, ok := s.pluginMap.Get(typeConfigName)
+	if !ok {
+		return pluginInfo{}, false
+	}
+	return info.(pluginInfo), true
+}
+
 func StartSchedulingManager(config *util.ControllerConfig, stopChan <-chan struct{}) (*SchedulingManager, error) {
 	manager, err := newSchedulingManager(config)
 	if err != nil {
@@ -96,6 +127,11 @@ func newSchedulingManager(config *util.ControllerConfig) (*SchedulingManager, er
 	c.worker = util.NewReconcileWorker("schedulingmanager", c.reconcile, util.WorkerOptions{})
 
 	var err error
+	c.client, err = genericclient.New(kubeConfig)
+	if err != nil {
+		return nil, err
+	}
+
 	c.store, c.controller, err = util.NewGenericInformer(
 		kubeConfig,
 		config.KubeFedNamespace,
@@ -107,9 +143,39 @@ func newSchedulingManager(config *util.ControllerConfig) (*SchedulingManager, er
 		return nil, err
 	}
 
+	if !config.LimitedScope() {
+		// Scale subresources are read from CustomResourceDefinitions,
+		// so the type configs of a definition are reconciled again
+		// when it changes.
+		crdAPIResource := &metav1.APIResource{
+			Group:   apiextv1.GroupName,
+			Version: apiextv1.SchemeGroupVersion.Version,
+			Kind:    "CustomResourceDefinition",
+			Name:    "customresourcedefinitions",
+		}
+		crdClient, err := util.NewResourceClient(kubeConfig, crdAPIResource)
+		if err != nil {
+			return nil, err
+		}
+		_, c.crdController = util.NewResourceInformer(crdClient, metav1.NamespaceAll, crdAPIResource, c.enqueueTypeConfigsForCRD)
+	}
+
 	return c, nil
 }
 
+// enqueueTypeConfigsForCRD enqueues the FederatedTypeConfigs whose
+// target type is defined by the given CustomResourceDefinition.
+func (c *SchedulingManager) enqueueTypeConfigsForCRD(crd runtimeclient.Object) {
+	for _, obj := range c.store.List() {
+		typeConfig := obj.(*corev1b1.FederatedTypeConfig).DeepCopy()
+		corev1b1.SetFederatedTypeConfigDefaults(typeConfig)
+		targetType := typeConfig.GetTargetType()
+		if (schema.GroupResource{Group: targetType.Group, Resource: targetType.Name}).String() == crd.GetName() {
+			c.worker.EnqueueObject(typeConfig)
+		}
+	}
+}
+
 func (c *SchedulingManager) GetScheduler(schedulingKind string) *SchedulerWrapper {
 	scheduler, ok := c.schedulers.Get(schedulingKind)
 	if !ok {
@@ -121,9 +187,14 @@ func (c *SchedulingManager) GetScheduler(schedulingKind string) *SchedulerWrappe
 // Run runs the Controller.
 func (c *SchedulingManager) Run(stopChan <-chan struct{}) {
 	go c.controller.Run(stopChan)
+	cachesSynced := []cache.InformerSynced{c.controller.HasSynced}
+	if c.crdController != nil {
+		go c.crdController.Run(stopChan)
+		cachesSynced = append(cachesSynced, c.crdController.HasSynced)
+	}
 
 	// wait for the caches to synchronize before starting the worker
-	if !cache.WaitForCacheSync(stopChan, c.controller.HasSynced) {
+	if !cache.WaitForCacheSync(stopChan, cachesSynced...) {
 		runtime.HandleError(errors.New("Timed out waiting for cache to sync in scheduling manager"))
 		return
 	}
@@ -151,12 +222,6 @@ func (c *SchedulingManager) reconcile(qualifiedName util.QualifiedName) util.Rec
 	klog.V(3).Infof("Running reconcile FederatedTypeConfig %q in scheduling manager", key)
 
 	typeConfigName := qualifiedName.Name
-	schedulingType := schedulingtypes.GetSchedulingType(typeConfigName)
-	if schedulingType == nil {
-		// No scheduler supported for this resource
-		return util.StatusAllOK
-	}
-	schedulingKind := schedulingType.Kind
 
 	cachedObj, exist, err := c.store.GetByKey(key)
 	if err != nil {
@@ -165,25 +230,41 @@ func (c *SchedulingManager) reconcile(qualifiedName util.QualifiedName) util.Rec
 	}
 
 	if !exist {
-		c.stopScheduler(schedulingKind, typeConfigName)
+		c.unregisterSchedulingType(typeConfigName)
 		return util.StatusAllOK
 	}
 
 	typeConfig := cachedObj.(*corev1b1.FederatedTypeConfig)
 	if !typeConfig.GetPropagationEnabled() || typeConfig.DeletionTimestamp != nil {
-		c.stopScheduler(schedulingKind, typeConfigName)
+		c.unregisterSchedulingType(typeConfigName)
 		return util.StatusAllOK
 	}
 
 	// set name and group for the type config target
 	corev1b1.SetFederatedTypeConfigDefaults(typeConfig)
 
+	// Replicas can be scheduled for any target type with a scale
+	// subresource.
+	scale, err := schedulingtypes.GetScaleSubresource(c.client, typeConfig, c.config.LimitedScope())
+	if err != nil {
+		runtime.HandleError(errors.Wrapf(err, "Failed to determine the scale subresource of FederatedTypeConfig %q", key))
+		return util.StatusError
+	}
+	if scale == nil {
+		// No scheduler supported for this resource
+		c.unregisterSchedulingType(typeConfigName)
+		return util.StatusAllOK
+	}
+	schedulingType := schedulingtypes.RSPSchedulingType
+	schedulingKind := schedulingType.Kind
+	schedulingtypes.RegisterSchedulingType(typeConfigName, schedulingType)
+
 	// Scheduling preference controller is started on demand
 	abstractScheduler, ok := c.schedulers.Get(schedulingKind)
 	if !ok {
 		klog.Infof("Starting schedulingpreference controller for %s", schedulingKind)
 		stopChan := make(chan struct{})
-		schedulerInterface, err := schedulingpreference.StartSchedulingPreferenceController(c.config, *schedulingType, stopChan)
+		schedulerInterface, err := schedulingpreference.StartSchedulingPreferenceController(c.config, schedulingType, stopChan)
 		if err != nil {
 			runtime.HandleError(errors.Wrapf(err, "Error starting schedulingpreference controller for %s", schedulingKind))
 			return util.StatusError
@@ -193,12 +274,16 @@ func (c *SchedulingManager) reconcile(qualifiedName util.QualifiedName) util.Rec
 	}
 
 	scheduler := abstractScheduler.(*SchedulerWrapper)
-	if scheduler.HasPlugin(typeConfigName) {
-		// Scheduler and plugin already running for this target typeConfig
-		return util.StatusAllOK
-	}
-
 	federatedKind := typeConfig.GetFederatedType().Kind
+	if running, ok := scheduler.getPlugin(typeConfigName); ok {
+		if reflect.DeepEqual(running.scale, *scale) {
+			// Scheduler and plugin already running for this target typeConfig
+			return util.StatusAllOK
+		}
+		klog.Infof("Restarting plugin %s for %s since the scale subresource of its target type changed", federatedKind, schedulingKind)
+		scheduler.StopPlugin(running.federatedKind)
+		scheduler.pluginMap.Delete(typeConfigName)
+	}
 
 	fedNsAPIResource, err := c.getFederatedNamespaceAPIResource()
 	if err != nil {
@@ -207,16 +292,27 @@ func (c *SchedulingManager) reconcile(qualifiedName util.QualifiedName) util.Rec
 	}
 
 	klog.Infof("Starting plugin %s for %s", federatedKind, schedulingKind)
-	err = scheduler.StartPlugin(typeConfig, fedNsAPIResource)
+	err = scheduler.StartPlugin(typeConfig, scale, fedNsAPIResource)
 	if err != nil {
 		runtime.HandleError(errors.Wrapf(err, "Error starting plugin %s for %s", federatedKind, schedulingKind))
 		return util.StatusError
 	}
-	scheduler.pluginMap.Store(typeConfigName, federatedKind)
+	scheduler.pluginMap.Store(typeConfigName, pluginInfo{federatedKind: federatedKind, scale: *scale})
 
 	return util.StatusAllOK
 }
 
+// unregisterSchedulingType stops the scheduling of the target type of
+// the named FederatedTypeConfig, if it was registered for scheduling.
+func (c *SchedulingManager) unregisterSchedulingType(typeConfigName string) {
+	schedulingType := schedulingtypes.GetSchedulingType(typeConfigName)
+	if schedulingType == nil {
+		return
+	}
+	c.stopScheduler(schedulingType.Kind, typeConfigName)
+	schedulingtypes.UnregisterSchedulingType(typeConfigName)
+}
+
 func (c *SchedulingManager) stopScheduler(schedulingKind, typeConfigName string) {
 	abstractScheduler, ok := c.schedulers.Get(schedulingKind)
 	if !ok {
@@ -224,10 +320,9 @@ func (c *SchedulingManager) stopScheduler(schedulingKind, typeConfigName string)
 	}
 
 	scheduler := abstractScheduler.(*SchedulerWrapper)
-	if scheduler.HasPlugin(typeConfigName) {
-		kind, _ := scheduler.pluginMap.Get(typeConfigName)
-		klog.Infof("Stopping plugin %s for %s", kind.(string), schedulingKind)
-		scheduler.StopPlugin(kind.(string))
+	if running, ok := scheduler.getPlugin(typeConfigName); ok {
+		klog.Infof("Stopping plugin %s for %s", running.federatedKind, schedulingKind)
+		scheduler.StopPlugin(running.federatedKind)
 		scheduler.pluginMap.Delete(typeConfigName)
 	}
 
@@ -27,6 +27,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 
 	"sigs.k8s.io/kubefed/pkg/apis/core/typeconfig"
+	fedv1b1 "sigs.k8s.io/kubefed/pkg/apis/core/v1beta1"
 	genericclient "sigs.k8s.io/kubefed/pkg/client/generic"
 	"sigs.k8s.io/kubefed/pkg/controller/sync/version"
 	"sigs.k8s.io/kubefed/pkg/controller/util"
@@ -70,6 +71,10 @@ type resourceAccessor struct {
 
 	// Records events on the federated resource
 	eventRecorder record.EventRecorder
+
+	// Returns the clusters that overrides targeting a cluster
+	// selector are matched against
+	getClusters func() ([]*fedv1b1.KubeFedCluster, error)
 }
 
 func NewFederatedResourceAccessor(
@@ -78,8 +83,10 @@ func NewFederatedResourceAccessor(
 	fedNamespaceAPIResource *metav1.APIResource,
 	client genericclient.Client,
 	enqueueObj func(runtimeclient.Object),
-	eventRecorder record.EventRecorder) (FederatedResourceAccessor, error) {
+	eventRecorder record.EventRecorder,
+	getClusters func() ([]*fedv1b1.KubeFedCluster, error)) (FederatedResourceAccessor, error) {
 	a := &resourceAccessor{
+		getClusters:             getClusters,
 		limitedScope:            controllerConfig.LimitedScope(),
 		typeConfig:              typeConfig,
 		targetIsNamespace:       typeConfig.GetTargetType().Kind == util.NamespaceKind,
@@ -277,6 +284,7 @@ func (a *resourceAccessor) FederatedResource(eventSource util.QualifiedName) (Fe
 		namespace:         namespace,
 		fedNamespace:      fedNamespace,
 		eventRecorder:     a.eventRecorder,
+		getClusters:       a.getClusters,
 	}, false, nil
 }
 
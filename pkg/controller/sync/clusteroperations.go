@@ -0,0 +1,424 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sync
+
+import (
+	"reflect"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/flowcontrol"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"sigs.k8s.io/kubefed/pkg/controller/sync/dispatch"
+	"sigs.k8s.io/kubefed/pkg/controller/sync/status"
+	"sigs.k8s.io/kubefed/pkg/controller/util"
+)
+
+// clusterOperations tracks the operations dispatched to member
+// clusters for federated resources. Each pair of federated resource
+// and member cluster is an independent unit of work: at most one
+// operation is in flight for a pair at any one time, a pair whose
+// operation failed is retried with exponential backoff without
+// delaying the other clusters of the resource, and the result of a
+// completed operation is held until a subsequent reconcile of the
+// resource aggregates it into the federated status.
+type clusterOperations struct {
+	sync.Mutex
+
+	backoff *flowcontrol.Backoff
+
+	// Operation state keyed by federated resource and cluster name.
+	states map[string]map[string]*clusterOperationState
+
+	// Reconciles the named federated resource after the given delay.
+	enqueue func(qualifiedName util.QualifiedName, delay time.Duration)
+}
+
+type clusterOperationState struct {
+	inFlight bool
+
+	// Whether the resource was reconciled while an operation was in
+	// flight, in which case the resource has to be reconciled again
+	// once the operation completes.
+	stale bool
+
+	// The result of the last completed operation, or nil if no
+	// operation has completed.
+	result *clusterOperationResult
+
+	// The earliest time a failed operation may be retried.
+	retryAt time.Time
+}
+
+// clusterOperationResult is the outcome of an operation in a single
+// member cluster.
+type clusterOperationResult struct {
+	// Whether a status was reported for the cluster. A cluster from
+	// which the managed label was removed has no status.
+	reported       bool
+	status         status.PropagationStatus
+	resourceStatus interface{}
+	driftedPaths   []string
+
+	// The version of the resource in the cluster if it differs from
+	// the version recorded for the federated resource, held until it
+	// has been recorded.
+	version *string
+
+	// Whether the resource in the cluster was written, held until it
+	// has been aggregated into the federated status.
+	resourcesUpdated bool
+
+	// The generation of the federated resource the operation was
+	// dispatched for.
+	generation int64
+}
+
+func (r *clusterOperationResult) failed() bool {
+	return r.reported && status.IsRecoverableError(r.status)
+}
+
+func newClusterOperations(initialBackoff, maxBackoff time.Duration, enqueue func(util.QualifiedName, time.Duration)) *clusterOperations {
+	if initialBackoff == 0 {
+		initialBackoff = time.Second * 5
+	}
+	if maxBackoff == 0 {
+		maxBackoff = time.Minute * 5
+	}
+	return &clusterOperations{
+		backoff: flowcontrol.NewBackOff(initialBackoff, maxBackoff),
+		states:  make(map[string]map[string]*clusterOperationState),
+		enqueue: enqueue,
+	}
+}
+
+func (o *clusterOperations) Run(stopChan <-chan struct{}) {
+	util.StartBackoffGC(o.backoff, stopChan)
+}
+
+func backoffID(key, clusterName string) string {
+	return key + "/" + clusterName
+}
+
+func (o *clusterOperations) state(key, clusterName string) *clusterOperationState {
+	clusterStates, ok := o.states[key]
+	if !ok {
+		clusterStates = make(map[string]*clusterOperationState)
+		o.states[key] = clusterStates
+	}
+	state, ok := clusterStates[clusterName]
+	if !ok {
+		state = &clusterOperationState{}
+		clusterStates[clusterName] = state
+	}
+	return state
+}
+
+// start determines whether an operation for the given generation of
+// the named federated resource may be started in the named cluster,
+// and if so marks an operation as in flight. An operation may not be
+// started while another is in flight or while a failed operation for
+// the same generation is backing off. In the latter case the resource
+// is enqueued for when the backoff expires.
+func (o *clusterOperations) start(qualifiedName util.QualifiedName, clusterName string, generation int64) bool {
+	o.Lock()
+	defer o.Unlock()
+
+	state := o.state(qualifiedName.String(), clusterName)
+	if state.inFlight {
+		state.stale = true
+		return false
+	}
+	if state.result != nil && state.result.failed() && state.result.generation == generation {
+		if delay := state.retryAt.Sub(o.backoff.Clock.Now()); delay > 0 {
+			o.enqueue(qualifiedName, delay)
+			return false
+		}
+	}
+	state.inFlight = true
+	return true
+}
+
+// complete records the result of an operation started in the named
+// cluster and enqueues the named federated resource if its status has
+// to be updated or it has been reconciled since the operation started.
+func (o *clusterOperations) complete(qualifiedName util.QualifiedName, clusterName string, result *clusterOperationResult) {
+	o.Lock()
+	defer o.Unlock()
+
+	key := qualifiedName.String()
+	clusterStates, ok := o.states[key]
+	if !ok {
+		// The federated resource has been forgotten.
+		return
+	}
+	state, ok := clusterStates[clusterName]
+	if !ok {
+		return
+	}
+	id := backoffID(key, clusterName)
+	if result.failed() {
+		now := o.backoff.Clock.Now()
+		o.backoff.Next(id, now)
+		state.retryAt = now.Add(o.backoff.Get(id))
+	} else {
+		o.backoff.Reset(id)
+		state.retryAt = time.Time{}
+	}
+
+	// A failed operation always requires a reconcile so that its retry
+	// is scheduled.
+	changed := state.stale || result.failed() || result.version != nil || result.resourcesUpdated ||
+		state.result == nil || !reflect.DeepEqual(*state.result, *result)
+	state.inFlight = false
+	state.stale = false
+	state.result = result
+	if changed {
+		o.enqueue(qualifiedName, 0)
+	}
+}
+
+// collect returns the results of the operations completed for the
+// named federated resource in the given clusters, along with the
+// versions and whether resources were updated since the last
+// collection. The state of other clusters is discarded.
+func (o *clusterOperations) collect(qualifiedName util.QualifiedName, clusterNames sets.Set[string]) (map[string]*clusterOperationResult, map[string]string, bool) {
+	o.Lock()
+	defer o.Unlock()
+
+	key := qualifiedName.String()
+	results := make(map[string]*clusterOperationResult)
+	versions := make(map[string]string)
+	resourcesUpdated := false
+	for clusterName, state := range o.states[key] {
+		if !clusterNames.Has(clusterName) {
+			if !state.inFlight {
+				delete(o.states[key], clusterName)
+				o.backoff.Reset(backoffID(key, clusterName))
+			}
+			continue
+		}
+		result := state.result
+		if result == nil {
+			continue
+		}
+		if result.version != nil {
+			versions[clusterName] = *result.version
+		}
+		resourcesUpdated = resourcesUpdated || result.resourcesUpdated
+		collected := *result
+		collected.version = nil
+		collected.resourcesUpdated = false
+		state.result = &collected
+		results[clusterName] = &collected
+	}
+	return results, versions, resourcesUpdated
+}
+
+// inFlight indicates whether an operation is in flight for the named
+// federated resource in the named cluster.
+func (o *clusterOperations) inFlight(qualifiedName util.QualifiedName, clusterName string) bool {
+	o.Lock()
+	defer o.Unlock()
+
+	state, ok := o.states[qualifiedName.String()][clusterName]
+	return ok && state.inFlight
+}
+
+// forget discards the state of the named federated resource.
+func (o *clusterOperations) forget(qualifiedName util.QualifiedName) {
+	o.Lock()
+	defer o.Unlock()
+
+	key := qualifiedName.String()
+	for clusterName := range o.states[key] {
+		o.backoff.Reset(backoffID(key, clusterName))
+	}
+	delete(o.states, key)
+}
+
+// asyncDispatcher dispatches the operations for a federated resource
+// to member clusters without waiting for them to complete. Each
+// operation is run by the executor with a dispatcher dedicated to its
+// cluster, and its result is tracked by clusterOperations. Statuses recorded
+// directly (e.g. for clusters that are not ready) apply to the current
+// reconcile only.
+type asyncDispatcher struct {
+	operations    *clusterOperations
+	executor      dispatch.Executor
+	fedResource   FederatedResource
+	qualifiedName util.QualifiedName
+	generation    int64
+
+	// Returns a dispatcher for a single operation. The dispatcher
+	// must run operations in the calling goroutine.
+	newDispatcher func() dispatch.ManagedDispatcher
+
+	rawResourceStatusCollection bool
+
+	statusMap         status.PropagationStatusMap
+	resourceStatusMap map[string]interface{}
+
+	// Clusters to which operations were dispatched, whether or not the
+	// operations were started.
+	dispatched sets.Set[string]
+}
+
+func newAsyncDispatcher(operations *clusterOperations, executor dispatch.Executor, fedResource FederatedResource, newDispatcher func() dispatch.ManagedDispatcher, rawResourceStatusCollection bool) *asyncDispatcher {
+	return &asyncDispatcher{
+		operations:                  operations,
+		executor:                    executor,
+		fedResource:                 fedResource,
+		qualifiedName:               fedResource.FederatedName(),
+		generation:                  fedResource.Object().GetGeneration(),
+		newDispatcher:               newDispatcher,
+		rawResourceStatusCollection: rawResourceStatusCollection,
+		statusMap:                   make(status.PropagationStatusMap),
+		resourceStatusMap:           make(map[string]interface{}),
+		dispatched:                  sets.New[string](),
+	}
+}
+
+func (d *asyncDispatcher) Create(clusterName string) {
+	d.dispatch(clusterName, func(dispatcher dispatch.ManagedDispatcher) {
+		dispatcher.Create(clusterName)
+	})
+}
+
+func (d *asyncDispatcher) Update(clusterName string, clusterObj *unstructured.Unstructured) {
+	d.dispatch(clusterName, func(dispatcher dispatch.ManagedDispatcher) {
+		dispatcher.Update(clusterName, clusterObj)
+	})
+}
+
+func (d *asyncDispatcher) Delete(clusterName string, opts ...runtimeclient.DeleteOption) {
+	d.dispatch(clusterName, func(dispatcher dispatch.ManagedDispatcher) {
+		dispatcher.Delete(clusterName, opts...)
+	})
+}
+
+func (d *asyncDispatcher) RemoveManagedLabel(clusterName string, clusterObj *unstructured.Unstructured) {
+	d.dispatch(clusterName, func(dispatcher dispatch.ManagedDispatcher) {
+		dispatcher.RemoveManagedLabel(clusterName, clusterObj)
+	})
+}
+
+func (d *asyncDispatcher) RecordClusterError(propStatus status.PropagationStatus, clusterName string, err error) {
+	d.fedResource.RecordError(string(propStatus), err)
+	d.RecordStatus(clusterName, propStatus, nil)
+}
+
+func (d *asyncDispatcher) RecordStatus(clusterName string, propStatus status.PropagationStatus, resourceStatus interface{}) {
+	d.statusMap[clusterName] = propStatus
+	if d.rawResourceStatusCollection {
+		d.resourceStatusMap[clusterName] = resourceStatus
+	}
+}
+
+func (d *asyncDispatcher) dispatch(clusterName string, op func(dispatch.ManagedDispatcher)) {
+	d.dispatched.Insert(clusterName)
+	if !d.operations.start(d.qualifiedName, clusterName, d.generation) {
+		return
+	}
+	d.executor.Execute(clusterName, func() {
+		dispatcher := d.newDispatcher()
+		op(dispatcher)
+		_, timeoutErr := dispatcher.Wait()
+		if timeoutErr != nil {
+			d.fedResource.RecordError("OperationTimeoutError", timeoutErr)
+			runtime.HandleError(timeoutErr)
+		}
+
+		result := &clusterOperationResult{generation: d.generation}
+		collectedStatus, collectedResourceStatus := dispatcher.CollectedStatus()
+		result.status, result.reported = collectedStatus.StatusMap[clusterName]
+		result.resourceStatus = collectedResourceStatus.StatusMap[clusterName]
+		result.driftedPaths = collectedStatus.DriftedPaths[clusterName]
+		result.resourcesUpdated = collectedStatus.ResourcesUpdated
+		if version, ok := dispatcher.VersionMap()[clusterName]; ok {
+			recordedVersion, err := d.fedResource.VersionForCluster(clusterName)
+			if err != nil || version != recordedVersion {
+				result.version = &version
+			}
+		}
+		d.operations.complete(d.qualifiedName, clusterName, result)
+	})
+}
+
+// CollectedStatus returns the statuses recorded directly along with
+// the results of the last operations completed in the clusters to
+// which operations were dispatched. A cluster without a completed
+// operation is reported as having an operation in progress.
+func (d *asyncDispatcher) CollectedStatus(results map[string]*clusterOperationResult, resourcesUpdated bool) (status.CollectedPropagationStatus, status.CollectedResourceStatus) {
+	statusMap := make(status.PropagationStatusMap)
+	resourceStatusMap := make(map[string]interface{})
+	driftedPaths := make(map[string][]string)
+	for clusterName, propStatus := range d.statusMap {
+		statusMap[clusterName] = propStatus
+	}
+	for clusterName, resourceStatus := range d.resourceStatusMap {
+		resourceStatusMap[clusterName] = resourceStatus
+	}
+	for clusterName := range d.dispatched {
+		result, ok := results[clusterName]
+		if !ok || d.operations.inFlight(d.qualifiedName, clusterName) && result.generation != d.generation {
+			statusMap[clusterName] = status.OperationInProgress
+			continue
+		}
+		if !result.reported {
+			continue
+		}
+		statusMap[clusterName] = result.status
+		if d.rawResourceStatusCollection {
+			resourceStatusMap[clusterName] = result.resourceStatus
+		}
+		if len(result.driftedPaths) > 0 {
+			driftedPaths[clusterName] = result.driftedPaths
+		}
+	}
+	return status.CollectedPropagationStatus{
+		StatusMap:        statusMap,
+		ResourcesUpdated: resourcesUpdated,
+		DriftedPaths:     driftedPaths,
+	}, status.CollectedResourceStatus{
+		StatusMap:        resourceStatusMap,
+		ResourcesUpdated: resourcesUpdated,
+	}
+}
+
+// ReconciliationStatus determines whether the federated resource has
+// to be reconciled again on account of the statuses recorded directly.
+// Failed operations are retried by clusterOperations instead.
+func (d *asyncDispatcher) ReconciliationStatus() util.ReconciliationStatus {
+	needsRecheck := false
+	for _, value := range d.statusMap {
+		if status.IsRecoverableError(value) {
+			return util.StatusError
+		}
+		// Dependencies in member clusters are not watched, so
+		// they need to be checked again.
+		needsRecheck = needsRecheck || value == status.WaitingForDependency
+	}
+	if needsRecheck {
+		return util.StatusNeedsRecheck
+	}
+	return util.StatusAllOK
+}
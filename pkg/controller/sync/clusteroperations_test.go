@@ -0,0 +1,149 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sync
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/flowcontrol"
+	testingclock "k8s.io/utils/clock/testing"
+
+	"sigs.k8s.io/kubefed/pkg/controller/sync/status"
+	"sigs.k8s.io/kubefed/pkg/controller/util"
+)
+
+func TestClusterOperations(t *testing.T) {
+	resource := util.QualifiedName{Namespace: "ns", Name: "foo"}
+	failed := &clusterOperationResult{reported: true, status: status.UpdateFailed, generation: 1}
+	succeeded := &clusterOperationResult{reported: true, status: status.ClusterPropagationOK, generation: 1}
+
+	testCases := map[string]struct {
+		// Completed operations in cluster1, each followed by an attempt
+		// to start another operation after the given delay.
+		results []*clusterOperationResult
+		delays  []time.Duration
+		// The generation of the federated resource for the final start.
+		generation      int64
+		expectedStarted bool
+		expectedDelay   time.Duration
+	}{
+		"Operation is started after success": {
+			results:         []*clusterOperationResult{succeeded},
+			delays:          []time.Duration{0},
+			generation:      1,
+			expectedStarted: true,
+		},
+		"Failed operation is not retried during backoff": {
+			results:       []*clusterOperationResult{failed},
+			delays:        []time.Duration{time.Second},
+			generation:    1,
+			expectedDelay: 4 * time.Second,
+		},
+		"Failed operation is retried after backoff": {
+			results:         []*clusterOperationResult{failed},
+			delays:          []time.Duration{5 * time.Second},
+			generation:      1,
+			expectedStarted: true,
+		},
+		"Backoff increases with consecutive failures": {
+			results:       []*clusterOperationResult{failed, failed},
+			delays:        []time.Duration{5 * time.Second, time.Second},
+			generation:    1,
+			expectedDelay: 9 * time.Second,
+		},
+		"Backoff is reset by success": {
+			results:       []*clusterOperationResult{failed, succeeded, failed},
+			delays:        []time.Duration{5 * time.Second, 0, 0},
+			generation:    1,
+			expectedDelay: 5 * time.Second,
+		},
+		"Failed operation is retried for a new generation": {
+			results:         []*clusterOperationResult{failed},
+			delays:          []time.Duration{0},
+			generation:      2,
+			expectedStarted: true,
+		},
+	}
+
+	for testName, tc := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			fakeClock := testingclock.NewFakeClock(time.Now())
+			var delays []time.Duration
+			operations := newClusterOperations(0, 0, func(_ util.QualifiedName, delay time.Duration) {
+				delays = append(delays, delay)
+			})
+			operations.backoff = flowcontrol.NewFakeBackOff(5*time.Second, time.Minute, fakeClock)
+
+			assert.True(t, operations.start(resource, "cluster1", 1))
+			for i, result := range tc.results {
+				if i > 0 {
+					assert.True(t, operations.start(resource, "cluster1", 1))
+				}
+				operations.complete(resource, "cluster1", result)
+				fakeClock.Step(tc.delays[i])
+			}
+
+			delays = nil
+			started := operations.start(resource, "cluster1", tc.generation)
+			assert.Equal(t, tc.expectedStarted, started)
+			if tc.expectedStarted {
+				assert.Empty(t, delays)
+			} else {
+				assert.Equal(t, []time.Duration{tc.expectedDelay}, delays)
+			}
+		})
+	}
+}
+
+func TestClusterOperationsEnqueue(t *testing.T) {
+	resource := util.QualifiedName{Namespace: "ns", Name: "foo"}
+	enqueued := 0
+	operations := newClusterOperations(0, 0, func(util.QualifiedName, time.Duration) {
+		enqueued++
+	})
+	result := func() *clusterOperationResult {
+		return &clusterOperationResult{reported: true, status: status.ClusterPropagationOK, generation: 1}
+	}
+
+	assert.True(t, operations.start(resource, "cluster1", 1))
+	assert.False(t, operations.start(resource, "cluster1", 1), "an operation should not be started while another is in flight")
+	assert.True(t, operations.start(resource, "cluster2", 1), "operations in other clusters should be independent")
+
+	operations.complete(resource, "cluster1", result())
+	assert.Equal(t, 1, enqueued, "the first result should trigger a reconcile")
+
+	assert.True(t, operations.start(resource, "cluster1", 1))
+	operations.complete(resource, "cluster1", result())
+	assert.Equal(t, 1, enqueued, "an unchanged result should not trigger a reconcile")
+
+	version := "1"
+	updated := result()
+	updated.version = &version
+	assert.True(t, operations.start(resource, "cluster1", 1))
+	operations.complete(resource, "cluster1", updated)
+	assert.Equal(t, 2, enqueued, "a result with a version should trigger a reconcile")
+
+	operations.complete(resource, "cluster2", result())
+	results, versions, _ := operations.collect(resource, sets.New("cluster1"))
+	assert.Equal(t, map[string]string{"cluster1": "1"}, versions)
+	assert.Nil(t, results["cluster1"].version, "a collected version should not be collected again")
+	assert.NotContains(t, operations.states[resource.String()], "cluster2", "state of clusters that no longer exist should be discarded")
+}
@@ -45,7 +45,9 @@ import (
 	"sigs.k8s.io/kubefed/pkg/apis/core/typeconfig"
 	fedv1b1 "sigs.k8s.io/kubefed/pkg/apis/core/v1beta1"
 	genericclient "sigs.k8s.io/kubefed/pkg/client/generic"
+	"sigs.k8s.io/kubefed/pkg/controller/sync/dependency"
 	"sigs.k8s.io/kubefed/pkg/controller/sync/dispatch"
+	"sigs.k8s.io/kubefed/pkg/controller/sync/rollout"
 	"sigs.k8s.io/kubefed/pkg/controller/sync/status"
 	"sigs.k8s.io/kubefed/pkg/controller/util"
 	"sigs.k8s.io/kubefed/pkg/metrics"
@@ -93,6 +95,19 @@ type KubeFedSyncController struct {
 	limitedScope bool
 
 	rawResourceStatusCollection bool
+
+	// The name of the CustomResourceDefinition of the target type, or
+	// empty if the target type is not a custom resource.
+	targetCRDName string
+
+	// Time allowed for an operation in a member cluster to complete.
+	clusterOperationTimeout time.Duration
+
+	// Operations dispatched to member clusters
+	operations *clusterOperations
+
+	// Runs operations against member clusters with bounded concurrency
+	executor *util.ClusterExecutor
 }
 
 // StartKubeFedSyncController starts a new sync controller for a type config
@@ -134,6 +149,11 @@ func newKubeFedSyncController(controllerConfig *util.ControllerConfig, typeConfi
 		skipAdoptingResources:       controllerConfig.SkipAdoptingResources,
 		limitedScope:                controllerConfig.LimitedScope(),
 		rawResourceStatusCollection: controllerConfig.RawResourceStatusCollection,
+		clusterOperationTimeout:     controllerConfig.ClusterOperationTimeout,
+		executor:                    controllerConfig.ClusterExecutor,
+	}
+	if s.executor == nil {
+		s.executor = util.NewClusterExecutor(0, 0)
 	}
 
 	s.worker = util.NewReconcileWorker(strings.ToLower(federatedTypeAPIResource.Kind), s.reconcile, util.WorkerOptions{
@@ -143,13 +163,24 @@ func newKubeFedSyncController(controllerConfig *util.ControllerConfig, typeConfi
 		MaxConcurrentReconciles: int(controllerConfig.MaxConcurrentSyncReconciles),
 	})
 
+	s.operations = newClusterOperations(controllerConfig.ClusterInitialBackoff, controllerConfig.ClusterMaxBackoff, s.worker.EnqueueWithDelay)
+
 	// Build deliverer for triggering cluster reconciliations.
 	s.clusterDeliverer = util.NewDelayingDeliverer()
 
 	targetAPIResource := typeConfig.GetTargetType()
 
-	// Federated informer for resources in member clusters
+	// Custom resources implicitly depend on their definition, which
+	// can only be determined with cluster scope.
 	var err error
+	if !s.limitedScope {
+		s.targetCRDName, err = dependency.CustomResourceDefinitionName(client, targetAPIResource)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Federated informer for resources in member clusters
 	s.informer, err = util.NewFederatedInformer(
 		controllerConfig,
 		client,
@@ -167,6 +198,11 @@ func newKubeFedSyncController(controllerConfig *util.ControllerConfig, typeConfi
 			ClusterUnavailable: func(cluster *fedv1b1.KubeFedCluster, _ []interface{}) {
 				s.clusterDeliverer.DeliverAt(allClustersKey, nil, time.Now().Add(s.clusterUnavailableDelay))
 			},
+			// Placement constraints spreading resources over a topology
+			// depend on the region and zones of clusters.
+			ClusterTopologyChanged: func(cluster *fedv1b1.KubeFedCluster) {
+				s.clusterDeliverer.DeliverAt(allClustersKey, nil, time.Now().Add(s.clusterAvailableDelay))
+			},
 		},
 	)
 	if err != nil {
@@ -175,7 +211,7 @@ func newKubeFedSyncController(controllerConfig *util.ControllerConfig, typeConfi
 
 	s.fedAccessor, err = NewFederatedResourceAccessor(
 		controllerConfig, typeConfig, fedNamespaceAPIResource,
-		client, s.worker.EnqueueObject, recorder)
+		client, s.worker.EnqueueObject, recorder, s.placementClusters)
 	if err != nil {
 		return nil, err
 	}
@@ -198,6 +234,7 @@ func (s *KubeFedSyncController) Run(stopChan <-chan struct{}) {
 		s.reconcileOnClusterChange()
 	})
 
+	s.operations.Run(stopChan)
 	s.worker.Run(stopChan)
 
 	// Ensure all goroutines are cleaned up when the stop channel closes
@@ -329,19 +366,143 @@ func (s *KubeFedSyncController) syncToClusters(fedResource FederatedResource) ut
 		return s.setFederatedStatus(fedResource, status.ClusterRetrievalFailed, nil, nil, enableRawResourceStatusCollection)
 	}
 
-	selectedClusterNames, err := fedResource.ComputePlacement(clusters)
+	// Resources are propagated to pull-mode clusters by their agents,
+	// which report the status of their clusters.
+	pullClusters, err := s.informer.GetPullClusters()
+	if err != nil {
+		fedResource.RecordError(string(status.ClusterRetrievalFailed), errors.Wrap(err, "Failed to retrieve list of pull-mode clusters"))
+		runtime.HandleError(errors.Wrapf(err, "failed to retrieve list of pull-mode clusters"))
+		return s.setFederatedStatus(fedResource, status.ClusterRetrievalFailed, nil, nil, enableRawResourceStatusCollection)
+	}
+
+	// Placement constraints apply across all clusters, so placement is
+	// computed for push-mode and pull-mode clusters together.
+	placementClusters, err := s.placementClusters()
+	if err != nil {
+		fedResource.RecordError(string(status.ClusterRetrievalFailed), errors.Wrap(err, "Failed to retrieve list of clusters"))
+		runtime.HandleError(errors.Wrapf(err, "failed to retrieve list of clusters"))
+		return s.setFederatedStatus(fedResource, status.ClusterRetrievalFailed, nil, nil, enableRawResourceStatusCollection)
+	}
+	placedClusterNames, placementStatus, err := fedResource.ComputePlacementStatus(placementClusters)
 	if err != nil {
 		fedResource.RecordError(string(status.ComputePlacementFailed), errors.Wrap(err, "Failed to compute placement"))
 		runtime.HandleError(errors.Wrapf(err, "failed to compute placement"))
 		return s.setFederatedStatus(fedResource, status.ComputePlacementFailed, nil, nil, enableRawResourceStatusCollection)
 	}
+	pullClusterNames := sets.New[string]()
+	for _, cluster := range pullClusters {
+		pullClusterNames.Insert(cluster.Name)
+	}
+	selectedClusterNames := placedClusterNames.Difference(pullClusterNames)
+	selectedPullClusterNames := placedClusterNames.Intersection(pullClusterNames)
 
 	kind := fedResource.TargetKind()
 	key := fedResource.TargetName().String()
 	klog.V(4).Infof("Ensuring %s %q in clusters: %s", kind, key, strings.Join(sets.List(selectedClusterNames), ","))
 
-	dispatcher := dispatch.NewManagedDispatcher(s.informer.GetClientForCluster, fedResource, s.skipAdoptingResources, enableRawResourceStatusCollection)
+	// Record the versions written by operations completed since the
+	// last reconcile so that they are considered by the rollout and
+	// by the operations dispatched below.
+	clusterNames := sets.New[string]()
+	for _, cluster := range clusters {
+		clusterNames.Insert(cluster.Name)
+	}
+	results, updatedVersionMap, resourcesUpdated := s.operations.collect(fedResource.FederatedName(), clusterNames)
+	err = fedResource.UpdateVersions(sets.List(selectedClusterNames), updatedVersionMap)
+	if err != nil {
+		// Versioning of federated resources is an optimization to
+		// avoid unnecessary updates, and failure to record version
+		// information does not indicate a failure of propagation.
+		runtime.HandleError(err)
+	}
+
+	clusterObjects := s.cachedClusterObjects(key)
+	rolloutPlan, err := computeRolloutPlan(fedResource, clusters, selectedClusterNames, clusterObjects)
+	if err != nil {
+		fedResource.RecordError(string(status.ComputeRolloutFailed), errors.Wrap(err, "Failed to compute rollout"))
+		runtime.HandleError(errors.Wrapf(err, "failed to compute rollout"))
+		return s.setFederatedStatus(fedResource, status.ComputeRolloutFailed, nil, nil, enableRawResourceStatusCollection)
+	}
+
+	dependencies, err := dependency.NewChecker(s.informer.GetClientForCluster, fedResource.Object(), fedResource.TargetName().Namespace, s.targetCRDName, s.limitedScope)
+	if err != nil {
+		fedResource.RecordError(string(status.ComputeDependenciesFailed), errors.Wrap(err, "Failed to compute dependencies"))
+		runtime.HandleError(errors.Wrapf(err, "failed to compute dependencies"))
+		return s.setFederatedStatus(fedResource, status.ComputeDependenciesFailed, nil, nil, enableRawResourceStatusCollection)
+	}
+
+	// Operations are dispatched to each member cluster independently
+	// and are not waited on. Their results are aggregated by the
+	// reconcile triggered by their completion.
+	dispatcher := newAsyncDispatcher(s.operations, s.executor, fedResource, func() dispatch.ManagedDispatcher {
+		// The operation runs in the goroutine the executor started
+		// for the cluster.
+		return dispatch.NewManagedDispatcher(s.informer.GetClientForCluster, fedResource, s.skipAdoptingResources,
+			enableRawResourceStatusCollection, s.typeConfig.GetServerSideApplyEnabled(), s.typeConfig.GetRetainFields(),
+			s.clusterOperationTimeout, dispatch.InlineExecutor{})
+	}, enableRawResourceStatusCollection)
+	dispatchToClusters(dispatcher, fedResource, clusters, selectedClusterNames, rolloutPlan, dependencies, clusterObjects)
+
+	collectedStatus, collectedResourceStatus := dispatcher.CollectedStatus(results, resourcesUpdated)
+	if rolloutPlan != nil {
+		collectedStatus.Rollout = rolloutPlan.Status
+	}
+	collectedStatus.RetainedClusters = selectedPullClusterNames
+	collectedStatus.PullClusters = pullClusterNames
+	collectedStatus.Placement = placementStatus
+	klog.V(4).Infof("Setting the federated status '%v' for %s %q", collectedResourceStatus, kind, key)
+	if result := s.setFederatedStatus(fedResource, status.AggregateSuccess, &collectedStatus, &collectedResourceStatus, enableRawResourceStatusCollection); result != util.StatusAllOK {
+		return result
+	}
+	return dispatcher.ReconciliationStatus()
+}
+
+// clusterDispatcher is the subset of dispatch.ManagedDispatcher used
+// to propagate a federated resource to member clusters.
+type clusterDispatcher interface {
+	Create(clusterName string)
+	Update(clusterName string, clusterObj *unstructured.Unstructured)
+	Delete(clusterName string, opts ...runtimeclient.DeleteOption)
+	RemoveManagedLabel(clusterName string, clusterObj *unstructured.Unstructured)
+	RecordClusterError(propStatus status.PropagationStatus, clusterName string, err error)
+	RecordStatus(clusterName string, propStatus status.PropagationStatus, resourceStatus interface{})
+}
+
+// clusterObjectSource retrieves the managed target object of a
+// federated resource from member clusters.
+type clusterObjectSource struct {
+	// get returns the object in the named cluster, or nil if the
+	// cluster does not have a managed object.
+	get func(clusterName string) (*unstructured.Unstructured, error)
+	// failedStatus is recorded for a cluster whose object could not
+	// be retrieved.
+	failedStatus status.PropagationStatus
+}
+
+// cachedClusterObjects returns a source of cluster objects backed by
+// the informer cache of member clusters.
+func (s *KubeFedSyncController) cachedClusterObjects(key string) clusterObjectSource {
+	return clusterObjectSource{
+		get: func(clusterName string) (*unstructured.Unstructured, error) {
+			rawClusterObj, _, err := s.informer.GetTargetStore().GetByKey(clusterName, key)
+			if err != nil {
+				return nil, errors.Wrap(err, "Failed to retrieve cached cluster object")
+			}
+			if rawClusterObj == nil {
+				return nil, nil
+			}
+			return rawClusterObj.(*unstructured.Unstructured), nil
+		},
+		failedStatus: status.CachedRetrievalFailed,
+	}
+}
 
+// dispatchToClusters dispatches the operations required to ensure
+// that the given federated resource is propagated to the selected
+// clusters and removed from the rest. Creation in a cluster is deferred
+// until the dependencies of the resource are satisfied in that cluster.
+func dispatchToClusters(dispatcher clusterDispatcher, fedResource FederatedResource, clusters []*fedv1b1.KubeFedCluster,
+	selectedClusterNames sets.Set[string], rolloutPlan *rollout.Plan, dependencies *dependency.Checker, clusterObjects clusterObjectSource) {
 	for _, cluster := range clusters {
 		clusterName := cluster.Name
 		selectedCluster := selectedClusterNames.Has(clusterName)
@@ -356,18 +517,12 @@ func (s *KubeFedSyncController) syncToClusters(fedResource FederatedResource) ut
 			continue
 		}
 
-		rawClusterObj, _, err := s.informer.GetTargetStore().GetByKey(clusterName, key)
+		clusterObj, err := clusterObjects.get(clusterName)
 		if err != nil {
-			wrappedErr := errors.Wrap(err, "Failed to retrieve cached cluster object")
-			dispatcher.RecordClusterError(status.CachedRetrievalFailed, clusterName, wrappedErr)
+			dispatcher.RecordClusterError(clusterObjects.failedStatus, clusterName, err)
 			continue
 		}
 
-		var clusterObj *unstructured.Unstructured
-		if rawClusterObj != nil {
-			clusterObj = rawClusterObj.(*unstructured.Unstructured)
-		}
-
 		// Resource should not exist in the named cluster
 		if !selectedCluster {
 			if clusterObj == nil {
@@ -391,6 +546,28 @@ func (s *KubeFedSyncController) syncToClusters(fedResource FederatedResource) ut
 
 		// Resource should appear in the named cluster
 
+		if rolloutPlan != nil && !rolloutPlan.Allowed(clusterName) {
+			var resourceStatus interface{}
+			if clusterObj != nil {
+				resourceStatus = clusterObj.Object[util.StatusField]
+			}
+			dispatcher.RecordStatus(clusterName, status.WaitingForRollout, resourceStatus)
+			continue
+		}
+
+		if clusterObj == nil && dependencies != nil {
+			reference, err := dependencies.Unsatisfied(clusterName)
+			if err != nil {
+				dispatcher.RecordClusterError(status.DependencyCheckFailed, clusterName, err)
+				continue
+			}
+			if reference != nil {
+				klog.V(4).Infof("Waiting for dependency %s of %s %q in cluster %q", reference, fedResource.TargetKind(), fedResource.TargetName(), clusterName)
+				dispatcher.RecordStatus(clusterName, status.WaitingForDependency, nil)
+				continue
+			}
+		}
+
 		// TODO(marun) Consider waiting until the result of resource
 		// creation has reached the target store before attempting
 		// subsequent operations.  Otherwise the object won't be found
@@ -401,24 +578,51 @@ func (s *KubeFedSyncController) syncToClusters(fedResource FederatedResource) ut
 			dispatcher.Update(clusterName, clusterObj)
 		}
 	}
-	_, timeoutErr := dispatcher.Wait()
-	if timeoutErr != nil {
-		fedResource.RecordError("OperationTimeoutError", timeoutErr)
-		runtime.HandleError(errors.Wrapf(timeoutErr, "operation timeout"))
+}
+
+// computeRolloutPlan determines which of the selected clusters may be
+// created or updated according to the rollout strategy of the given
+// federated resource. A nil plan is returned if the resource does not
+// have a rollout strategy. A cluster is considered to have been updated
+// if a version has been recorded for the current template and overrides.
+func computeRolloutPlan(fedResource FederatedResource, clusters []*fedv1b1.KubeFedCluster, selectedClusterNames sets.Set[string], clusterObjects clusterObjectSource) (*rollout.Plan, error) {
+	strategy, err := rollout.GetStrategy(fedResource.Object())
+	if err != nil || strategy == nil {
+		return nil, err
 	}
-	// Write updated versions to the API.
-	updatedVersionMap := dispatcher.VersionMap()
-	err = fedResource.UpdateVersions(sets.List(selectedClusterNames), updatedVersionMap)
-	if err != nil {
-		// Versioning of federated resources is an optimization to
-		// avoid unnecessary updates, and failure to record version
-		// information does not indicate a failure of propagation.
-		runtime.HandleError(err)
+
+	rolloutClusters := []*fedv1b1.KubeFedCluster{}
+	states := make(map[string]rollout.ClusterState)
+	for _, cluster := range clusters {
+		// Clusters that are not ready do not take part in the rollout
+		// to avoid blocking it.
+		if !selectedClusterNames.Has(cluster.Name) || !util.IsClusterReady(&cluster.Status) {
+			continue
+		}
+		rolloutClusters = append(rolloutClusters, cluster)
+
+		version, err := fedResource.VersionForCluster(cluster.Name)
+		if err != nil || version == "" {
+			continue
+		}
+		state := rollout.ClusterState{Updated: true}
+		clusterObj, err := clusterObjects.get(cluster.Name)
+		if err == nil && clusterObj != nil {
+			// The cached object may predate the recorded version, and
+			// its status can only be trusted once the generation matches.
+			current := clusterObj.GetGeneration() == 0 || util.ObjectVersion(clusterObj) == version
+			state.Ready = current && rollout.IsReady(clusterObj)
+		}
+		states[cluster.Name] = state
 	}
+	return rollout.NewPlan(strategy, rolloutClusters, states)
+}
 
-	collectedStatus, collectedResourceStatus := dispatcher.CollectedStatus()
-	klog.V(4).Infof("Setting the federated status '%v' for %s %q", collectedResourceStatus, kind, key)
-	return s.setFederatedStatus(fedResource, status.AggregateSuccess, &collectedStatus, &collectedResourceStatus, enableRawResourceStatusCollection)
+// placementClusters returns the clusters that placement is computed
+// for, the same clusters as the agents of pull-mode clusters compute
+// placement for.
+func (s *KubeFedSyncController) placementClusters() ([]*fedv1b1.KubeFedCluster, error) {
+	return s.informer.GetPlacementClusters()
 }
 
 func (s *KubeFedSyncController) setFederatedStatus(fedResource FederatedResource,
@@ -432,7 +636,9 @@ func (s *KubeFedSyncController) setFederatedStatus(fedResource FederatedResource
 
 	kind := fedResource.FederatedKind()
 	name := fedResource.FederatedName()
-	obj := fedResource.Object()
+	// Operations dispatched to member clusters may still be reading
+	// the federated resource, so the status is set on a copy.
+	obj := fedResource.Object().DeepCopy()
 
 	// Only a single reason for propagation failure is reported at any one time, so only report
 	// NamespaceNotFederated if no other explicit error has been indicated.
@@ -475,20 +681,12 @@ func (s *KubeFedSyncController) setFederatedStatus(fedResource FederatedResource
 		return util.StatusError
 	}
 
-	// return Error to trigger a retry with back off on recoverable propagation failure
-	if reason == status.AggregateSuccess {
-		for _, value := range collectedStatus.StatusMap {
-			if status.IsRecoverableError(value) {
-				return util.StatusError
-			}
-		}
-	}
-
 	return util.StatusAllOK
 }
 
 func (s *KubeFedSyncController) ensureDeletion(fedResource FederatedResource) util.ReconciliationStatus {
 	fedResource.DeleteVersions()
+	s.operations.forget(fedResource.FederatedName())
 
 	key := fedResource.FederatedName().String()
 	kind := fedResource.FederatedKind()
@@ -621,6 +819,24 @@ func (s *KubeFedSyncController) deleteFromClusters(fedResource FederatedResource
 	if !ok {
 		return false, errors.Errorf("failed to remove managed resources from one or more clusters.")
 	}
+
+	// The agent of a pull-mode cluster removes the entry of its cluster
+	// from the status of the federated resource once the managed
+	// resource has been removed from the cluster.
+	pullClusters, err := s.informer.GetPullClusters()
+	if err != nil {
+		return false, err
+	}
+	reportingClusters, err := status.ClusterNames(fedResource.Object())
+	if err != nil {
+		return false, err
+	}
+	for _, cluster := range pullClusters {
+		if reportingClusters.Has(cluster.Name) {
+			remainingClusters = append(remainingClusters, cluster.Name)
+		}
+	}
+
 	if len(remainingClusters) > 0 {
 		fedKind := fedResource.FederatedKind()
 		fedName := fedResource.FederatedName()
@@ -653,7 +869,7 @@ func (s *KubeFedSyncController) ensureRemovedOrUnmanaged(fedResource FederatedRe
 		return errors.Wrapf(err, "failed to compute placement for %s %q", fedResource.FederatedKind(), fedResource.FederatedName().Name)
 	}
 
-	dispatcher := dispatch.NewCheckUnmanagedDispatcher(s.informer.GetClientForCluster, fedResource.TargetGVK(), fedResource.TargetName())
+	dispatcher := dispatch.NewCheckUnmanagedDispatcher(s.informer.GetClientForCluster, fedResource.TargetGVK(), fedResource.TargetName(), s.executor)
 	unreadyClusters := []string{}
 	for _, cluster := range clusters {
 		if !targetClusters.Has(cluster.Name) {
@@ -687,7 +903,7 @@ func (s *KubeFedSyncController) handleDeletionInClusters(gvk schema.GroupVersion
 		return false, errors.Wrap(err, "failed to get a list of clusters")
 	}
 
-	dispatcher := dispatch.NewUnmanagedDispatcher(s.informer.GetClientForCluster, gvk, qualifiedName)
+	dispatcher := dispatch.NewUnmanagedDispatcher(s.informer.GetClientForCluster, gvk, qualifiedName, s.executor)
 	retrievalFailureClusters := []string{}
 	unreadyClusters := []string{}
 	for _, cluster := range memberClusters {
@@ -0,0 +1,209 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dependency
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"sigs.k8s.io/kubefed/pkg/client/generic"
+	"sigs.k8s.io/kubefed/pkg/controller/sync/rollout"
+	"sigs.k8s.io/kubefed/pkg/controller/util"
+)
+
+const (
+	customResourceDefinitionAPIVersion = "apiextensions.k8s.io/v1"
+	customResourceDefinitionKind       = "CustomResourceDefinition"
+)
+
+// Reference identifies a resource that must be propagated to a member
+// cluster and be ready before the resource of a federated resource is
+// created in that cluster.
+type Reference struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Name       string `json:"name"`
+	// Namespace of the resource. Defaults to the namespace of the
+	// federated resource, and is ignored for cluster-scoped kinds.
+	Namespace string `json:"namespace,omitempty"`
+}
+
+func (r Reference) String() string {
+	if r.Namespace == "" {
+		return fmt.Sprintf("%s %q", r.Kind, r.Name)
+	}
+	return fmt.Sprintf("%s %q", r.Kind, r.Namespace+"/"+r.Name)
+}
+
+type genericDependencySpec struct {
+	DependsOn []Reference `json:"dependsOn,omitempty"`
+}
+
+type genericDependency struct {
+	Spec genericDependencySpec `json:"spec,omitempty"`
+}
+
+// GetReferences returns the dependencies declared by the given
+// federated resource.
+func GetReferences(fedObject *unstructured.Unstructured) ([]Reference, error) {
+	dependency := &genericDependency{}
+	err := util.UnstructuredToInterface(fedObject, dependency)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to unmarshall dependencies")
+	}
+	for _, reference := range dependency.Spec.DependsOn {
+		if reference.APIVersion == "" || reference.Kind == "" || reference.Name == "" {
+			return nil, errors.Errorf("Dependency %s must specify apiVersion, kind and name", reference)
+		}
+	}
+	return dependency.Spec.DependsOn, nil
+}
+
+// CustomResourceDefinitionName returns the name of the
+// CustomResourceDefinition defining the given type in the host
+// cluster, or an empty string if the type is not a custom resource.
+func CustomResourceDefinitionName(hostClient generic.Client, apiResource metav1.APIResource) (string, error) {
+	if apiResource.Group == "" {
+		return "", nil
+	}
+	name := fmt.Sprintf("%s.%s", apiResource.Name, apiResource.Group)
+	crd := &unstructured.Unstructured{}
+	crd.SetAPIVersion(customResourceDefinitionAPIVersion)
+	crd.SetKind(customResourceDefinitionKind)
+	err := hostClient.Get(context.Background(), crd, "", name)
+	if apierrors.IsNotFound(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", errors.Wrapf(err, "Failed to determine whether %s is a custom resource", apiResource.Kind)
+	}
+	return name, nil
+}
+
+// Checker determines whether the dependencies of a federated resource
+// are satisfied in member clusters.
+type Checker struct {
+	clientAccessor func(clusterName string) (generic.Client, error)
+	references     []Reference
+}
+
+// NewChecker returns a checker for the dependencies of the given
+// federated resource. Unless the control plane is limited to a single
+// namespace, the resource implicitly depends on its namespace (if
+// targetNamespace is not empty) and on the named
+// CustomResourceDefinition (if crdName is not empty). These are
+// checked before the declared dependencies. A nil checker is returned
+// if there are no dependencies.
+func NewChecker(clientAccessor func(clusterName string) (generic.Client, error), fedObject *unstructured.Unstructured,
+	targetNamespace, crdName string, limitedScope bool) (*Checker, error) {
+	declared, err := GetReferences(fedObject)
+	if err != nil {
+		return nil, err
+	}
+
+	references := []Reference{}
+	if !limitedScope {
+		if targetNamespace != "" {
+			references = append(references, Reference{APIVersion: "v1", Kind: util.NamespaceKind, Name: targetNamespace})
+		}
+		if crdName != "" {
+			references = append(references, Reference{
+				APIVersion: customResourceDefinitionAPIVersion,
+				Kind:       customResourceDefinitionKind,
+				Name:       crdName,
+			})
+		}
+	}
+	for _, reference := range declared {
+		if reference.Namespace == "" {
+			reference.Namespace = targetNamespace
+		}
+		references = append(references, reference)
+	}
+	if len(references) == 0 {
+		return nil, nil
+	}
+	return &Checker{
+		clientAccessor: clientAccessor,
+		references:     references,
+	}, nil
+}
+
+// Unsatisfied returns the first dependency that has not been
+// propagated to the named cluster or is not yet ready there, or nil if
+// all dependencies are satisfied.
+func (c *Checker) Unsatisfied(clusterName string) (*Reference, error) {
+	client, err := c.clientAccessor(clusterName)
+	if err != nil {
+		return nil, err
+	}
+	for i := range c.references {
+		reference := c.references[i]
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(schema.FromAPIVersionAndKind(reference.APIVersion, reference.Kind))
+		name := reference.Name
+		namespace := reference.Namespace
+		if reference.Kind == util.NamespaceKind && reference.APIVersion == "v1" {
+			name = util.NamespaceForCluster(clusterName, name)
+		} else if namespace != "" {
+			namespace = util.NamespaceForCluster(clusterName, namespace)
+		}
+		err := client.Get(context.Background(), obj, namespace, name)
+		if apierrors.IsNotFound(err) {
+			return &reference, nil
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "Failed to retrieve dependency %s", reference)
+		}
+		if !IsReady(obj) {
+			return &reference, nil
+		}
+	}
+	return nil, nil
+}
+
+// IsReady determines whether a dependency in a member cluster is ready.
+// A CustomResourceDefinition must be established, a namespace must not
+// be terminating and other resources must be ready according to
+// rollout.IsReady.
+func IsReady(obj *unstructured.Unstructured) bool {
+	if obj.GetDeletionTimestamp() != nil {
+		return false
+	}
+	switch obj.GetKind() {
+	case customResourceDefinitionKind:
+		conditions, _, _ := unstructured.NestedSlice(obj.Object, util.StatusField, "conditions")
+		for _, rawCondition := range conditions {
+			condition, ok := rawCondition.(map[string]interface{})
+			if ok && condition["type"] == "Established" {
+				return condition["status"] == "True"
+			}
+		}
+		return false
+	case util.NamespaceKind:
+		phase, _, _ := unstructured.NestedString(obj.Object, util.StatusField, "phase")
+		return phase != "Terminating"
+	}
+	return rollout.IsReady(obj)
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dependency
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"sigs.k8s.io/kubefed/pkg/client/generic"
+)
+
+func TestCheckerUnsatisfied(t *testing.T) {
+	activeNamespace := newObject("v1", "Namespace", "", "test-ns", map[string]interface{}{"phase": "Active"})
+	establishedCRD := newObject(customResourceDefinitionAPIVersion, customResourceDefinitionKind, "", "widgets.example.com", map[string]interface{}{
+		"conditions": []interface{}{
+			map[string]interface{}{"type": "Established", "status": "True"},
+		},
+	})
+	pendingCRD := newObject(customResourceDefinitionAPIVersion, customResourceDefinitionKind, "", "widgets.example.com", map[string]interface{}{
+		"conditions": []interface{}{
+			map[string]interface{}{"type": "Established", "status": "False"},
+		},
+	})
+	configMap := newObject("v1", "ConfigMap", "test-ns", "config", nil)
+	unavailableDeployment := newObject("apps/v1", "Deployment", "test-ns", "backend", map[string]interface{}{
+		"conditions": []interface{}{
+			map[string]interface{}{"type": "Available", "status": "False"},
+		},
+	})
+
+	testCases := map[string]struct {
+		dependsOn         []interface{}
+		crdName           string
+		limitedScope      bool
+		clusterObjs       []*unstructured.Unstructured
+		expectNoChecker   bool
+		expectedReference *Reference
+	}{
+		"Namespace is an implicit dependency": {
+			expectedReference: &Reference{APIVersion: "v1", Kind: "Namespace", Name: "test-ns"},
+		},
+		"Definition of a custom resource is an implicit dependency": {
+			crdName:     "widgets.example.com",
+			clusterObjs: []*unstructured.Unstructured{activeNamespace, pendingCRD},
+			expectedReference: &Reference{
+				APIVersion: customResourceDefinitionAPIVersion,
+				Kind:       customResourceDefinitionKind,
+				Name:       "widgets.example.com",
+			},
+		},
+		"Implicit dependencies are ignored with limited scope": {
+			crdName:         "widgets.example.com",
+			limitedScope:    true,
+			expectNoChecker: true,
+		},
+		"Missing declared dependency is unsatisfied": {
+			dependsOn: []interface{}{
+				map[string]interface{}{"apiVersion": "v1", "kind": "ConfigMap", "name": "config"},
+			},
+			clusterObjs:       []*unstructured.Unstructured{activeNamespace},
+			expectedReference: &Reference{APIVersion: "v1", Kind: "ConfigMap", Name: "config", Namespace: "test-ns"},
+		},
+		"Declared dependency that is not ready is unsatisfied": {
+			dependsOn: []interface{}{
+				map[string]interface{}{"apiVersion": "v1", "kind": "ConfigMap", "name": "config"},
+				map[string]interface{}{"apiVersion": "apps/v1", "kind": "Deployment", "name": "backend"},
+			},
+			clusterObjs:       []*unstructured.Unstructured{activeNamespace, configMap, unavailableDeployment},
+			expectedReference: &Reference{APIVersion: "apps/v1", Kind: "Deployment", Name: "backend", Namespace: "test-ns"},
+		},
+		"Ready dependencies are satisfied": {
+			crdName: "widgets.example.com",
+			dependsOn: []interface{}{
+				map[string]interface{}{"apiVersion": "v1", "kind": "ConfigMap", "name": "config"},
+			},
+			clusterObjs: []*unstructured.Unstructured{activeNamespace, establishedCRD, configMap},
+		},
+	}
+
+	for testName, tc := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			fedObject := &unstructured.Unstructured{Object: map[string]interface{}{}}
+			if tc.dependsOn != nil {
+				fedObject.Object["spec"] = map[string]interface{}{"dependsOn": tc.dependsOn}
+			}
+			client := &fakeClient{objs: tc.clusterObjs}
+			clientAccessor := func(string) (generic.Client, error) { return client, nil }
+
+			checker, err := NewChecker(clientAccessor, fedObject, "test-ns", tc.crdName, tc.limitedScope)
+			require.NoError(t, err)
+			if tc.expectNoChecker {
+				assert.Nil(t, checker)
+				return
+			}
+			require.NotNil(t, checker)
+
+			reference, err := checker.Unsatisfied("cluster1")
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedReference, reference)
+		})
+	}
+}
+
+func TestGetReferences(t *testing.T) {
+	fedObject := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"dependsOn": []interface{}{
+				map[string]interface{}{"kind": "ConfigMap", "name": "config"},
+			},
+		},
+	}}
+	_, err := GetReferences(fedObject)
+	assert.Error(t, err)
+}
+
+func newObject(apiVersion, kind, namespace, name string, status map[string]interface{}) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	obj.SetAPIVersion(apiVersion)
+	obj.SetKind(kind)
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	if status != nil {
+		obj.Object["status"] = status
+	}
+	return obj
+}
+
+// fakeClient returns the configured object matching the kind, namespace
+// and name requested by Get, or a not found error.
+type fakeClient struct {
+	generic.Client
+
+	objs []*unstructured.Unstructured
+}
+
+func (c *fakeClient) Get(ctx context.Context, obj runtimeclient.Object, namespace, name string) error {
+	target := obj.(*unstructured.Unstructured)
+	for _, candidate := range c.objs {
+		if candidate.GetKind() == target.GetKind() && candidate.GetNamespace() == namespace && candidate.GetName() == name {
+			candidate.DeepCopyInto(target)
+			return nil
+		}
+	}
+	return apierrors.NewNotFound(schema.GroupResource{Resource: target.GetKind()}, name)
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatch
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/csaupgrade"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"sigs.k8s.io/kubefed/pkg/client/generic"
+	"sigs.k8s.io/kubefed/pkg/controller/sync/status"
+)
+
+// FieldManager is the name of the field manager that owns the fields
+// of resources propagated to member clusters with server-side apply.
+const FieldManager = "kubefed"
+
+// RetainApplyFields updates the desired object with values retained
+// from the cluster object when the desired object will be propagated
+// with server-side apply. Fields that are omitted from an applied
+// object are left to the field managers in the member cluster, so
+// unlike RetainClusterFields there is no need to retain cluster
+// metadata or fields allocated by the member cluster.
+func RetainApplyFields(desiredObj, clusterObj, fedObj *unstructured.Unstructured) error {
+	// Replicas are retained rather than omitted so that KubeFed
+	// continues to share ownership of the field. Omitting a field
+	// that is solely owned by KubeFed would remove it.
+	return retainReplicas(desiredObj, clusterObj, fedObj)
+}
+
+// applyObject applies the desired object to a member cluster with
+// server-side apply. Unless ownership is forced, a field owned by
+// another field manager results in a conflict error rather than being
+// overwritten.
+func applyObject(ctx context.Context, client generic.Client, obj *unstructured.Unstructured, force bool) error {
+	obj.SetResourceVersion("")
+	obj.SetManagedFields(nil)
+	opts := []runtimeclient.PatchOption{runtimeclient.FieldOwner(FieldManager)}
+	if force {
+		opts = append(opts, runtimeclient.ForceOwnership)
+	}
+	return client.Patch(ctx, obj, runtimeclient.Apply, opts...)
+}
+
+// createWithApply creates the desired object in a member cluster so
+// that KubeFed owns the fields it sets from the outset. An apply would
+// silently adopt an existing resource, so the object is created with a
+// regular create by the KubeFed field manager, which fails with an
+// AlreadyExists error for an existing resource to allow the caller to
+// apply the same adoption rules as for a regular create.
+func createWithApply(ctx context.Context, client generic.Client, obj *unstructured.Unstructured) error {
+	return client.Create(ctx, obj, runtimeclient.FieldOwner(FieldManager))
+}
+
+// updateWithApply updates the object in a member cluster by applying
+// the desired object without forcing ownership. The returned
+// propagation status indicates the cause of an error.
+func updateWithApply(ctx context.Context, client generic.Client, obj, clusterObj *unstructured.Unstructured) (status.PropagationStatus, error) {
+	err := upgradeManagedFields(ctx, client, clusterObj)
+	if err != nil {
+		return status.UpdateFailed, errors.Wrapf(err, "failed to transfer the fields set on creation to field manager %q", FieldManager)
+	}
+	err = applyObject(ctx, client, obj, false)
+	if apierrors.IsConflict(err) {
+		return status.FieldManagerConflict, errors.Wrapf(err, "field manager %q conflicts with another field manager", FieldManager)
+	}
+	if err != nil {
+		return status.UpdateFailed, err
+	}
+	return "", nil
+}
+
+// upgradeManagedFields transfers the fields of the cluster object that
+// are owned by the create of the KubeFed field manager to its applies.
+// Managers are distinguished by operation, so an apply would otherwise
+// conflict with the create when changing a field it set.
+func upgradeManagedFields(ctx context.Context, client generic.Client, clusterObj *unstructured.Unstructured) error {
+	patch, err := csaupgrade.UpgradeManagedFieldsPatch(clusterObj, sets.New(FieldManager), FieldManager)
+	if err != nil || patch == nil {
+		return err
+	}
+	return client.Patch(ctx, clusterObj.DeepCopy(), runtimeclient.RawPatch(types.JSONPatchType, patch))
+}
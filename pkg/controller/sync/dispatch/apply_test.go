@@ -0,0 +1,196 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatch
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"sigs.k8s.io/kubefed/pkg/client/generic"
+	"sigs.k8s.io/kubefed/pkg/controller/sync/status"
+)
+
+func TestApplyObject(t *testing.T) {
+	for _, force := range []bool{false, true} {
+		client := &fakeApplyClient{}
+		obj := newPlanConfigMap("value", 0, true)
+		obj.SetResourceVersion("42")
+		obj.SetManagedFields([]metav1.ManagedFieldsEntry{{Manager: "kubectl"}})
+
+		err := applyObject(context.Background(), client, obj, force)
+		require.NoError(t, err)
+
+		require.Len(t, client.patches, 1)
+		patch := client.patches[0]
+		assert.Equal(t, types.ApplyPatchType, patch.patchType)
+		assert.Equal(t, FieldManager, patch.opts.FieldManager)
+		assert.Equal(t, force, patch.opts.Force != nil && *patch.opts.Force)
+		assert.Empty(t, patch.obj.GetResourceVersion())
+		assert.Empty(t, patch.obj.GetManagedFields())
+	}
+}
+
+func TestCreateWithApply(t *testing.T) {
+	testCases := map[string]struct {
+		createErr       error
+		expectedExisted bool
+	}{
+		"Object is created by the KubeFed field manager": {},
+		"Existing object": {
+			createErr:       apierrors.NewAlreadyExists(schema.GroupResource{Resource: "configmaps"}, "test"),
+			expectedExisted: true,
+		},
+	}
+
+	for testName, tc := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			client := &fakeApplyClient{createErr: tc.createErr}
+			obj := newPlanConfigMap("value", 0, true)
+
+			err := createWithApply(context.Background(), client, obj)
+			assert.Equal(t, tc.createErr != nil, err != nil)
+			assert.Equal(t, tc.expectedExisted, apierrors.IsAlreadyExists(err))
+
+			// The object is created in a single request.
+			require.Len(t, client.creates, 1)
+			assert.Equal(t, FieldManager, client.creates[0].opts.FieldManager)
+			assert.Empty(t, client.patches)
+		})
+	}
+}
+
+func TestUpdateWithApply(t *testing.T) {
+	fields := &metav1.FieldsV1{Raw: []byte(`{"f:data":{"f:key":{}}}`)}
+
+	testCases := map[string]struct {
+		managedFields  []metav1.ManagedFieldsEntry
+		applyErr       error
+		expectUpgrade  bool
+		expectedStatus status.PropagationStatus
+	}{
+		"Successful apply": {
+			managedFields: []metav1.ManagedFieldsEntry{
+				{Manager: FieldManager, Operation: metav1.ManagedFieldsOperationApply, APIVersion: "v1", FieldsType: "FieldsV1", FieldsV1: fields},
+			},
+		},
+		"Fields set on creation are transferred to the applies": {
+			managedFields: []metav1.ManagedFieldsEntry{
+				{Manager: FieldManager, Operation: metav1.ManagedFieldsOperationUpdate, APIVersion: "v1", FieldsType: "FieldsV1", FieldsV1: fields},
+			},
+			expectUpgrade: true,
+		},
+		"Fields of other managers are not transferred": {
+			managedFields: []metav1.ManagedFieldsEntry{
+				{Manager: "kubectl", Operation: metav1.ManagedFieldsOperationUpdate, APIVersion: "v1", FieldsType: "FieldsV1", FieldsV1: fields},
+			},
+		},
+		"Conflict with another field manager": {
+			applyErr:       apierrors.NewConflict(schema.GroupResource{Resource: "configmaps"}, "test", assert.AnError),
+			expectedStatus: status.FieldManagerConflict,
+		},
+		"Failed apply": {
+			applyErr:       apierrors.NewInternalError(assert.AnError),
+			expectedStatus: status.UpdateFailed,
+		},
+	}
+
+	for testName, tc := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			client := &fakeApplyClient{patchErr: tc.applyErr}
+			obj := newPlanConfigMap("value", 0, true)
+			clusterObj := newPlanConfigMap("value", 0, true)
+			clusterObj.SetResourceVersion("42")
+			clusterObj.SetManagedFields(tc.managedFields)
+
+			propStatus, err := updateWithApply(context.Background(), client, obj, clusterObj)
+			assert.Equal(t, tc.expectedStatus, propStatus)
+			assert.Equal(t, tc.applyErr != nil, err != nil)
+
+			patches := client.patches
+			if tc.expectUpgrade {
+				require.Len(t, patches, 2)
+				assert.Equal(t, types.JSONPatchType, patches[0].patchType)
+				patches = patches[1:]
+			}
+			require.Len(t, patches, 1)
+			patch := patches[0]
+			assert.Equal(t, types.ApplyPatchType, patch.patchType)
+			assert.False(t, patch.opts.Force != nil && *patch.opts.Force)
+		})
+	}
+}
+
+type createdObject struct {
+	obj  *unstructured.Unstructured
+	opts *runtimeclient.CreateOptions
+}
+
+type appliedPatch struct {
+	obj       *unstructured.Unstructured
+	patchType types.PatchType
+	opts      *runtimeclient.PatchOptions
+}
+
+// fakeApplyClient records the objects that are created and patched,
+// and returns the configured errors.
+type fakeApplyClient struct {
+	generic.Client
+
+	createErr error
+	patchErr  error
+
+	creates []createdObject
+	patches []appliedPatch
+}
+
+func (c *fakeApplyClient) Create(ctx context.Context, obj runtimeclient.Object, opts ...runtimeclient.CreateOption) error {
+	uObj := obj.(*unstructured.Unstructured)
+	c.creates = append(c.creates, createdObject{
+		obj:  uObj.DeepCopy(),
+		opts: (&runtimeclient.CreateOptions{}).ApplyOptions(opts),
+	})
+	if c.createErr != nil {
+		return c.createErr
+	}
+	uObj.SetUID("uid")
+	uObj.SetResourceVersion("created")
+	return nil
+}
+
+func (c *fakeApplyClient) Patch(ctx context.Context, obj runtimeclient.Object, patch runtimeclient.Patch, opts ...runtimeclient.PatchOption) error {
+	uObj := obj.(*unstructured.Unstructured)
+	c.patches = append(c.patches, appliedPatch{
+		obj:       uObj.DeepCopy(),
+		patchType: patch.Type(),
+		opts:      (&runtimeclient.PatchOptions{}).ApplyOptions(opts),
+	})
+	if c.patchErr != nil && patch.Type() == types.ApplyPatchType {
+		return c.patchErr
+	}
+	uObj.SetResourceVersion("applied")
+	return nil
+}
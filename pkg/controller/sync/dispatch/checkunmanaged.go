@@ -47,8 +47,8 @@ type checkUnmanagedDispatcherImpl struct {
 	targetName util.QualifiedName
 }
 
-func NewCheckUnmanagedDispatcher(clientAccessor clientAccessorFunc, targetGVK schema.GroupVersionKind, targetName util.QualifiedName) CheckUnmanagedDispatcher {
-	dispatcher := newOperationDispatcher(clientAccessor, nil)
+func NewCheckUnmanagedDispatcher(clientAccessor clientAccessorFunc, targetGVK schema.GroupVersionKind, targetName util.QualifiedName, executor Executor) CheckUnmanagedDispatcher {
+	dispatcher := newOperationDispatcher(clientAccessor, nil, 0, executor)
 	return &checkUnmanagedDispatcherImpl{
 		dispatcher: dispatcher,
 		targetGVK:  targetGVK,
@@ -64,17 +64,16 @@ func (d *checkUnmanagedDispatcherImpl) Wait() (bool, error) {
 // exist in the given cluster, or if it does exist, that it does not
 // have the managed label.
 func (d *checkUnmanagedDispatcherImpl) CheckRemovedOrUnlabeled(clusterName string, isHostNamespace isNamespaceInHostClusterFunc) {
-	d.dispatcher.incrementOperationsInitiated()
 	const op = "check for deletion of resource or removal of managed label from"
 	const opContinuous = "Checking for deletion of resource or removal of managed label from"
-	go d.dispatcher.clusterOperation(clusterName, op, func(client generic.Client) util.ReconciliationStatus {
+	d.dispatcher.clusterOperation(clusterName, op, func(ctx context.Context, client generic.Client) util.ReconciliationStatus {
 		targetName := d.targetNameForCluster(clusterName)
 
 		klog.V(2).Infof(eventTemplate, opContinuous, d.targetGVK.Kind, targetName, clusterName)
 
 		clusterObj := &unstructured.Unstructured{}
 		clusterObj.SetGroupVersionKind(d.targetGVK)
-		err := client.Get(context.Background(), clusterObj, targetName.Namespace, targetName.Name)
+		err := client.Get(ctx, clusterObj, targetName.Namespace, targetName.Name)
 		if apierrors.IsNotFound(err) {
 			return util.StatusAllOK
 		}
@@ -19,6 +19,7 @@ package dispatch
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"strings"
 	"sync"
 	"time"
@@ -34,6 +35,7 @@ import (
 	"k8s.io/klog/v2"
 	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 
+	fedv1b1 "sigs.k8s.io/kubefed/pkg/apis/core/v1beta1"
 	"sigs.k8s.io/kubefed/pkg/client/generic"
 	"sigs.k8s.io/kubefed/pkg/controller/sync/status"
 	"sigs.k8s.io/kubefed/pkg/controller/util"
@@ -78,6 +80,7 @@ type managedDispatcherImpl struct {
 	versionMap            map[string]string
 	statusMap             status.PropagationStatusMap
 	resourceStatusMap     map[string]interface{}
+	driftedPathsMap       map[string][]string
 	skipAdoptingResources bool
 
 	// Track when resource updates are performed to allow indicating
@@ -85,18 +88,29 @@ type managedDispatcherImpl struct {
 	resourcesUpdated bool
 
 	rawResourceStatusCollection bool
+
+	// Whether resources should be written to member clusters with
+	// server-side apply rather than create and update.
+	serverSideApply bool
+
+	// Fields of the target type to retain from member clusters in
+	// addition to the built-in defaults for the target kind.
+	retainFields []fedv1b1.RetainField
 }
 
-func NewManagedDispatcher(clientAccessor clientAccessorFunc, fedResource FederatedResourceForDispatch, skipAdoptingResources, rawResourceStatusCollection bool) ManagedDispatcher {
+func NewManagedDispatcher(clientAccessor clientAccessorFunc, fedResource FederatedResourceForDispatch, skipAdoptingResources, rawResourceStatusCollection, serverSideApply bool, retainFields []fedv1b1.RetainField, timeout time.Duration, executor Executor) ManagedDispatcher {
 	d := &managedDispatcherImpl{
 		fedResource:                 fedResource,
 		versionMap:                  make(map[string]string),
 		statusMap:                   make(status.PropagationStatusMap),
 		resourceStatusMap:           make(map[string]interface{}),
+		driftedPathsMap:             make(map[string][]string),
 		skipAdoptingResources:       skipAdoptingResources,
 		rawResourceStatusCollection: rawResourceStatusCollection,
+		serverSideApply:             serverSideApply,
+		retainFields:                retainFields,
 	}
-	d.dispatcher = newOperationDispatcher(clientAccessor, d)
+	d.dispatcher = newOperationDispatcher(clientAccessor, d, timeout, executor)
 	d.unmanagedDispatcher = newUnmanagedDispatcher(d.dispatcher, d, fedResource.TargetGVK(), fedResource.TargetName())
 	return d
 }
@@ -141,23 +155,21 @@ func (d *managedDispatcherImpl) Create(clusterName string) {
 	// operation timed out.  The timeout status will be cleared by
 	// Wait() if a timeout does not occur.
 	d.RecordStatus(clusterName, status.CreationTimedOut, nil)
-	start := time.Now()
-	d.dispatcher.incrementOperationsInitiated()
 	const op = "create"
-	go d.dispatcher.clusterOperation(clusterName, op, func(client generic.Client) util.ReconciliationStatus {
+	d.dispatcher.clusterOperation(clusterName, op, func(ctx context.Context, client generic.Client) util.ReconciliationStatus {
+		start := time.Now()
 		d.recordEvent(clusterName, op, "Creating")
 
-		obj, err := d.fedResource.ObjectForCluster(clusterName)
+		obj, propStatus, err := desiredObject(d.fedResource, clusterName, nil, d.serverSideApply, d.retainFields)
 		if err != nil {
-			return d.recordOperationError(status.ComputeResourceFailed, clusterName, op, err)
+			return d.recordOperationError(propStatus, clusterName, op, err)
 		}
 
-		err = d.fedResource.ApplyOverrides(obj, clusterName)
-		if err != nil {
-			return d.recordOperationError(status.ApplyOverridesFailed, clusterName, op, err)
+		if d.serverSideApply {
+			err = createWithApply(ctx, client, obj)
+		} else {
+			err = client.Create(ctx, obj)
 		}
-
-		err = client.Create(context.Background(), obj)
 		if err == nil {
 			version := util.ObjectVersion(obj)
 			d.recordVersion(clusterName, version)
@@ -175,7 +187,7 @@ func (d *managedDispatcherImpl) Create(clusterName string) {
 
 		// Attempt to update the existing resource to ensure that it
 		// is labeled as a managed resource.
-		err = client.Get(context.Background(), obj, obj.GetNamespace(), obj.GetName())
+		err = client.Get(ctx, obj, obj.GetNamespace(), obj.GetName())
 		if err != nil {
 			wrappedErr := errors.Wrapf(err, "failed to retrieve object potentially requiring adoption")
 			return d.recordOperationError(status.RetrievalFailed, clusterName, op, wrappedErr)
@@ -198,46 +210,44 @@ func (d *managedDispatcherImpl) Create(clusterName string) {
 func (d *managedDispatcherImpl) Update(clusterName string, clusterObj *unstructured.Unstructured) {
 	d.RecordStatus(clusterName, status.UpdateTimedOut, clusterObj.Object[util.StatusField])
 
-	d.dispatcher.incrementOperationsInitiated()
 	const op = "update"
-	go d.dispatcher.clusterOperation(clusterName, op, func(client generic.Client) util.ReconciliationStatus {
+	d.dispatcher.clusterOperation(clusterName, op, func(ctx context.Context, client generic.Client) util.ReconciliationStatus {
 		if util.IsExplicitlyUnmanaged(clusterObj) {
 			err := errors.Errorf("Unable to manage the object which has label %s: %s", util.ManagedByKubeFedLabelKey, util.UnmanagedByKubeFedLabelValue)
 			return d.recordOperationError(status.ManagedLabelFalse, clusterName, op, err)
 		}
 
-		obj, err := d.fedResource.ObjectForCluster(clusterName)
-		if err != nil {
-			return d.recordOperationError(status.ComputeResourceFailed, clusterName, op, err)
-		}
-
-		err = RetainClusterFields(d.fedResource.TargetKind(), obj, clusterObj, d.fedResource.Object())
-		if err != nil {
-			wrappedErr := errors.Wrapf(err, "failed to retain fields")
-			return d.recordOperationError(status.FieldRetentionFailed, clusterName, op, wrappedErr)
-		}
-
-		err = d.fedResource.ApplyOverrides(obj, clusterName)
+		obj, propStatus, err := desiredObject(d.fedResource, clusterName, clusterObj, d.serverSideApply, d.retainFields)
 		if err != nil {
-			return d.recordOperationError(status.ApplyOverridesFailed, clusterName, op, err)
+			return d.recordOperationError(propStatus, clusterName, op, err)
 		}
 
 		version, err := d.fedResource.VersionForCluster(clusterName)
 		if err != nil {
 			return d.recordOperationError(status.VersionRetrievalFailed, clusterName, op, err)
 		}
-		if !util.ObjectNeedsUpdate(obj, clusterObj, version) {
+		decision := decideUpdate(d.fedResource.Object(), obj, clusterObj, version, d.serverSideApply)
+		if !decision.needsUpdate {
 			// Resource is current
 			d.RecordStatus(clusterName, status.UpdateTimedOut, clusterObj.Object[util.StatusField])
 			return util.StatusAllOK
 		}
 
+		if decision.drifted && !d.reconcileDrift(clusterName, decision, clusterObj) {
+			return util.StatusAllOK
+		}
+
 		// Only record an event if the resource is not current
 		d.recordEvent(clusterName, op, "Updating")
 
-		err = client.Update(context.Background(), obj)
+		propStatus = status.UpdateFailed
+		if d.serverSideApply {
+			propStatus, err = updateWithApply(ctx, client, obj, clusterObj)
+		} else {
+			err = client.Update(ctx, obj)
+		}
 		if err != nil {
-			return d.recordOperationError(status.UpdateFailed, clusterName, op, err)
+			return d.recordOperationError(propStatus, clusterName, op, err)
 		}
 		d.RecordStatus(clusterName, status.UpdateTimedOut, obj.Object[util.StatusField])
 		d.setResourcesUpdated()
@@ -247,6 +257,149 @@ func (d *managedDispatcherImpl) Update(clusterName string, clusterObj *unstructu
 	})
 }
 
+// reconcileDrift handles a cluster object that has drifted from its
+// desired state according to the drift policy of the federated
+// resource. Returns whether the cluster object should be updated.
+func (d *managedDispatcherImpl) reconcileDrift(clusterName string, decision updateDecision, clusterObj *unstructured.Unstructured) bool {
+	if decision.driftPolicy == util.DriftPolicyIgnore {
+		d.recordVersion(clusterName, util.ObjectVersion(clusterObj))
+		return false
+	}
+
+	if len(decision.driftedPaths) > 0 {
+		d.recordDrift(clusterName, decision.driftedPaths)
+		// Drift is only reported when the drifted paths differ from
+		// those recorded in the status of the federated resource, so
+		// that a resource that remains drifted is reported once.
+		if d.driftChanged(clusterName, decision.driftedPaths) {
+			metrics.DriftedResourceInc(d.fedResource.TargetKind(), clusterName, string(decision.driftPolicy))
+			targetName := d.unmanagedDispatcher.targetNameForCluster(clusterName)
+			d.fedResource.RecordEvent("DriftedInCluster", "%s %q in cluster %q has drifted from its desired state at %s",
+				d.fedResource.TargetKind(), targetName, clusterName, strings.Join(decision.driftedPaths, ", "))
+		}
+	}
+
+	if decision.update() {
+		return true
+	}
+
+	if len(decision.driftedPaths) == 0 {
+		// The fields managed by KubeFed are unchanged, so the cluster
+		// object can be considered current.
+		d.recordVersion(clusterName, util.ObjectVersion(clusterObj))
+		return false
+	}
+	d.RecordStatus(clusterName, status.Drifted, clusterObj.Object[util.StatusField])
+	return false
+}
+
+// driftChanged returns whether the given drifted paths differ from the
+// paths recorded for the named cluster in the status of the federated
+// resource.
+func (d *managedDispatcherImpl) driftChanged(clusterName string, driftedPaths []string) bool {
+	recordedPaths, err := status.DriftedPaths(d.fedResource.Object(), clusterName)
+	if err != nil {
+		klog.Errorf("Failed to retrieve the drifted paths recorded for cluster %q: %v", clusterName, err)
+		return true
+	}
+	return !reflect.DeepEqual(recordedPaths, driftedPaths)
+}
+
+// desiredObject computes the object that should exist in the named
+// cluster from the federated resource. If the object already exists in
+// the cluster, fields that are owned by the member cluster are retained
+// from clusterObj. The returned propagation status indicates the cause
+// of an error.
+func desiredObject(fedResource FederatedResourceForDispatch, clusterName string, clusterObj *unstructured.Unstructured,
+	serverSideApply bool, retainFields []fedv1b1.RetainField) (*unstructured.Unstructured, status.PropagationStatus, error) {
+	obj, err := fedResource.ObjectForCluster(clusterName)
+	if err != nil {
+		return nil, status.ComputeResourceFailed, err
+	}
+
+	// Server-side apply tracks the ownership of labels and annotations
+	// itself.
+	var metadataTracker *appliedMetadataTracker
+	if !serverSideApply {
+		metadataTracker = newAppliedMetadataTracker(obj)
+	}
+
+	if clusterObj != nil {
+		if serverSideApply {
+			err = RetainApplyFields(obj, clusterObj, fedResource.Object())
+		} else {
+			err = RetainClusterFields(fedResource.TargetKind(), retainFields, obj, clusterObj, fedResource.Object())
+		}
+		if err != nil {
+			return nil, status.FieldRetentionFailed, errors.Wrapf(err, "failed to retain fields")
+		}
+	}
+
+	if metadataTracker != nil {
+		metadataTracker.beforeOverrides(obj)
+	}
+	err = fedResource.ApplyOverrides(obj, clusterName)
+	if err != nil {
+		var substitutionErr *util.SubstitutionError
+		if errors.As(err, &substitutionErr) {
+			return nil, status.SubstitutionFailed, err
+		}
+		return nil, status.ApplyOverridesFailed, err
+	}
+	if metadataTracker != nil {
+		if err := metadataTracker.record(obj, clusterObj); err != nil {
+			return nil, status.ComputeResourceFailed, err
+		}
+	}
+	return obj, "", nil
+}
+
+// updateDecision describes whether an object in a member cluster should
+// be updated to its desired state.
+type updateDecision struct {
+	// Whether the cluster object differs from the desired object.
+	needsUpdate bool
+	// Whether the cluster object differs from the desired object even
+	// though the federated resource has not changed since it was last
+	// propagated.
+	drifted bool
+	// The drift policy of the federated resource.
+	driftPolicy util.DriftPolicy
+	// The paths of the fields that have drifted. Not computed if drift
+	// is ignored.
+	driftedPaths []string
+}
+
+// update returns whether the cluster object should be written.
+func (u updateDecision) update() bool {
+	return u.needsUpdate && (!u.drifted || u.driftPolicy == util.DriftPolicyRevert)
+}
+
+// decideUpdate determines whether the given cluster object should be
+// updated to the desired object given the version last propagated to
+// the cluster.
+func decideUpdate(fedObj, obj, clusterObj *unstructured.Unstructured, version string, serverSideApply bool) updateDecision {
+	needsUpdate := util.ObjectNeedsUpdate
+	if serverSideApply {
+		needsUpdate = util.ObjectNeedsApply
+	}
+	decision := updateDecision{
+		needsUpdate: needsUpdate(obj, clusterObj, version),
+	}
+	// A recorded version indicates that the federated resource
+	// has not changed since it was last propagated, so a resource
+	// that is not current has drifted in the member cluster.
+	if !decision.needsUpdate || version == "" {
+		return decision
+	}
+	decision.drifted = true
+	decision.driftPolicy = util.GetDriftPolicy(fedObj)
+	if decision.driftPolicy != util.DriftPolicyIgnore {
+		decision.driftedPaths = util.DriftedPaths(obj, clusterObj)
+	}
+	return decision
+}
+
 func (d *managedDispatcherImpl) Delete(clusterName string, opts ...runtimeclient.DeleteOption) {
 	d.RecordStatus(clusterName, status.DeletionTimedOut, nil)
 
@@ -311,6 +464,12 @@ func (d *managedDispatcherImpl) recordVersion(clusterName, version string) {
 	d.versionMap[clusterName] = version
 }
 
+func (d *managedDispatcherImpl) recordDrift(clusterName string, driftedPaths []string) {
+	d.Lock()
+	defer d.Unlock()
+	d.driftedPathsMap[clusterName] = driftedPaths
+}
+
 func (d *managedDispatcherImpl) setResourcesUpdated() {
 	d.Lock()
 	defer d.Unlock()
@@ -329,9 +488,15 @@ func (d *managedDispatcherImpl) CollectedStatus() (status.CollectedPropagationSt
 	for key, value := range d.resourceStatusMap {
 		resourceStatusMap[key] = value
 	}
+
+	driftedPathsMap := make(map[string][]string)
+	for key, value := range d.driftedPathsMap {
+		driftedPathsMap[key] = value
+	}
 	return status.CollectedPropagationStatus{
 			StatusMap:        statusMap,
 			ResourcesUpdated: d.resourcesUpdated,
+			DriftedPaths:     driftedPathsMap,
 		}, status.CollectedResourceStatus{
 			StatusMap:        resourceStatusMap,
 			ResourcesUpdated: d.resourcesUpdated,
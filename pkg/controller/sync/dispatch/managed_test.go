@@ -0,0 +1,129 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatch
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"sigs.k8s.io/kubefed/pkg/client/generic"
+	"sigs.k8s.io/kubefed/pkg/controller/sync/status"
+	"sigs.k8s.io/kubefed/pkg/controller/util"
+)
+
+func TestReconcileDrift(t *testing.T) {
+	const clusterName = "cluster1"
+	driftedPaths := []string{"/data/key"}
+
+	testCases := map[string]struct {
+		driftPolicy    util.DriftPolicy
+		recordedPaths  []string
+		driftedPaths   []string
+		expectedUpdate bool
+		expectedEvents int
+		expectedStatus status.PropagationStatus
+		expectedPaths  []string
+	}{
+		"Reverted drift is recorded": {
+			driftPolicy:    util.DriftPolicyRevert,
+			driftedPaths:   driftedPaths,
+			expectedUpdate: true,
+			expectedEvents: 1,
+			expectedPaths:  driftedPaths,
+		},
+		"Reported drift is recorded": {
+			driftPolicy:    util.DriftPolicyReport,
+			driftedPaths:   driftedPaths,
+			expectedEvents: 1,
+			expectedStatus: status.Drifted,
+			expectedPaths:  driftedPaths,
+		},
+		"Drift that is already recorded is not reported again": {
+			driftPolicy:    util.DriftPolicyReport,
+			recordedPaths:  driftedPaths,
+			driftedPaths:   driftedPaths,
+			expectedStatus: status.Drifted,
+			expectedPaths:  driftedPaths,
+		},
+		"Drift of other fields is reported": {
+			driftPolicy:    util.DriftPolicyReport,
+			recordedPaths:  []string{"/data/other"},
+			driftedPaths:   driftedPaths,
+			expectedEvents: 1,
+			expectedStatus: status.Drifted,
+			expectedPaths:  driftedPaths,
+		},
+		"Ignored drift is not recorded": {
+			driftPolicy: util.DriftPolicyIgnore,
+		},
+	}
+
+	for testName, tc := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			fedObject := &unstructured.Unstructured{Object: map[string]interface{}{
+				"apiVersion": "types.kubefed.io/v1beta1",
+				"kind":       "FederatedConfigMap",
+			}}
+			if tc.recordedPaths != nil {
+				_, err := status.SetClusterStatus(fedObject, clusterName, &status.GenericClusterStatus{
+					Status:       status.Drifted,
+					DriftedPaths: tc.recordedPaths,
+				})
+				require.NoError(t, err)
+			}
+			fedResource := &fakeEventFederatedResource{
+				fakeFederatedResource: fakeFederatedResource{fedObject: fedObject},
+			}
+			clientAccessor := func(string) (generic.Client, error) { return nil, nil }
+			d := NewManagedDispatcher(clientAccessor, fedResource, false, false, false, nil, time.Second, nil).(*managedDispatcherImpl)
+
+			decision := updateDecision{
+				needsUpdate:  true,
+				drifted:      true,
+				driftPolicy:  tc.driftPolicy,
+				driftedPaths: tc.driftedPaths,
+			}
+			update := d.reconcileDrift(clusterName, decision, newPlanConfigMap("drifted", 0, true))
+
+			assert.Equal(t, tc.expectedUpdate, update)
+			assert.Equal(t, tc.expectedEvents, fedResource.events)
+			assert.Equal(t, tc.expectedStatus, d.statusMap[clusterName])
+			assert.Equal(t, tc.expectedPaths, d.driftedPathsMap[clusterName])
+		})
+	}
+}
+
+// fakeEventFederatedResource counts the events recorded for the
+// federated resource.
+type fakeEventFederatedResource struct {
+	fakeFederatedResource
+
+	events int
+}
+
+func (r *fakeEventFederatedResource) TargetName() util.QualifiedName {
+	return util.QualifiedName{Namespace: "test-ns", Name: "test"}
+}
+
+func (r *fakeEventFederatedResource) RecordEvent(reason, messageFmt string, args ...interface{}) {
+	r.events++
+}
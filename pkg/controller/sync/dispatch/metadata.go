@@ -0,0 +1,147 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatch
+
+import (
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"sigs.k8s.io/kubefed/pkg/controller/util"
+)
+
+// retainMetadata merges the labels and annotations of the desired
+// object with those of the cluster object. Keys recorded as applied by
+// KubeFed in the cluster object are replaced by the keys of the desired
+// object, and all other keys of the cluster object are preserved.
+//
+// A cluster object without a record was last updated by a version of
+// KubeFed that replaced labels and retained annotations wholesale, so
+// all of its labels and none of its annotations are considered to have
+// been applied by KubeFed.
+func retainMetadata(desiredObj, clusterObj *unstructured.Unstructured) error {
+	applied, err := util.GetAppliedMetadata(clusterObj)
+	if err != nil {
+		return err
+	}
+	if applied == nil {
+		applied = &util.AppliedMetadata{Labels: sortedKeys(clusterObj.GetLabels())}
+	}
+	desiredObj.SetLabels(mergeMetadata(clusterObj.GetLabels(), applied.Labels, desiredObj.GetLabels()))
+	desiredObj.SetAnnotations(mergeMetadata(clusterObj.GetAnnotations(), applied.Annotations, desiredObj.GetAnnotations()))
+	return nil
+}
+
+func mergeMetadata(clusterValues map[string]string, appliedKeys []string, desiredValues map[string]string) map[string]string {
+	merged := make(map[string]string, len(clusterValues)+len(desiredValues))
+	for key, value := range clusterValues {
+		merged[key] = value
+	}
+	for _, key := range appliedKeys {
+		delete(merged, key)
+	}
+	for key, value := range desiredValues {
+		merged[key] = value
+	}
+	if len(merged) == 0 {
+		return nil
+	}
+	return merged
+}
+
+// appliedMetadataTracker determines the label and annotation keys of a
+// desired object that are applied by KubeFed: the keys set by the
+// template and the keys added or changed by overrides.
+type appliedMetadataTracker struct {
+	applied util.AppliedMetadata
+
+	labels      map[string]string
+	annotations map[string]string
+}
+
+// newAppliedMetadataTracker returns a tracker for an object computed
+// from the template of a federated resource.
+func newAppliedMetadataTracker(templateObj *unstructured.Unstructured) *appliedMetadataTracker {
+	return &appliedMetadataTracker{
+		applied: util.AppliedMetadata{
+			Labels:      sortedKeys(templateObj.GetLabels()),
+			Annotations: sortedKeys(templateObj.GetAnnotations()),
+		},
+	}
+}
+
+// beforeOverrides captures the labels and annotations of the object
+// before overrides are applied to it.
+func (t *appliedMetadataTracker) beforeOverrides(obj *unstructured.Unstructured) {
+	t.labels = obj.GetLabels()
+	t.annotations = obj.GetAnnotations()
+}
+
+// record sets the last applied metadata annotation of the object after
+// overrides have been applied. The annotation is only set if KubeFed
+// applies labels or annotations or if the cluster object (which may be
+// nil) already has the annotation, so that objects propagated from
+// templates without metadata are left as they were.
+func (t *appliedMetadataTracker) record(obj, clusterObj *unstructured.Unstructured) error {
+	applied := util.AppliedMetadata{
+		Labels:      overriddenKeys(t.applied.Labels, t.labels, obj.GetLabels()),
+		Annotations: overriddenKeys(t.applied.Annotations, t.annotations, obj.GetAnnotations()),
+	}
+	if applied.IsEmpty() && (clusterObj == nil || !hasAppliedMetadata(clusterObj)) {
+		return nil
+	}
+	return errors.Wrap(util.SetAppliedMetadata(obj, &applied), "failed to record applied metadata")
+}
+
+// overriddenKeys returns the sorted union of the given applied keys and
+// the keys whose value was added or changed by overrides. Keys removed
+// by overrides are excluded.
+func overriddenKeys(appliedKeys []string, before, after map[string]string) []string {
+	keys := make(map[string]string)
+	for _, key := range appliedKeys {
+		if value, ok := after[key]; ok {
+			keys[key] = value
+		}
+	}
+	for key, value := range after {
+		if beforeValue, ok := before[key]; !ok || beforeValue != value {
+			keys[key] = value
+		}
+	}
+	return sortedKeys(keys)
+}
+
+func hasAppliedMetadata(obj *unstructured.Unstructured) bool {
+	_, ok := obj.GetAnnotations()[util.LastAppliedMetadataAnnotation]
+	return ok
+}
+
+// sortedKeys returns the sorted keys of the given labels or annotations,
+// excluding those maintained by KubeFed itself.
+func sortedKeys(values map[string]string) []string {
+	var keys []string
+	for key := range values {
+		if key == util.ManagedByKubeFedLabelKey || key == util.LastAppliedMetadataAnnotation {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
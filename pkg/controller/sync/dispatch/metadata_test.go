@@ -0,0 +1,166 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"sigs.k8s.io/kubefed/pkg/controller/util"
+)
+
+func TestDesiredObjectMetadata(t *testing.T) {
+	testCases := map[string]struct {
+		templateLabels      map[string]string
+		templateAnnotations map[string]string
+		overrideLabels      map[string]string
+		clusterLabels       map[string]string
+		clusterAnnotations  map[string]string
+		serverSideApply     bool
+		expectedLabels      map[string]string
+		expectedAnnotations map[string]string
+	}{
+		"Template metadata is recorded on creation": {
+			templateLabels:      map[string]string{"app": "foo"},
+			templateAnnotations: map[string]string{"note": "foo"},
+			expectedLabels:      map[string]string{"app": "foo", util.ManagedByKubeFedLabelKey: "true"},
+			expectedAnnotations: map[string]string{
+				"note":                             "foo",
+				util.LastAppliedMetadataAnnotation: `{"labels":["app"],"annotations":["note"]}`,
+			},
+		},
+		"Creation without template metadata is not recorded": {
+			expectedLabels: map[string]string{util.ManagedByKubeFedLabelKey: "true"},
+		},
+		"Keys set in the member cluster are preserved": {
+			templateLabels:      map[string]string{"app": "foo"},
+			templateAnnotations: map[string]string{"note": "foo"},
+			clusterLabels:       map[string]string{"app": "bar", "zone": "a", util.ManagedByKubeFedLabelKey: "true"},
+			clusterAnnotations: map[string]string{
+				"note":                             "bar",
+				"controller":                       "value",
+				util.LastAppliedMetadataAnnotation: `{"labels":["app"],"annotations":["note"]}`,
+			},
+			expectedLabels: map[string]string{"app": "foo", "zone": "a", util.ManagedByKubeFedLabelKey: "true"},
+			expectedAnnotations: map[string]string{
+				"note":                             "foo",
+				"controller":                       "value",
+				util.LastAppliedMetadataAnnotation: `{"labels":["app"],"annotations":["note"]}`,
+			},
+		},
+		"Keys removed from the template are removed": {
+			templateLabels: map[string]string{"app": "foo"},
+			clusterLabels:  map[string]string{"app": "foo", "tier": "web", "zone": "a", util.ManagedByKubeFedLabelKey: "true"},
+			clusterAnnotations: map[string]string{
+				"note":                             "foo",
+				"controller":                       "value",
+				util.LastAppliedMetadataAnnotation: `{"labels":["app","tier"],"annotations":["note"]}`,
+			},
+			expectedLabels: map[string]string{"app": "foo", "zone": "a", util.ManagedByKubeFedLabelKey: "true"},
+			expectedAnnotations: map[string]string{
+				"controller":                       "value",
+				util.LastAppliedMetadataAnnotation: `{"labels":["app"]}`,
+			},
+		},
+		"Keys added by overrides are recorded": {
+			templateLabels: map[string]string{"app": "foo"},
+			overrideLabels: map[string]string{"region": "us"},
+			clusterLabels:  map[string]string{"app": "foo", "zone": "a", util.ManagedByKubeFedLabelKey: "true"},
+			clusterAnnotations: map[string]string{
+				util.LastAppliedMetadataAnnotation: `{"labels":["app"]}`,
+			},
+			expectedLabels: map[string]string{"app": "foo", "region": "us", "zone": "a", util.ManagedByKubeFedLabelKey: "true"},
+			expectedAnnotations: map[string]string{
+				util.LastAppliedMetadataAnnotation: `{"labels":["app","region"]}`,
+			},
+		},
+		"Labels are replaced and annotations retained for a resource without a record": {
+			templateLabels:      map[string]string{"app": "foo"},
+			clusterLabels:       map[string]string{"app": "bar", "zone": "a", util.ManagedByKubeFedLabelKey: "true"},
+			clusterAnnotations:  map[string]string{"controller": "value"},
+			expectedLabels:      map[string]string{"app": "foo", util.ManagedByKubeFedLabelKey: "true"},
+			expectedAnnotations: map[string]string{"controller": "value", util.LastAppliedMetadataAnnotation: `{"labels":["app"]}`},
+		},
+		"Template metadata is applied as-is with server-side apply": {
+			templateLabels:      map[string]string{"app": "foo"},
+			templateAnnotations: map[string]string{"note": "foo"},
+			clusterLabels:       map[string]string{"zone": "a"},
+			clusterAnnotations:  map[string]string{"controller": "value"},
+			serverSideApply:     true,
+			expectedLabels:      map[string]string{"app": "foo", util.ManagedByKubeFedLabelKey: "true"},
+			expectedAnnotations: map[string]string{"note": "foo"},
+		},
+	}
+
+	for testName, tc := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			fedResource := &fakeMetadataFederatedResource{
+				fakeFederatedResource: fakeFederatedResource{
+					fedObject: &unstructured.Unstructured{Object: map[string]interface{}{}},
+				},
+				labels:         tc.templateLabels,
+				annotations:    tc.templateAnnotations,
+				overrideLabels: tc.overrideLabels,
+			}
+			var clusterObj *unstructured.Unstructured
+			if tc.clusterLabels != nil || tc.clusterAnnotations != nil {
+				clusterObj = newPlanConfigMap("desired", 1, false)
+				clusterObj.SetLabels(tc.clusterLabels)
+				clusterObj.SetAnnotations(tc.clusterAnnotations)
+			}
+
+			obj, _, err := desiredObject(fedResource, "cluster1", clusterObj, tc.serverSideApply, nil)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedLabels, obj.GetLabels())
+			assert.Equal(t, tc.expectedAnnotations, obj.GetAnnotations())
+		})
+	}
+}
+
+// fakeMetadataFederatedResource computes objects with the configured
+// template metadata and adds the configured labels via overrides.
+type fakeMetadataFederatedResource struct {
+	fakeFederatedResource
+
+	labels         map[string]string
+	annotations    map[string]string
+	overrideLabels map[string]string
+}
+
+func (r *fakeMetadataFederatedResource) ObjectForCluster(clusterName string) (*unstructured.Unstructured, error) {
+	obj := newPlanConfigMap("desired", 0, false)
+	obj.SetLabels(r.labels)
+	obj.SetAnnotations(r.annotations)
+	return obj, nil
+}
+
+func (r *fakeMetadataFederatedResource) ApplyOverrides(obj *unstructured.Unstructured, clusterName string) error {
+	labels := obj.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	for key, value := range r.overrideLabels {
+		labels[key] = value
+	}
+	obj.SetLabels(labels)
+	util.AddManagedLabel(obj)
+	return nil
+}
@@ -17,7 +17,8 @@ limitations under the License.
 package dispatch
 
 import (
-	"sync/atomic"
+	"context"
+	"sync"
 	"time"
 
 	"github.com/pkg/errors"
@@ -29,6 +30,10 @@ import (
 	"sigs.k8s.io/kubefed/pkg/controller/util"
 )
 
+// DefaultOperationTimeout is the time allowed for the operations
+// dispatched to member clusters to complete if no timeout is specified.
+const DefaultOperationTimeout = 30 * time.Second
+
 type clientAccessorFunc func(clusterName string) (generic.Client, error)
 
 type dispatchRecorder interface {
@@ -45,55 +50,111 @@ type OperationDispatcher interface {
 	Wait() (ok bool, timeoutErr error)
 }
 
+// Executor runs the operations dispatched to member clusters.
+type Executor interface {
+	// Execute runs the given operation against the named cluster,
+	// either immediately or at a later time.
+	Execute(clusterName string, operation func())
+}
+
+// InlineExecutor runs operations in the calling goroutine.
+type InlineExecutor struct{}
+
+func (InlineExecutor) Execute(clusterName string, operation func()) {
+	operation()
+}
+
+// goroutineExecutor runs each operation in its own goroutine.
+type goroutineExecutor struct{}
+
+func (goroutineExecutor) Execute(clusterName string, operation func()) {
+	go operation()
+}
+
 type operationDispatcherImpl struct {
+	sync.Mutex
+
 	clientAccessor clientAccessorFunc
+	executor       Executor
 
-	resultChan          chan util.ReconciliationStatus
-	operationsInitiated int32
+	operationsInitiated int
+	operationsCompleted int
+	failed              bool
+	// Signalled when an operation completes.
+	completedChan chan struct{}
+	// Set once Wait has timed out. Operations that have not started
+	// by then are abandoned.
+	abandoned bool
 
 	timeout time.Duration
 
 	recorder dispatchRecorder
 }
 
-func newOperationDispatcher(clientAccessor clientAccessorFunc, recorder dispatchRecorder) *operationDispatcherImpl {
+// newOperationDispatcher returns a dispatcher that runs operations
+// with the given executor, or in a goroutine per operation if the
+// executor is nil.
+func newOperationDispatcher(clientAccessor clientAccessorFunc, recorder dispatchRecorder, timeout time.Duration, executor Executor) *operationDispatcherImpl {
+	if timeout <= 0 {
+		timeout = DefaultOperationTimeout
+	}
+	if executor == nil {
+		executor = goroutineExecutor{}
+	}
 	return &operationDispatcherImpl{
 		clientAccessor: clientAccessor,
-		resultChan:     make(chan util.ReconciliationStatus),
-		timeout:        30 * time.Second, // TODO(marun) Make this configurable
+		executor:       executor,
+		completedChan:  make(chan struct{}, 1),
+		timeout:        timeout,
 		recorder:       recorder,
 	}
 }
 
 func (d *operationDispatcherImpl) Wait() (bool, error) {
-	ok := true
-	timedOut := false
-	start := time.Now()
-	for i := int32(0); i < atomic.LoadInt32(&d.operationsInitiated); i++ {
-		now := time.Now()
-		if !now.Before(start.Add(d.timeout)) {
-			timedOut = true
-			break
+	timer := time.NewTimer(d.timeout)
+	defer timer.Stop()
+	for {
+		d.Lock()
+		initiated := d.operationsInitiated
+		if d.operationsCompleted == initiated {
+			ok := !d.failed
+			d.Unlock()
+			return ok, nil
 		}
+		d.Unlock()
+
 		select {
-		case result := <-d.resultChan:
-			if result == util.StatusError {
-				ok = false
-			}
-			break
-		case <-time.After(start.Add(d.timeout).Sub(now)):
-			timedOut = true
-			break
+		case <-d.completedChan:
+		case <-timer.C:
+			d.Lock()
+			d.abandoned = true
+			d.Unlock()
+			return false, errors.Errorf("Failed to finish %d operations in %v", initiated, d.timeout)
 		}
 	}
-	if timedOut {
-		return false, errors.Errorf("Failed to finish %d operations in %v", atomic.LoadInt32(&d.operationsInitiated), d.timeout)
-	}
-	return ok, nil
 }
 
-func (d *operationDispatcherImpl) clusterOperation(clusterName, op string, opFunc func(generic.Client) util.ReconciliationStatus) {
-	// TODO(marun) Support cancellation of client calls on timeout.
+// clusterOperation schedules the given operation against the named
+// cluster with the executor of the dispatcher. Client calls made with
+// the context passed to the operation are cancelled once the timeout
+// of the dispatcher has elapsed.
+func (d *operationDispatcherImpl) clusterOperation(clusterName, op string, opFunc func(context.Context, generic.Client) util.ReconciliationStatus) {
+	d.Lock()
+	d.operationsInitiated++
+	d.Unlock()
+	d.executor.Execute(clusterName, func() {
+		d.completeOperation(d.runClusterOperation(clusterName, op, opFunc))
+	})
+}
+
+func (d *operationDispatcherImpl) runClusterOperation(clusterName, op string, opFunc func(context.Context, generic.Client) util.ReconciliationStatus) util.ReconciliationStatus {
+	d.Lock()
+	abandoned := d.abandoned
+	d.Unlock()
+	if abandoned {
+		return util.StatusError
+	}
+
 	client, err := d.clientAccessor(clusterName)
 	if err != nil {
 		wrappedErr := errors.Wrapf(err, "Error retrieving client for cluster")
@@ -102,15 +163,24 @@ func (d *operationDispatcherImpl) clusterOperation(clusterName, op string, opFun
 		} else {
 			d.recorder.recordOperationError(status.ClientRetrievalFailed, clusterName, op, wrappedErr)
 		}
-		d.resultChan <- util.StatusError
-		return
+		return util.StatusError
 	}
 
 	// TODO(marun) Retry on recoverable errors (e.g. IsConflict, AlreadyExists)
-	ok := opFunc(client)
-	d.resultChan <- ok
+	ctx, cancel := context.WithTimeout(context.Background(), d.timeout)
+	defer cancel()
+	return opFunc(ctx, client)
 }
 
-func (d *operationDispatcherImpl) incrementOperationsInitiated() {
-	atomic.AddInt32(&d.operationsInitiated, 1)
+func (d *operationDispatcherImpl) completeOperation(result util.ReconciliationStatus) {
+	d.Lock()
+	d.operationsCompleted++
+	if result == util.StatusError {
+		d.failed = true
+	}
+	d.Unlock()
+	select {
+	case d.completedChan <- struct{}{}:
+	default:
+	}
 }
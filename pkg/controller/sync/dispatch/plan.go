@@ -0,0 +1,234 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatch
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	fedv1b1 "sigs.k8s.io/kubefed/pkg/apis/core/v1beta1"
+	"sigs.k8s.io/kubefed/pkg/controller/sync/status"
+	"sigs.k8s.io/kubefed/pkg/controller/util"
+)
+
+// PlannedOperation is an operation that propagation of a federated
+// resource would perform in a member cluster.
+type PlannedOperation string
+
+const (
+	OperationNone               PlannedOperation = "None"
+	OperationCreate             PlannedOperation = "Create"
+	OperationUpdate             PlannedOperation = "Update"
+	OperationDelete             PlannedOperation = "Delete"
+	OperationRemoveManagedLabel PlannedOperation = "RemoveManagedLabel"
+)
+
+// ClusterPlan describes the outcome of propagating a federated resource
+// to a member cluster.
+type ClusterPlan struct {
+	ClusterName string           `json:"clusterName"`
+	Operation   PlannedOperation `json:"operation"`
+	// The propagation status that would be recorded for the cluster.
+	Status status.PropagationStatus `json:"status,omitempty"`
+	// A human-readable explanation of the operation or status.
+	Message string `json:"message,omitempty"`
+	// The fields of the cluster object that have drifted from their
+	// desired state.
+	DriftedPaths []string `json:"driftedPaths,omitempty"`
+	// The object that would be written to the cluster, after field
+	// retention and overrides have been applied. Only set for create
+	// and update.
+	DesiredObject *unstructured.Unstructured `json:"desiredObject,omitempty"`
+	// The object that currently exists in the cluster, if any.
+	ClusterObject *unstructured.Unstructured `json:"clusterObject,omitempty"`
+}
+
+// PlanningDispatcher records the operations that a managed dispatcher
+// would perform in member clusters without performing them. Decisions
+// are made with the same logic as the managed dispatcher, and member
+// clusters are only read from.
+type PlanningDispatcher struct {
+	sync.Mutex
+
+	clientAccessor        clientAccessorFunc
+	fedResource           FederatedResourceForDispatch
+	skipAdoptingResources bool
+	serverSideApply       bool
+	retainFields          []fedv1b1.RetainField
+
+	plans map[string]*ClusterPlan
+}
+
+func NewPlanningDispatcher(clientAccessor clientAccessorFunc, fedResource FederatedResourceForDispatch, skipAdoptingResources, serverSideApply bool, retainFields []fedv1b1.RetainField) *PlanningDispatcher {
+	return &PlanningDispatcher{
+		clientAccessor:        clientAccessor,
+		fedResource:           fedResource,
+		skipAdoptingResources: skipAdoptingResources,
+		serverSideApply:       serverSideApply,
+		retainFields:          retainFields,
+		plans:                 make(map[string]*ClusterPlan),
+	}
+}
+
+func (d *PlanningDispatcher) Create(clusterName string) {
+	obj, propStatus, err := desiredObject(d.fedResource, clusterName, nil, d.serverSideApply, d.retainFields)
+	if err != nil {
+		d.RecordClusterError(propStatus, clusterName, err)
+		return
+	}
+
+	// The managed dispatcher only discovers a resource that exists but
+	// is not managed when creation fails, so check for it up front.
+	client, err := d.clientAccessor(clusterName)
+	if err != nil {
+		d.RecordClusterError(status.ClientRetrievalFailed, clusterName, err)
+		return
+	}
+	clusterObj := &unstructured.Unstructured{}
+	clusterObj.SetGroupVersionKind(obj.GroupVersionKind())
+	err = client.Get(context.Background(), clusterObj, obj.GetNamespace(), obj.GetName())
+	if apierrors.IsNotFound(err) {
+		d.record(clusterName, &ClusterPlan{
+			Operation:     OperationCreate,
+			Status:        status.ClusterPropagationOK,
+			DesiredObject: obj,
+		})
+		return
+	}
+	if err != nil {
+		wrappedErr := errors.Wrapf(err, "failed to retrieve object potentially requiring adoption")
+		d.RecordClusterError(status.RetrievalFailed, clusterName, wrappedErr)
+		return
+	}
+
+	if d.skipAdoptingResources && !d.fedResource.IsNamespaceInHostCluster(clusterObj) {
+		d.record(clusterName, &ClusterPlan{
+			Operation:     OperationNone,
+			Status:        status.AlreadyExists,
+			Message:       "Resource pre-exist in cluster",
+			ClusterObject: clusterObj,
+		})
+		return
+	}
+	d.Update(clusterName, clusterObj)
+}
+
+func (d *PlanningDispatcher) Update(clusterName string, clusterObj *unstructured.Unstructured) {
+	plan := &ClusterPlan{
+		Operation:     OperationNone,
+		ClusterObject: clusterObj,
+	}
+	defer d.record(clusterName, plan)
+
+	if util.IsExplicitlyUnmanaged(clusterObj) {
+		plan.Status = status.ManagedLabelFalse
+		plan.Message = fmt.Sprintf("Unable to manage the object which has label %s: %s", util.ManagedByKubeFedLabelKey, util.UnmanagedByKubeFedLabelValue)
+		return
+	}
+
+	obj, propStatus, err := desiredObject(d.fedResource, clusterName, clusterObj, d.serverSideApply, d.retainFields)
+	if err != nil {
+		plan.Status = propStatus
+		plan.Message = err.Error()
+		return
+	}
+	plan.DesiredObject = obj
+
+	version, err := d.fedResource.VersionForCluster(clusterName)
+	if err != nil {
+		plan.Status = status.VersionRetrievalFailed
+		plan.Message = err.Error()
+		return
+	}
+	decision := decideUpdate(d.fedResource.Object(), obj, clusterObj, version, d.serverSideApply)
+	plan.DriftedPaths = decision.driftedPaths
+	switch {
+	case decision.update():
+		plan.Operation = OperationUpdate
+		if !util.HasManagedLabel(clusterObj) {
+			plan.Message = "Resource exists in cluster and will be adopted"
+		} else if decision.drifted {
+			plan.Message = "Resource has drifted and will be reverted"
+		}
+	case decision.driftPolicy == util.DriftPolicyIgnore:
+		plan.Message = "Resource has drifted and will be left untouched"
+	case len(decision.driftedPaths) > 0:
+		plan.Status = status.Drifted
+		plan.Message = "Resource has drifted and will be reported"
+	default:
+		plan.Message = "Resource is up to date"
+	}
+}
+
+func (d *PlanningDispatcher) Delete(clusterName string, opts ...runtimeclient.DeleteOption) {
+	d.record(clusterName, &ClusterPlan{
+		Operation: OperationDelete,
+		Status:    status.WaitingForRemoval,
+	})
+}
+
+func (d *PlanningDispatcher) RemoveManagedLabel(clusterName string, clusterObj *unstructured.Unstructured) {
+	d.record(clusterName, &ClusterPlan{
+		Operation:     OperationRemoveManagedLabel,
+		Status:        status.ClusterPropagationOK,
+		ClusterObject: clusterObj,
+	})
+}
+
+func (d *PlanningDispatcher) RecordClusterError(propStatus status.PropagationStatus, clusterName string, err error) {
+	d.record(clusterName, &ClusterPlan{
+		Operation: OperationNone,
+		Status:    propStatus,
+		Message:   err.Error(),
+	})
+}
+
+func (d *PlanningDispatcher) RecordStatus(clusterName string, propStatus status.PropagationStatus, resourceStatus interface{}) {
+	d.record(clusterName, &ClusterPlan{
+		Operation: OperationNone,
+		Status:    propStatus,
+	})
+}
+
+// Plans returns the recorded plans sorted by cluster name.
+func (d *PlanningDispatcher) Plans() []ClusterPlan {
+	d.Lock()
+	defer d.Unlock()
+	plans := make([]ClusterPlan, 0, len(d.plans))
+	for _, plan := range d.plans {
+		plans = append(plans, *plan)
+	}
+	sort.Slice(plans, func(i, j int) bool {
+		return plans[i].ClusterName < plans[j].ClusterName
+	})
+	return plans
+}
+
+func (d *PlanningDispatcher) record(clusterName string, plan *ClusterPlan) {
+	d.Lock()
+	defer d.Unlock()
+	plan.ClusterName = clusterName
+	d.plans[clusterName] = plan
+}
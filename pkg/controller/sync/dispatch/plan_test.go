@@ -0,0 +1,203 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dispatch
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"sigs.k8s.io/kubefed/pkg/client/generic"
+	"sigs.k8s.io/kubefed/pkg/controller/sync/status"
+	"sigs.k8s.io/kubefed/pkg/controller/util"
+)
+
+func TestPlanningDispatcher(t *testing.T) {
+	const clusterName = "cluster1"
+	current := newPlanConfigMap("desired", 2, true)
+	changed := newPlanConfigMap("changed", 2, true)
+	unmanaged := newPlanConfigMap("existing", 1, false)
+
+	testCases := map[string]struct {
+		dispatch              func(d *PlanningDispatcher)
+		existingObj           *unstructured.Unstructured
+		version               string
+		driftPolicy           util.DriftPolicy
+		skipAdoptingResources bool
+		expectedOperation     PlannedOperation
+		expectedStatus        status.PropagationStatus
+		expectedDriftedPaths  []string
+		expectDesiredObject   bool
+	}{
+		"Missing resource is created": {
+			dispatch:            func(d *PlanningDispatcher) { d.Create(clusterName) },
+			expectedOperation:   OperationCreate,
+			expectDesiredObject: true,
+		},
+		"Existing unmanaged resource is adopted": {
+			dispatch:            func(d *PlanningDispatcher) { d.Create(clusterName) },
+			existingObj:         unmanaged,
+			expectedOperation:   OperationUpdate,
+			expectDesiredObject: true,
+		},
+		"Existing unmanaged resource is not adopted if adoption is disabled": {
+			dispatch:              func(d *PlanningDispatcher) { d.Create(clusterName) },
+			existingObj:           unmanaged,
+			skipAdoptingResources: true,
+			expectedOperation:     OperationNone,
+			expectedStatus:        status.AlreadyExists,
+		},
+		"Current resource is not updated": {
+			dispatch:            func(d *PlanningDispatcher) { d.Update(clusterName, current) },
+			version:             util.ObjectVersion(current),
+			expectedOperation:   OperationNone,
+			expectDesiredObject: true,
+		},
+		"Resource is updated after the federated resource changes": {
+			dispatch:            func(d *PlanningDispatcher) { d.Update(clusterName, changed) },
+			expectedOperation:   OperationUpdate,
+			expectDesiredObject: true,
+		},
+		"Drifted resource is reverted": {
+			dispatch:             func(d *PlanningDispatcher) { d.Update(clusterName, changed) },
+			version:              "gen:1",
+			expectedOperation:    OperationUpdate,
+			expectedDriftedPaths: []string{"/data/key"},
+			expectDesiredObject:  true,
+		},
+		"Drifted resource is reported": {
+			dispatch:             func(d *PlanningDispatcher) { d.Update(clusterName, changed) },
+			version:              "gen:1",
+			driftPolicy:          util.DriftPolicyReport,
+			expectedOperation:    OperationNone,
+			expectedStatus:       status.Drifted,
+			expectedDriftedPaths: []string{"/data/key"},
+			expectDesiredObject:  true,
+		},
+		"Resource is deleted": {
+			dispatch:          func(d *PlanningDispatcher) { d.Delete(clusterName) },
+			expectedOperation: OperationDelete,
+			expectedStatus:    status.WaitingForRemoval,
+		},
+	}
+
+	for testName, tc := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			fedResource := &fakeFederatedResource{
+				fedObject: &unstructured.Unstructured{Object: map[string]interface{}{}},
+				version:   tc.version,
+			}
+			if tc.driftPolicy != "" {
+				err := unstructured.SetNestedField(fedResource.fedObject.Object, string(tc.driftPolicy), util.SpecField, util.DriftPolicyField)
+				require.NoError(t, err)
+			}
+			client := &fakeGetClient{obj: tc.existingObj}
+			clientAccessor := func(string) (generic.Client, error) { return client, nil }
+			d := NewPlanningDispatcher(clientAccessor, fedResource, tc.skipAdoptingResources, false, nil)
+
+			tc.dispatch(d)
+
+			plans := d.Plans()
+			require.Len(t, plans, 1)
+			plan := plans[0]
+			assert.Equal(t, clusterName, plan.ClusterName)
+			assert.Equal(t, tc.expectedOperation, plan.Operation)
+			assert.Equal(t, tc.expectedStatus, plan.Status)
+			assert.Equal(t, tc.expectedDriftedPaths, plan.DriftedPaths)
+			assert.Equal(t, tc.expectDesiredObject, plan.DesiredObject != nil)
+		})
+	}
+}
+
+func newPlanConfigMap(value string, generation int64, managed bool) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata": map[string]interface{}{
+				"name":      "test",
+				"namespace": "test-ns",
+			},
+			"data": map[string]interface{}{"key": value},
+		},
+	}
+	if generation != 0 {
+		obj.SetGeneration(generation)
+	}
+	if managed {
+		util.AddManagedLabel(obj)
+	}
+	return obj
+}
+
+type fakeFederatedResource struct {
+	FederatedResourceForDispatch
+
+	fedObject *unstructured.Unstructured
+	version   string
+}
+
+func (r *fakeFederatedResource) TargetKind() string {
+	return "ConfigMap"
+}
+
+func (r *fakeFederatedResource) TargetGVK() schema.GroupVersionKind {
+	return schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}
+}
+
+func (r *fakeFederatedResource) Object() *unstructured.Unstructured {
+	return r.fedObject
+}
+
+func (r *fakeFederatedResource) VersionForCluster(clusterName string) (string, error) {
+	return r.version, nil
+}
+
+func (r *fakeFederatedResource) ObjectForCluster(clusterName string) (*unstructured.Unstructured, error) {
+	return newPlanConfigMap("desired", 0, true), nil
+}
+
+func (r *fakeFederatedResource) ApplyOverrides(obj *unstructured.Unstructured, clusterName string) error {
+	return nil
+}
+
+func (r *fakeFederatedResource) IsNamespaceInHostCluster(clusterObj runtimeclient.Object) bool {
+	return false
+}
+
+// fakeGetClient returns the configured object from Get, or a not found
+// error if no object is configured.
+type fakeGetClient struct {
+	generic.Client
+
+	obj *unstructured.Unstructured
+}
+
+func (c *fakeGetClient) Get(ctx context.Context, obj runtimeclient.Object, namespace, name string) error {
+	if c.obj == nil {
+		return apierrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, name)
+	}
+	c.obj.DeepCopyInto(obj.(*unstructured.Unstructured))
+	return nil
+}
@@ -17,141 +17,233 @@ limitations under the License.
 package dispatch
 
 import (
+	"reflect"
+	"strconv"
+
 	"github.com/pkg/errors"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 
+	"sigs.k8s.io/kubefed/pkg/apis/core/common"
+	fedv1b1 "sigs.k8s.io/kubefed/pkg/apis/core/v1beta1"
 	"sigs.k8s.io/kubefed/pkg/controller/util"
 )
 
+// defaultRetainFields are the fields retained for the given target kind
+// in addition to the fields configured by its FederatedTypeConfig.
+var defaultRetainFields = map[string][]fedv1b1.RetainField{
+	// healthCheckNodePort, clusterIP(s) and nodePorts are allocated to a
+	// service by the member cluster and are unchangeable, so they are
+	// retained while updating.
+	util.ServiceKind: {
+		{Path: "/spec/healthCheckNodePort"},
+		{Path: "/spec/clusterIP"},
+		{Path: "/spec/clusterIPs"},
+		{Path: "/spec/ports/*/nodePort", ListKeys: []string{"name", "protocol", "port"}},
+	},
+	// The 'secrets' field of a service account is retained if the
+	// desired representation does not include a value for the field.
+	// This ensures that the sync controller doesn't continually clear a
+	// generated secret from a service account, prompting continual
+	// regeneration by the service account controller in the member
+	// cluster.
+	//
+	// TODO(marun) Clearing a manually-set secrets field will require
+	// resetting placement.  Is there a better way to do this?
+	util.ServiceAccountKind: {
+		{Path: "/secrets", RetainOnlyIfDesiredEmpty: true},
+	},
+}
+
+// defaultRetainFieldValidators validate the types of the values of the
+// default retained fields of a target kind in the cluster object.
+var defaultRetainFieldValidators = map[string]func(clusterObj *unstructured.Unstructured) error{
+	util.ServiceKind: validateServiceFields,
+}
+
+// validateServiceFields validates the types of the fields of a service
+// that are retained by default.
+func validateServiceFields(clusterObj *unstructured.Unstructured) error {
+	if _, _, err := unstructured.NestedInt64(clusterObj.Object, util.SpecField, util.HealthCheckNodePortField); err != nil {
+		return errors.Wrap(err, "Error retrieving healthCheckNodePort from cluster service")
+	}
+	if _, _, err := unstructured.NestedString(clusterObj.Object, util.SpecField, util.ClusterIPField); err != nil {
+		return errors.Wrap(err, "Error retrieving clusterIP from cluster service")
+	}
+	if _, _, err := unstructured.NestedStringSlice(clusterObj.Object, util.SpecField, util.ClusterIPsField); err != nil {
+		return errors.Wrap(err, "Error retrieving clusterIPs from cluster service")
+	}
+	ports, _, err := unstructured.NestedSlice(clusterObj.Object, util.SpecField, util.PortsField)
+	if err != nil {
+		return errors.Wrap(err, "Error retrieving ports from cluster service")
+	}
+	for _, port := range ports {
+		portMap, ok := port.(map[string]interface{})
+		if !ok {
+			return errors.Errorf("Error retrieving ports from cluster service: %v is of the type %T, expected map[string]interface{}", port, port)
+		}
+		if _, _, err := unstructured.NestedInt64(portMap, "nodePort"); err != nil {
+			return errors.Wrap(err, "Error retrieving nodePort from cluster service")
+		}
+	}
+	return nil
+}
+
 // RetainClusterFields updates the desired object with values retained
 // from the cluster object.
-func RetainClusterFields(targetKind string, desiredObj, clusterObj, fedObj *unstructured.Unstructured) error {
+func RetainClusterFields(targetKind string, retainFields []fedv1b1.RetainField, desiredObj, clusterObj, fedObj *unstructured.Unstructured) error {
 	// Pass the same ResourceVersion as in the cluster object for update operation, otherwise operation will fail.
 	desiredObj.SetResourceVersion(clusterObj.GetResourceVersion())
 
-	// Retain finalizers and annotations since they will typically be set by
-	// controllers in a member cluster.  It is still possible to set the fields
-	// via overrides.
+	// Retain finalizers since they will typically be set by controllers in
+	// a member cluster.  It is still possible to set the field via
+	// overrides.
 	desiredObj.SetFinalizers(clusterObj.GetFinalizers())
-	desiredObj.SetAnnotations(clusterObj.GetAnnotations())
 
-	if targetKind == util.ServiceKind {
-		return retainServiceFields(desiredObj, clusterObj)
+	// Preserve the labels and annotations set by controllers in a member
+	// cluster while applying those of the template.
+	if err := retainMetadata(desiredObj, clusterObj); err != nil {
+		return err
+	}
+
+	if err := retainDefaultFieldValues(targetKind, desiredObj, clusterObj); err != nil {
+		return err
 	}
-	if targetKind == util.ServiceAccountKind {
-		return retainServiceAccountFields(desiredObj, clusterObj)
+	if err := retainFieldValues(retainFields, desiredObj, clusterObj); err != nil {
+		return err
 	}
 	return retainReplicas(desiredObj, clusterObj, fedObj)
 }
 
-func retainServiceFields(desiredObj, clusterObj *unstructured.Unstructured) error {
-	// healthCheckNodePort is allocated by APIServer and unchangeable, so it should be retained while updating
-	healthCheckNodePort, ok, err := unstructured.NestedInt64(clusterObj.Object, util.SpecField, util.HealthCheckNodePortField)
-	if err != nil {
-		return errors.Wrap(err, "Error retrieving healthCheckNodePort from service")
-	}
-	if ok && healthCheckNodePort > 0 {
-		if err = unstructured.SetNestedField(desiredObj.Object, healthCheckNodePort, util.SpecField, util.HealthCheckNodePortField); err != nil {
-			return errors.Wrap(err, "Error setting healthCheckNodePort for service")
+// retainDefaultFieldValues retains the default fields of the given
+// target kind. Unlike configured fields, whose values are retained
+// as-is, the values of default fields are known to the sync controller
+// and must be of the expected type.
+func retainDefaultFieldValues(targetKind string, desiredObj, clusterObj *unstructured.Unstructured) error {
+	if validate, ok := defaultRetainFieldValidators[targetKind]; ok {
+		if err := validate(clusterObj); err != nil {
+			return err
 		}
 	}
+	return retainFieldValues(defaultRetainFields[targetKind], desiredObj, clusterObj)
+}
 
-	// ClusterIP and NodePort are allocated to Service by cluster, so retain the same if any while updating
-
-	// Retain clusterip and clusterips
-	clusterIP, ok, err := unstructured.NestedString(clusterObj.Object, util.SpecField, util.ClusterIPField)
-	if err != nil {
-		return errors.Wrap(err, "Error retrieving clusterIP from cluster service")
-	}
-	// !ok could indicate that a cluster ip was not assigned
-	if ok && clusterIP != "" {
-		err := unstructured.SetNestedField(desiredObj.Object, clusterIP, util.SpecField, util.ClusterIPField)
+// retainFieldValues sets the value of each of the given fields in the
+// desired object to its value in the cluster object. Fields that are not
+// set or have an empty value in the cluster object are left unchanged.
+func retainFieldValues(retainFields []fedv1b1.RetainField, desiredObj, clusterObj *unstructured.Unstructured) error {
+	for _, retainField := range retainFields {
+		segments, err := common.ParseFieldPath(retainField.Path)
 		if err != nil {
-			return errors.Wrap(err, "Error setting clusterIP for service")
+			return errors.Wrap(err, "Error parsing retained field")
 		}
+		retainValue(desiredObj.Object, clusterObj.Object, segments, retainField)
 	}
-	clusterIPs, ok, err := unstructured.NestedStringSlice(clusterObj.Object, util.SpecField, util.ClusterIPsField)
-	if err != nil {
-		return errors.Wrap(err, "Error retrieving clusterIPs from cluster service")
-	}
-	// !ok could indicate that cluster ips was not assigned
-	if ok && len(clusterIPs) > 0 {
-		err := unstructured.SetNestedStringSlice(desiredObj.Object, clusterIPs, util.SpecField, util.ClusterIPsField)
-		if err != nil {
-			return errors.Wrap(err, "Error setting clusterIPs for service")
+	return nil
+}
+
+// retainValue returns the desired value updated with the cluster value
+// of the field identified by the given path segments.
+func retainValue(desired, cluster interface{}, segments []string, retainField fedv1b1.RetainField) interface{} {
+	if len(segments) == 0 {
+		if isEmptyValue(cluster) || (retainField.RetainOnlyIfDesiredEmpty && !isEmptyValue(desired)) {
+			return desired
 		}
+		return runtime.DeepCopyJSONValue(cluster)
 	}
 
-	// Retain nodeports
-	clusterPorts, ok, err := unstructured.NestedSlice(clusterObj.Object, util.SpecField, util.PortsField)
-	if err != nil {
-		return errors.Wrap(err, "Error retrieving ports from cluster service")
+	segment, remaining := segments[0], segments[1:]
+	if clusterList, ok := cluster.([]interface{}); ok {
+		desiredList, ok := desired.([]interface{})
+		if !ok {
+			return desired
+		}
+		if segment == common.FieldPathWildcard {
+			for i := range desiredList {
+				if clusterValue, ok := matchListElement(desiredList, clusterList, i, retainField.ListKeys); ok {
+					desiredList[i] = retainValue(desiredList[i], clusterValue, remaining, retainField)
+				}
+			}
+			return desiredList
+		}
+		i, err := strconv.Atoi(segment)
+		if err != nil || i < 0 || i >= len(desiredList) || i >= len(clusterList) {
+			return desired
+		}
+		desiredList[i] = retainValue(desiredList[i], clusterList[i], remaining, retainField)
+		return desiredList
 	}
+
+	clusterMap, ok := cluster.(map[string]interface{})
 	if !ok {
-		return nil
+		return desired
 	}
-	var desiredPorts []interface{}
-	desiredPorts, ok, err = unstructured.NestedSlice(desiredObj.Object, util.SpecField, util.PortsField)
-	if err != nil {
-		return errors.Wrap(err, "Error retrieving ports from service")
+	clusterValue, ok := clusterMap[segment]
+	if !ok {
+		return desired
 	}
+	desiredMap, ok := desired.(map[string]interface{})
 	if !ok {
-		desiredPorts = []interface{}{}
-	}
-	for desiredIndex := range desiredPorts {
-		for clusterIndex := range clusterPorts {
-			fPort := desiredPorts[desiredIndex].(map[string]interface{})
-			cPort := clusterPorts[clusterIndex].(map[string]interface{})
-			if !(fPort["name"] == cPort["name"] && fPort["protocol"] == cPort["protocol"] && fPort["port"] == cPort["port"]) {
-				continue
-			}
-			nodePort, ok := cPort["nodePort"]
-			if ok {
-				fPort["nodePort"] = nodePort
-			}
+		if desired != nil {
+			return desired
 		}
+		desiredMap = map[string]interface{}{}
 	}
-	err = unstructured.SetNestedSlice(desiredObj.Object, desiredPorts, util.SpecField, util.PortsField)
-	if err != nil {
-		return errors.Wrap(err, "Error setting ports for service")
+	desiredValue, ok := desiredMap[segment]
+	value := retainValue(desiredValue, clusterValue, remaining, retainField)
+	if !ok && value == nil {
+		// Avoid adding empty parents for a value that was not retained.
+		return desired
 	}
-
-	return nil
+	desiredMap[segment] = value
+	return desiredMap
 }
 
-// retainServiceAccountFields retains the 'secrets' field of a service account
-// if the desired representation does not include a value for the field.  This
-// ensures that the sync controller doesn't continually clear a generated
-// secret from a service account, prompting continual regeneration by the
-// service account controller in the member cluster.
-//
-// TODO(marun) Clearing a manually-set secrets field will require resetting
-// placement.  Is there a better way to do this?
-func retainServiceAccountFields(desiredObj, clusterObj *unstructured.Unstructured) error {
-	// Check whether the secrets field is populated in the desired object.
-	desiredSecrets, ok, err := unstructured.NestedSlice(desiredObj.Object, util.SecretsField)
-	if err != nil {
-		return errors.Wrap(err, "Error retrieving secrets from desired service account")
+// matchListElement returns the element of the cluster list matching the
+// element of the desired list at the given index. Elements are matched
+// by the values of the given keys, or by index if no keys are provided.
+func matchListElement(desiredList, clusterList []interface{}, index int, listKeys []string) (interface{}, bool) {
+	if len(listKeys) == 0 {
+		if index >= len(clusterList) {
+			return nil, false
+		}
+		return clusterList[index], true
+	}
+	desiredElement, ok := desiredList[index].(map[string]interface{})
+	if !ok {
+		return nil, false
 	}
-	if ok && len(desiredSecrets) > 0 {
-		// Field is populated, so an update to the target resource does not
-		// risk triggering a race with the service account controller.
-		return nil
+	for _, clusterValue := range clusterList {
+		clusterElement, ok := clusterValue.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		matched := true
+		for _, key := range listKeys {
+			if !reflect.DeepEqual(desiredElement[key], clusterElement[key]) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return clusterElement, true
+		}
 	}
+	return nil, false
+}
 
-	// Retrieve the secrets from the cluster object and retain them.
-	secrets, ok, err := unstructured.NestedSlice(clusterObj.Object, util.SecretsField)
-	if err != nil {
-		return errors.Wrap(err, "Error retrieving secrets from service account")
+func isEmptyValue(value interface{}) bool {
+	if value == nil {
+		return true
 	}
-	if ok && len(secrets) > 0 {
-		err := unstructured.SetNestedField(desiredObj.Object, secrets, util.SecretsField)
-		if err != nil {
-			return errors.Wrap(err, "Error setting secrets for service account")
-		}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	default:
+		return v.IsZero()
 	}
-	return nil
 }
 
 func retainReplicas(desiredObj, clusterObj, fedObj *unstructured.Unstructured) error {
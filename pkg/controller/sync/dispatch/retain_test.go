@@ -23,6 +23,7 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/utils/ptr"
 
+	fedv1b1 "sigs.k8s.io/kubefed/pkg/apis/core/v1beta1"
 	"sigs.k8s.io/kubefed/pkg/controller/util"
 )
 
@@ -70,7 +71,7 @@ func TestRetainClusterFields(t *testing.T) {
 					},
 				},
 			}
-			if err := RetainClusterFields("", desiredObj, clusterObj, fedObj); err != nil {
+			if err := RetainClusterFields("", nil, desiredObj, clusterObj, fedObj); err != nil {
 				t.Fatalf("Unexpected error: %v", err)
 			}
 
@@ -88,6 +89,72 @@ func TestRetainClusterFields(t *testing.T) {
 	}
 }
 
+func TestRetainApplyFields(t *testing.T) {
+	testCases := map[string]struct {
+		retainReplicas   bool
+		expectedReplicas int64
+	}{
+		"replicas not retained when retainReplicas=false": {
+			retainReplicas:   false,
+			expectedReplicas: 1,
+		},
+		"replicas retained when retainReplicas=true": {
+			retainReplicas:   true,
+			expectedReplicas: 2,
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			desiredObj := &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"spec": map[string]interface{}{
+						"replicas": int64(1),
+					},
+				},
+			}
+			clusterObj := &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"metadata": map[string]interface{}{
+						"resourceVersion": "42",
+						"annotations": map[string]interface{}{
+							"foo": "bar",
+						},
+					},
+					"spec": map[string]interface{}{
+						"replicas":  int64(2),
+						"clusterIP": "10.0.0.1",
+					},
+				},
+			}
+			fedObj := &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"spec": map[string]interface{}{
+						"retainReplicas": testCase.retainReplicas,
+					},
+				},
+			}
+			if err := RetainApplyFields(desiredObj, clusterObj, fedObj); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			replicas, _, err := unstructured.NestedInt64(desiredObj.Object, util.SpecField, util.ReplicasField)
+			if err != nil {
+				t.Fatalf("An unexpected error occurred: %v", err)
+			}
+			if replicas != testCase.expectedReplicas {
+				t.Fatalf("Expected %d replicas when retainReplicas=%v, got %d", testCase.expectedReplicas, testCase.retainReplicas, replicas)
+			}
+			if _, ok := desiredObj.Object["metadata"]; ok {
+				t.Fatalf("Expected cluster metadata not to be retained, got %v", desiredObj.Object["metadata"])
+			}
+			if _, ok, _ := unstructured.NestedString(desiredObj.Object, util.SpecField, util.ClusterIPField); ok {
+				t.Fatalf("Expected field 'spec.clusterIP' not to be retained")
+			}
+		})
+	}
+}
+
 func TestRetainHealthCheckNodePortInServiceFields(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -155,7 +222,7 @@ func TestRetainHealthCheckNodePortInServiceFields(t *testing.T) {
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			if err := retainServiceFields(test.desiredObj, test.clusterObj); (err == nil) != test.retainSucceed {
+			if err := retainDefaultFieldValues(util.ServiceKind, test.desiredObj, test.clusterObj); (err == nil) != test.retainSucceed {
 				t.Fatalf("test %s fails: unexpected returned error %v", test.name, err)
 			}
 
@@ -262,7 +329,7 @@ func TestRetainClusterIPsInServiceFields(t *testing.T) {
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			if err := retainServiceFields(test.desiredObj, test.clusterObj); (err == nil) != test.retainSucceed {
+			if err := retainDefaultFieldValues(util.ServiceKind, test.desiredObj, test.clusterObj); (err == nil) != test.retainSucceed {
 				t.Fatalf("test %s fails: unexpected returned error %v", test.name, err)
 			}
 
@@ -290,3 +357,127 @@ func TestRetainClusterIPsInServiceFields(t *testing.T) {
 		})
 	}
 }
+
+func TestRetainFieldValues(t *testing.T) {
+	testCases := map[string]struct {
+		retainFields []fedv1b1.RetainField
+		desiredObj   map[string]interface{}
+		clusterObj   map[string]interface{}
+		expectedObj  map[string]interface{}
+		expectedErr  bool
+	}{
+		"value set in cluster is retained": {
+			retainFields: []fedv1b1.RetainField{{Path: "/spec/foo/bar"}},
+			desiredObj:   map[string]interface{}{},
+			clusterObj: map[string]interface{}{
+				"spec": map[string]interface{}{"foo": map[string]interface{}{"bar": "cluster"}},
+			},
+			expectedObj: map[string]interface{}{
+				"spec": map[string]interface{}{"foo": map[string]interface{}{"bar": "cluster"}},
+			},
+		},
+		"value set in cluster overrides desired value": {
+			retainFields: []fedv1b1.RetainField{{Path: ".spec.foo"}},
+			desiredObj: map[string]interface{}{
+				"spec": map[string]interface{}{"foo": "desired"},
+			},
+			clusterObj: map[string]interface{}{
+				"spec": map[string]interface{}{"foo": "cluster"},
+			},
+			expectedObj: map[string]interface{}{
+				"spec": map[string]interface{}{"foo": "cluster"},
+			},
+		},
+		"empty value in cluster is not retained": {
+			retainFields: []fedv1b1.RetainField{{Path: "{.spec.foo}"}, {Path: "/spec/bar"}},
+			desiredObj:   map[string]interface{}{},
+			clusterObj: map[string]interface{}{
+				"spec": map[string]interface{}{"foo": "", "bar": int64(0)},
+			},
+			expectedObj: map[string]interface{}{},
+		},
+		"value not retained if desired is set and retainOnlyIfDesiredEmpty=true": {
+			retainFields: []fedv1b1.RetainField{{Path: "/spec/foo", RetainOnlyIfDesiredEmpty: true}},
+			desiredObj: map[string]interface{}{
+				"spec": map[string]interface{}{"foo": "desired"},
+			},
+			clusterObj: map[string]interface{}{
+				"spec": map[string]interface{}{"foo": "cluster"},
+			},
+			expectedObj: map[string]interface{}{
+				"spec": map[string]interface{}{"foo": "desired"},
+			},
+		},
+		"list elements are matched by index": {
+			retainFields: []fedv1b1.RetainField{{Path: ".spec.items[*].id"}},
+			desiredObj: map[string]interface{}{
+				"spec": map[string]interface{}{"items": []interface{}{
+					map[string]interface{}{"name": "a"},
+				}},
+			},
+			clusterObj: map[string]interface{}{
+				"spec": map[string]interface{}{"items": []interface{}{
+					map[string]interface{}{"name": "b", "id": "1"},
+					map[string]interface{}{"name": "a", "id": "2"},
+				}},
+			},
+			expectedObj: map[string]interface{}{
+				"spec": map[string]interface{}{"items": []interface{}{
+					map[string]interface{}{"name": "a", "id": "1"},
+				}},
+			},
+		},
+		"list elements are matched by list keys": {
+			retainFields: defaultRetainFields[util.ServiceKind],
+			desiredObj: map[string]interface{}{
+				"spec": map[string]interface{}{"ports": []interface{}{
+					map[string]interface{}{"name": "http", "protocol": "TCP", "port": int64(80)},
+					map[string]interface{}{"name": "https", "protocol": "TCP", "port": int64(443)},
+				}},
+			},
+			clusterObj: map[string]interface{}{
+				"spec": map[string]interface{}{"ports": []interface{}{
+					map[string]interface{}{"name": "https", "protocol": "TCP", "port": int64(443), "nodePort": int64(30443)},
+					map[string]interface{}{"name": "http", "protocol": "TCP", "port": int64(8080), "nodePort": int64(30080)},
+				}},
+			},
+			expectedObj: map[string]interface{}{
+				"spec": map[string]interface{}{"ports": []interface{}{
+					map[string]interface{}{"name": "http", "protocol": "TCP", "port": int64(80)},
+					map[string]interface{}{"name": "https", "protocol": "TCP", "port": int64(443), "nodePort": int64(30443)},
+				}},
+			},
+		},
+		"service account secrets are retained if desired is empty": {
+			retainFields: defaultRetainFields[util.ServiceAccountKind],
+			desiredObj:   map[string]interface{}{},
+			clusterObj: map[string]interface{}{
+				"secrets": []interface{}{map[string]interface{}{"name": "foo-token"}},
+			},
+			expectedObj: map[string]interface{}{
+				"secrets": []interface{}{map[string]interface{}{"name": "foo-token"}},
+			},
+		},
+		"invalid path returns an error": {
+			retainFields: []fedv1b1.RetainField{{Path: "/spec//foo"}},
+			desiredObj:   map[string]interface{}{},
+			clusterObj:   map[string]interface{}{},
+			expectedObj:  map[string]interface{}{},
+			expectedErr:  true,
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			desiredObj := &unstructured.Unstructured{Object: testCase.desiredObj}
+			clusterObj := &unstructured.Unstructured{Object: testCase.clusterObj}
+			err := retainFieldValues(testCase.retainFields, desiredObj, clusterObj)
+			if (err != nil) != testCase.expectedErr {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(testCase.expectedObj, desiredObj.Object) {
+				t.Fatalf("Expected %v, got %v", testCase.expectedObj, desiredObj.Object)
+			}
+		})
+	}
+}
@@ -56,8 +56,8 @@ type unmanagedDispatcherImpl struct {
 	recorder dispatchRecorder
 }
 
-func NewUnmanagedDispatcher(clientAccessor clientAccessorFunc, targetGVK schema.GroupVersionKind, targetName util.QualifiedName) UnmanagedDispatcher {
-	dispatcher := newOperationDispatcher(clientAccessor, nil)
+func NewUnmanagedDispatcher(clientAccessor clientAccessorFunc, targetGVK schema.GroupVersionKind, targetName util.QualifiedName, executor Executor) UnmanagedDispatcher {
+	dispatcher := newOperationDispatcher(clientAccessor, nil, 0, executor)
 	return newUnmanagedDispatcher(dispatcher, nil, targetGVK, targetName)
 }
 
@@ -75,11 +75,10 @@ func (d *unmanagedDispatcherImpl) Wait() (bool, error) {
 }
 
 func (d *unmanagedDispatcherImpl) Delete(clusterName string, opts ...runtimeclient.DeleteOption) {
-	start := time.Now()
-	d.dispatcher.incrementOperationsInitiated()
 	const op = "delete"
 	const opContinuous = "Deleting"
-	go d.dispatcher.clusterOperation(clusterName, op, func(client generic.Client) util.ReconciliationStatus {
+	d.dispatcher.clusterOperation(clusterName, op, func(ctx context.Context, client generic.Client) util.ReconciliationStatus {
+		start := time.Now()
 		targetName := d.targetNameForCluster(clusterName)
 		if d.recorder == nil {
 			klog.V(2).Infof(eventTemplate, opContinuous, d.targetGVK.Kind, targetName, clusterName)
@@ -89,7 +88,7 @@ func (d *unmanagedDispatcherImpl) Delete(clusterName string, opts ...runtimeclie
 
 		obj := &unstructured.Unstructured{}
 		obj.SetGroupVersionKind(d.targetGVK)
-		err := client.Delete(context.Background(), obj, targetName.Namespace, targetName.Name, opts...)
+		err := client.Delete(ctx, obj, targetName.Namespace, targetName.Name, opts...)
 		if apierrors.IsNotFound(err) {
 			err = nil
 		}
@@ -108,10 +107,9 @@ func (d *unmanagedDispatcherImpl) Delete(clusterName string, opts ...runtimeclie
 }
 
 func (d *unmanagedDispatcherImpl) RemoveManagedLabel(clusterName string, clusterObj *unstructured.Unstructured) {
-	d.dispatcher.incrementOperationsInitiated()
 	const op = "remove managed label from"
 	const opContinuous = "Removing managed label from"
-	go d.dispatcher.clusterOperation(clusterName, op, func(client generic.Client) util.ReconciliationStatus {
+	d.dispatcher.clusterOperation(clusterName, op, func(ctx context.Context, client generic.Client) util.ReconciliationStatus {
 		if d.recorder == nil {
 			klog.V(2).Infof(eventTemplate, opContinuous, d.targetGVK.Kind, d.targetNameForCluster(clusterName), clusterName)
 		} else {
@@ -124,7 +122,7 @@ func (d *unmanagedDispatcherImpl) RemoveManagedLabel(clusterName string, cluster
 
 		util.RemoveManagedLabel(updateObj)
 
-		err := client.Patch(context.Background(), updateObj, patch)
+		err := client.Patch(ctx, updateObj, patch)
 		if err != nil {
 			if d.recorder == nil {
 				wrappedErr := d.wrapOperationError(err, clusterName, op)
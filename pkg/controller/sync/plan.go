@@ -0,0 +1,318 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sync
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
+
+	"sigs.k8s.io/kubefed/pkg/apis/core/typeconfig"
+	fedv1b1 "sigs.k8s.io/kubefed/pkg/apis/core/v1beta1"
+	genericclient "sigs.k8s.io/kubefed/pkg/client/generic"
+	"sigs.k8s.io/kubefed/pkg/controller/sync/dependency"
+	"sigs.k8s.io/kubefed/pkg/controller/sync/dispatch"
+	"sigs.k8s.io/kubefed/pkg/controller/sync/status"
+	"sigs.k8s.io/kubefed/pkg/controller/sync/version"
+	"sigs.k8s.io/kubefed/pkg/controller/util"
+)
+
+// defaultVersionSyncTimeout bounds the time spent retrieving propagated
+// versions when the controller config does not specify a timeout.
+const defaultVersionSyncTimeout = 30 * time.Second
+
+// PropagationPlan describes the operations that the sync controller
+// would perform to propagate a federated resource to member clusters.
+type PropagationPlan struct {
+	// The names of the clusters selected by the placement of the
+	// federated resource.
+	SelectedClusters []string `json:"selectedClusters"`
+	// The progress of the rollout of the federated resource, if it has
+	// a rollout strategy.
+	Rollout *status.RolloutStatus `json:"rollout,omitempty"`
+	// The plans for member clusters that would be reconciled, sorted
+	// by cluster name.
+	Clusters []dispatch.ClusterPlan `json:"clusters"`
+}
+
+// PlanPropagation determines the operations that the sync controller
+// would perform to propagate the federated resource for the named
+// target resource, without performing them. The federated resource and
+// member clusters are read directly from the API rather than from
+// informer caches, and no events or status are written.
+func PlanPropagation(controllerConfig *util.ControllerConfig, typeConfig typeconfig.Interface, fedNamespaceAPIResource *metav1.APIResource, targetName util.QualifiedName) (*PropagationPlan, error) {
+	hostClient, err := genericclient.New(controllerConfig.KubeConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to create host cluster client")
+	}
+
+	fedResource, err := getFederatedResource(controllerConfig, typeConfig, fedNamespaceAPIResource, hostClient, targetName)
+	if err != nil {
+		return nil, err
+	}
+	if fedResource.Object().GetDeletionTimestamp() != nil {
+		return nil, errors.Errorf("%s %q is being deleted", fedResource.FederatedKind(), fedResource.FederatedName())
+	}
+
+	clusterList := &fedv1b1.KubeFedClusterList{}
+	err = hostClient.List(context.TODO(), clusterList, controllerConfig.KubeFedNamespace)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to list KubeFedClusters")
+	}
+	clusters := make([]*fedv1b1.KubeFedCluster, 0, len(clusterList.Items))
+	for i := range clusterList.Items {
+		clusters = append(clusters, &clusterList.Items[i])
+	}
+	fedResource.getClusters = func() ([]*fedv1b1.KubeFedCluster, error) {
+		return clusters, nil
+	}
+
+	selectedClusterNames, err := fedResource.ComputePlacement(clusters)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to compute placement")
+	}
+
+	clientForCluster := newClusterClientAccessor(hostClient, controllerConfig.KubeFedNamespace, clusters)
+	clusterObjects := directClusterObjects(clientForCluster, fedResource)
+	rolloutPlan, err := computeRolloutPlan(fedResource, clusters, selectedClusterNames, clusterObjects)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to compute rollout")
+	}
+
+	var crdName string
+	if !controllerConfig.LimitedScope() {
+		crdName, err = dependency.CustomResourceDefinitionName(hostClient, typeConfig.GetTargetType())
+		if err != nil {
+			return nil, err
+		}
+	}
+	dependencies, err := dependency.NewChecker(clientForCluster, fedResource.Object(), fedResource.TargetName().Namespace, crdName, controllerConfig.LimitedScope())
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to compute dependencies")
+	}
+
+	dispatcher := dispatch.NewPlanningDispatcher(clientForCluster, fedResource, controllerConfig.SkipAdoptingResources, typeConfig.GetServerSideApplyEnabled(), typeConfig.GetRetainFields())
+	dispatchToClusters(dispatcher, fedResource, clusters, selectedClusterNames, rolloutPlan, dependencies, clusterObjects)
+
+	plan := &PropagationPlan{
+		SelectedClusters: sets.List(selectedClusterNames),
+		Clusters:         dispatcher.Plans(),
+	}
+	if rolloutPlan != nil {
+		plan.Rollout = rolloutPlan.Status
+	}
+	for i := range plan.Clusters {
+		// Operations that do not require the desired object are not
+		// provided the cluster object by the sync logic, but it has
+		// already been retrieved.
+		clusterPlan := &plan.Clusters[i]
+		if clusterPlan.ClusterObject != nil {
+			continue
+		}
+		switch {
+		case clusterPlan.Operation == dispatch.OperationDelete,
+			clusterPlan.Status == status.WaitingForRemoval,
+			clusterPlan.Status == status.WaitingForRollout:
+			clusterPlan.ClusterObject, _ = clusterObjects.get(clusterPlan.ClusterName)
+		}
+	}
+	return plan, nil
+}
+
+// getFederatedResource retrieves the federated resource for the named
+// target resource and the resources it depends on from the host
+// cluster.
+func getFederatedResource(controllerConfig *util.ControllerConfig, typeConfig typeconfig.Interface, fedNamespaceAPIResource *metav1.APIResource,
+	hostClient genericclient.Client, targetName util.QualifiedName) (*federatedResource, error) {
+	targetIsNamespace := typeConfig.GetTargetType().Kind == util.NamespaceKind
+	federatedName := util.QualifiedName{
+		Namespace: util.NamespaceForResource(targetName.Namespace, controllerConfig.KubeFedNamespace),
+		Name:      targetName.Name,
+	}
+	if targetIsNamespace {
+		federatedName.Namespace = targetName.Name
+	}
+
+	federatedTypeAPIResource := typeConfig.GetFederatedType()
+	resource, err := getHostObject(controllerConfig, &federatedTypeAPIResource, federatedName)
+	if err != nil {
+		return nil, err
+	}
+	if resource == nil {
+		return nil, errors.Errorf("%s %q not found", federatedTypeAPIResource.Kind, federatedName)
+	}
+
+	var namespace *unstructured.Unstructured
+	if targetIsNamespace {
+		namespaceAPIResource := typeConfig.GetTargetType()
+		namespace, err = getHostObject(controllerConfig, &namespaceAPIResource, util.QualifiedName{Name: targetName.Name})
+		if err != nil {
+			return nil, err
+		}
+		if namespace == nil {
+			return nil, errors.Errorf("Namespace %q not found", targetName.Name)
+		}
+	}
+
+	var fedNamespace *unstructured.Unstructured
+	if typeConfig.GetNamespaced() {
+		if fedNamespaceAPIResource == nil {
+			return nil, errors.New("The federated namespace type is required to plan propagation of a namespaced type")
+		}
+		fedNamespaceName := util.QualifiedName{Namespace: federatedName.Namespace, Name: federatedName.Namespace}
+		fedNamespace, err = getHostObject(controllerConfig, fedNamespaceAPIResource, fedNamespaceName)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	versionManager := version.NewVersionManager(
+		hostClient,
+		typeConfig.GetFederatedNamespaced(),
+		federatedTypeAPIResource.Kind,
+		typeConfig.GetTargetType().Kind,
+		controllerConfig.TargetNamespace,
+	)
+	timeout := controllerConfig.CacheSyncTimeout
+	if timeout == 0 {
+		timeout = defaultVersionSyncTimeout
+	}
+	stopChan := make(chan struct{})
+	timer := time.AfterFunc(timeout, func() { close(stopChan) })
+	versionManager.Sync(stopChan)
+	timer.Stop()
+	if !versionManager.HasSynced() {
+		return nil, errors.Errorf("Timed out retrieving propagated versions for %s", federatedTypeAPIResource.Kind)
+	}
+
+	return &federatedResource{
+		limitedScope:      controllerConfig.LimitedScope(),
+		typeConfig:        typeConfig,
+		targetIsNamespace: targetIsNamespace,
+		targetName:        targetName,
+		federatedKind:     federatedTypeAPIResource.Kind,
+		federatedName:     federatedName,
+		federatedResource: resource,
+		versionManager:    versionManager,
+		namespace:         namespace,
+		fedNamespace:      fedNamespace,
+		// Events are not recorded when planning.
+		eventRecorder: &record.FakeRecorder{},
+	}, nil
+}
+
+// getHostObject retrieves the named resource from the host cluster,
+// returning nil if it does not exist.
+func getHostObject(controllerConfig *util.ControllerConfig, apiResource *metav1.APIResource, qualifiedName util.QualifiedName) (*unstructured.Unstructured, error) {
+	client, err := util.NewResourceClient(controllerConfig.KubeConfig, apiResource)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error creating client for %s", apiResource.Kind)
+	}
+	obj, err := client.Resources(qualifiedName.Namespace).Get(context.Background(), qualifiedName.Name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error retrieving %s %q", apiResource.Kind, qualifiedName)
+	}
+	return obj, nil
+}
+
+// newClusterClientAccessor returns a function that lazily creates
+// clients for the given member clusters.
+func newClusterClientAccessor(hostClient genericclient.Client, fedNamespace string, clusters []*fedv1b1.KubeFedCluster) func(clusterName string) (genericclient.Client, error) {
+	var lock sync.Mutex
+	clients := make(map[string]genericclient.Client)
+	return func(clusterName string) (genericclient.Client, error) {
+		lock.Lock()
+		defer lock.Unlock()
+		if client, ok := clients[clusterName]; ok {
+			return client, nil
+		}
+		for _, cluster := range clusters {
+			if cluster.Name != clusterName {
+				continue
+			}
+			config, err := util.BuildClusterConfig(cluster, hostClient, fedNamespace)
+			if err != nil {
+				return nil, errors.Wrapf(err, "Failed to build config for cluster %q", clusterName)
+			}
+			client, err := genericclient.New(config)
+			if err != nil {
+				return nil, errors.Wrapf(err, "Failed to create client for cluster %q", clusterName)
+			}
+			clients[clusterName] = client
+			return client, nil
+		}
+		return nil, errors.Errorf("Unknown cluster %q", clusterName)
+	}
+}
+
+// directClusterObjects returns a source of cluster objects retrieved
+// directly from member clusters. Consistent with the informer cache
+// used by the sync controller, objects without the managed label are
+// not returned.
+func directClusterObjects(clientForCluster func(clusterName string) (genericclient.Client, error), fedResource FederatedResource) clusterObjectSource {
+	type result struct {
+		obj *unstructured.Unstructured
+		err error
+	}
+	var lock sync.Mutex
+	results := make(map[string]result)
+	return clusterObjectSource{
+		get: func(clusterName string) (*unstructured.Unstructured, error) {
+			lock.Lock()
+			defer lock.Unlock()
+			if cached, ok := results[clusterName]; ok {
+				return cached.obj, cached.err
+			}
+			obj, err := getClusterObject(clientForCluster, fedResource, clusterName)
+			results[clusterName] = result{obj: obj, err: err}
+			return obj, err
+		},
+		failedStatus: status.RetrievalFailed,
+	}
+}
+
+func getClusterObject(clientForCluster func(clusterName string) (genericclient.Client, error), fedResource FederatedResource, clusterName string) (*unstructured.Unstructured, error) {
+	client, err := clientForCluster(clusterName)
+	if err != nil {
+		return nil, err
+	}
+	targetName := util.QualifiedNameForCluster(clusterName, fedResource.TargetName())
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(fedResource.TargetGVK())
+	err = client.Get(context.Background(), obj, targetName.Namespace, targetName.Name)
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to retrieve %s %q from cluster %q", fedResource.TargetKind(), targetName, clusterName)
+	}
+	if !util.HasManagedLabel(obj) {
+		return nil, nil
+	}
+	return obj, nil
+}
@@ -29,6 +29,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/record"
@@ -48,9 +49,12 @@ type FederatedResource interface {
 
 	FederatedName() util.QualifiedName
 	FederatedKind() string
+	TemplateVersion() (string, error)
+	OverrideVersion() (string, error)
 	UpdateVersions(selectedClusters []string, versionMap map[string]string) error
 	DeleteVersions()
 	ComputePlacement(clusters []*fedv1b1.KubeFedCluster) (selectedClusters sets.Set[string], err error)
+	ComputePlacementStatus(clusters []*fedv1b1.KubeFedCluster) (selectedClusters sets.Set[string], placementStatus *util.PlacementStatus, err error)
 	NamespaceNotFederated() bool
 }
 
@@ -65,11 +69,18 @@ type federatedResource struct {
 	federatedName     util.QualifiedName
 	federatedResource *unstructured.Unstructured
 	versionManager    *version.VersionManager
+	// Guards the overrides and clusters, which are read while
+	// the versions are retrieved under the lock of the resource.
+	overridesLock     sync.Mutex
 	overridesMap      util.OverridesMap
+	mergePatchesMap   map[string]util.MergePatches
+	selectorOverrides []util.SelectorOverride
 	versionMap        map[string]string
 	namespace         *unstructured.Unstructured
 	fedNamespace      *unstructured.Unstructured
 	eventRecorder     record.EventRecorder
+	getClusters       func() ([]*fedv1b1.KubeFedCluster, error)
+	clusters          map[string]*fedv1b1.KubeFedCluster
 }
 
 func (r *federatedResource) FederatedName() util.QualifiedName {
@@ -105,7 +116,64 @@ func (r *federatedResource) TemplateVersion() (string, error) {
 func (r *federatedResource) OverrideVersion() (string, error) {
 	// TODO(marun) Consider hashing overrides per cluster to minimize
 	// unnecessary updates.
-	return GetOverrideHash(r.federatedResource)
+	overrideHash, err := GetOverrideHash(r.federatedResource)
+	if err != nil {
+		return "", err
+	}
+
+	r.overridesLock.Lock()
+	defer r.overridesLock.Unlock()
+	if err := r.loadOverrides(); err != nil {
+		return "", err
+	}
+	variablesEnabled := util.ClusterVariablesEnabled(r.federatedResource)
+	if len(r.selectorOverrides) == 0 && !variablesEnabled {
+		return overrideHash, nil
+	}
+	if err := r.loadClusters(); err != nil {
+		return "", err
+	}
+	clusterNames := sets.List(sets.KeySet(r.clusters))
+	versionObj := map[string]interface{}{
+		"overrides": overrideHash,
+	}
+
+	// The clusters that overrides targeting a cluster selector apply
+	// to change with the labels of clusters, so the version also
+	// reflects the clusters matching each selector.
+	if len(r.selectorOverrides) != 0 {
+		matches := []interface{}{}
+		for _, selectorOverride := range r.selectorOverrides {
+			matchingNames := []interface{}{}
+			for _, clusterName := range clusterNames {
+				if selectorOverride.Selector.Matches(labels.Set(r.clusters[clusterName].Labels)) {
+					matchingNames = append(matchingNames, clusterName)
+				}
+			}
+			matches = append(matches, matchingNames)
+		}
+		versionObj["matches"] = matches
+	}
+
+	// Similarly, the version reflects the values of the cluster
+	// variables referenced by the template and overrides.
+	if variablesEnabled {
+		spec, _, err := unstructured.NestedMap(r.federatedResource.Object, util.SpecField)
+		if err != nil {
+			return "", errors.Wrapf(err, "Error retrieving %q", util.SpecField)
+		}
+		variables := map[string]interface{}{}
+		for _, clusterName := range clusterNames {
+			values := map[string]interface{}{}
+			for expression, value := range util.ClusterVariableValues(spec, r.clusters[clusterName]) {
+				values[expression] = value
+			}
+			variables[clusterName] = values
+		}
+		versionObj["variables"] = variables
+	}
+
+	return hashUnstructured(&unstructured.Unstructured{Object: versionObj}, "overrides")
 }
 
 func (r *federatedResource) VersionForCluster(clusterName string) (string, error) {
@@ -136,6 +204,13 @@ func (r *federatedResource) ComputePlacement(clusters []*fedv1b1.KubeFedCluster)
 	return util.ComputePlacement(r.federatedResource, clusters, false)
 }
 
+func (r *federatedResource) ComputePlacementStatus(clusters []*fedv1b1.KubeFedCluster) (sets.Set[string], *util.PlacementStatus, error) {
+	if r.typeConfig.GetNamespaced() {
+		return util.ComputeNamespacedPlacementStatus(r.federatedResource, r.fedNamespace, clusters, r.limitedScope, false)
+	}
+	return util.ComputePlacementStatus(r.federatedResource, clusters, false)
+}
+
 func (r *federatedResource) NamespaceNotFederated() bool {
 	return r.typeConfig.GetNamespaced() && r.fedNamespace == nil
 }
@@ -176,14 +251,10 @@ func (r *federatedResource) ObjectForCluster(clusterName string) (*unstructured.
 	}
 	obj := &unstructured.Unstructured{Object: templateBody}
 
-	notSupportedTemplate := "metadata.%s cannot be set via template to avoid conflicting with controllers " +
-		"in member clusters. Consider using an override to add or remove elements from this collection."
-	if len(obj.GetAnnotations()) > 0 {
-		r.RecordError("AnnotationsNotSupported", errors.Errorf(notSupportedTemplate, "annotations"))
-		obj.SetAnnotations(nil)
-	}
 	if len(obj.GetFinalizers()) > 0 {
-		r.RecordError("FinalizersNotSupported", errors.Errorf(notSupportedTemplate, "finalizers"))
+		r.RecordError("FinalizersNotSupported", errors.New("metadata.finalizers cannot be set via template to avoid "+
+			"conflicting with controllers in member clusters. Consider using an override to add or remove elements "+
+			"from this collection."))
 		obj.SetFinalizers(nil)
 	}
 
@@ -213,7 +284,7 @@ func (r *federatedResource) ObjectForCluster(clusterName string) (*unstructured.
 // object. The managed label is added afterwards to ensure labeling even if an
 // override was attempted.
 func (r *federatedResource) ApplyOverrides(obj *unstructured.Unstructured, clusterName string) error {
-	overrides, err := r.overridesForCluster(clusterName)
+	overrides, mergePatches, err := r.overridesForCluster(clusterName)
 	if err != nil {
 		return err
 	}
@@ -222,6 +293,19 @@ func (r *federatedResource) ApplyOverrides(obj *unstructured.Unstructured, clust
 			return err
 		}
 	}
+	if err := util.ApplyMergePatches(obj, mergePatches); err != nil {
+		return err
+	}
+
+	if util.ClusterVariablesEnabled(r.federatedResource) {
+		cluster, err := r.cluster(clusterName)
+		if err != nil {
+			return err
+		}
+		if err := util.SubstituteClusterVariables(obj, cluster); err != nil {
+			return errors.Wrapf(err, "failed to substitute the variables of cluster %q", clusterName)
+		}
+	}
 
 	// Ensure that resources managed by KubeFed always have the
 	// managed label.  The label is intended to be targeted by all the
@@ -240,17 +324,76 @@ func (r *federatedResource) RecordEvent(reason, messageFmt string, args ...inter
 	r.eventRecorder.Eventf(r.Object(), corev1.EventTypeNormal, reason, messageFmt, args...)
 }
 
-func (r *federatedResource) overridesForCluster(clusterName string) (util.ClusterOverrides, error) {
-	r.Lock()
-	defer r.Unlock()
-	if r.overridesMap == nil {
-		overridesMap, err := util.GetOverrides(r.federatedResource)
+func (r *federatedResource) overridesForCluster(clusterName string) (util.ClusterOverrides, []util.MergePatches, error) {
+	r.overridesLock.Lock()
+	defer r.overridesLock.Unlock()
+	if err := r.loadOverrides(); err != nil {
+		return nil, nil, err
+	}
+	var clusterLabels labels.Set
+	if len(r.selectorOverrides) != 0 {
+		if err := r.loadClusters(); err != nil {
+			return nil, nil, err
+		}
+		if cluster, ok := r.clusters[clusterName]; ok {
+			clusterLabels = cluster.Labels
+		}
+	}
+	return util.OverridesForCluster(clusterName, clusterLabels, r.overridesMap, r.mergePatchesMap, r.selectorOverrides)
+}
+
+// cluster returns the named cluster, or nil if it is not known.
+func (r *federatedResource) cluster(clusterName string) (*fedv1b1.KubeFedCluster, error) {
+	r.overridesLock.Lock()
+	defer r.overridesLock.Unlock()
+	if err := r.loadClusters(); err != nil {
+		return nil, err
+	}
+	return r.clusters[clusterName], nil
+}
+
+// loadOverrides reads the overrides of the federated resource if they
+// have not yet been read. The caller must hold the overrides lock.
+func (r *federatedResource) loadOverrides() error {
+	if r.overridesMap != nil {
+		return nil
+	}
+	overridesMap, err := util.GetOverrides(r.federatedResource)
+	if err != nil {
+		return errors.Wrapf(err, "Error reading cluster overrides")
+	}
+	mergePatchesMap, err := util.GetMergePatches(r.federatedResource)
+	if err != nil {
+		return errors.Wrapf(err, "Error reading cluster merge patches")
+	}
+	selectorOverrides, err := util.GetSelectorOverrides(r.federatedResource)
+	if err != nil {
+		return errors.Wrapf(err, "Error reading cluster selector overrides")
+	}
+	r.overridesMap = overridesMap
+	r.mergePatchesMap = mergePatchesMap
+	r.selectorOverrides = selectorOverrides
+	return nil
+}
+
+// loadClusters retrieves the clusters if they have not yet been
+// retrieved. The caller must hold the overrides lock.
+func (r *federatedResource) loadClusters() error {
+	if r.clusters != nil {
+		return nil
+	}
+	clusters := make(map[string]*fedv1b1.KubeFedCluster)
+	if r.getClusters != nil {
+		clusterList, err := r.getClusters()
 		if err != nil {
-			return nil, errors.Wrapf(err, "Error reading cluster overrides")
+			return errors.Wrap(err, "Error retrieving clusters for overrides")
+		}
+		for _, cluster := range clusterList {
+			clusters[cluster.Name] = cluster
 		}
-		r.overridesMap = overridesMap
 	}
-	return r.overridesMap[clusterName], nil
+	r.clusters = clusters
+	return nil
 }
 
 func GetTemplateHash(fieldMap map[string]interface{}) (string, error) {
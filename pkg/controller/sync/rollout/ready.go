@@ -0,0 +1,87 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rollout
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"sigs.k8s.io/kubefed/pkg/controller/util"
+)
+
+// IsReady determines from its status whether a resource in a member
+// cluster is ready. The status is expected to follow the conventions of
+// the built-in workload types:
+//
+//   - status.observedGeneration must not lag metadata.generation
+//   - Ready and Available conditions must be True, and a Progressing
+//     condition must not be False
+//   - the updated, ready and available replica counts must not be less
+//     than spec.replicas
+//
+// A resource without status is considered ready.
+func IsReady(clusterObj *unstructured.Unstructured) bool {
+	statusObj, ok := clusterObj.Object[util.StatusField].(map[string]interface{})
+	if !ok {
+		return true
+	}
+
+	if generation := clusterObj.GetGeneration(); generation != 0 {
+		observedGeneration, ok, _ := unstructured.NestedInt64(statusObj, "observedGeneration")
+		if ok && observedGeneration < generation {
+			return false
+		}
+	}
+
+	conditions, _, _ := unstructured.NestedSlice(statusObj, "conditions")
+	for _, rawCondition := range conditions {
+		condition, ok := rawCondition.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		switch condition["type"] {
+		case "Ready", "Available":
+			if condition["status"] != "True" {
+				return false
+			}
+		case "Progressing":
+			if condition["status"] == "False" {
+				return false
+			}
+		}
+	}
+
+	replicas, ok, _ := unstructured.NestedInt64(clusterObj.Object, util.SpecField, util.ReplicasField)
+	if !ok || replicas == 0 {
+		return true
+	}
+	// The ready and available replica counts are omitted from the
+	// status of the built-in workload types when zero, so a missing
+	// count is considered zero if the status reports replicas. Not all
+	// types report updated replicas (e.g. ReplicaSet), so that count
+	// is only checked if present.
+	_, hasReplicasStatus := statusObj[util.ReplicasField]
+	for _, field := range []string{"updatedReplicas", "readyReplicas", "availableReplicas"} {
+		count, ok, _ := unstructured.NestedInt64(statusObj, field)
+		if !ok && (field == "updatedReplicas" || !hasReplicasStatus) {
+			continue
+		}
+		if count < replicas {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,220 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rollout
+
+import (
+	"sort"
+
+	"github.com/pkg/errors"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	fedv1b1 "sigs.k8s.io/kubefed/pkg/apis/core/v1beta1"
+	"sigs.k8s.io/kubefed/pkg/controller/sync/status"
+	"sigs.k8s.io/kubefed/pkg/controller/util"
+)
+
+// Strategy configures the progressive rollout of changes to the
+// template or overrides of a federated resource to member clusters.
+type Strategy struct {
+	// Ordered waves of clusters. Clusters not matched by any wave are
+	// rolled out in an implicit final wave.
+	Waves []Wave `json:"waves,omitempty"`
+	// Maximum number of clusters whose resource may be updated but not
+	// yet ready at any one time. Unlimited if not set.
+	MaxUnavailableClusters *int64 `json:"maxUnavailableClusters,omitempty"`
+	// Whether the rollout is paused. Clusters that have not yet been
+	// updated are left untouched until the rollout is resumed.
+	Paused bool `json:"paused,omitempty"`
+	// Whether a wave should only be started once the resources of all
+	// clusters in previous waves are ready.
+	WaitForReady bool `json:"waitForReady,omitempty"`
+}
+
+// Wave selects the clusters to roll out to together. If one or more
+// clusters is provided, the clusterSelector field is ignored.
+type Wave struct {
+	Name            string                         `json:"name,omitempty"`
+	Clusters        []util.GenericClusterReference `json:"clusters,omitempty"`
+	ClusterSelector *metav1.LabelSelector          `json:"clusterSelector,omitempty"`
+}
+
+type genericRolloutSpec struct {
+	RolloutStrategy *Strategy `json:"rolloutStrategy,omitempty"`
+}
+
+type genericRollout struct {
+	Spec genericRolloutSpec `json:"spec,omitempty"`
+}
+
+// GetStrategy returns the rollout strategy of the given federated
+// resource, or nil if the resource does not have one.
+func GetStrategy(fedObject *unstructured.Unstructured) (*Strategy, error) {
+	rollout := &genericRollout{}
+	err := util.UnstructuredToInterface(fedObject, rollout)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to unmarshall rollout strategy")
+	}
+	return rollout.Spec.RolloutStrategy, nil
+}
+
+// ClusterState describes the progress of a rollout in a member cluster.
+type ClusterState struct {
+	// Whether the resource in the cluster has been propagated from the
+	// current template and overrides.
+	Updated bool
+	// Whether the updated resource is ready.
+	Ready bool
+}
+
+// Plan determines which clusters may be created or updated from the
+// current template and overrides of a federated resource.
+type Plan struct {
+	allowed sets.Set[string]
+	Status  *status.RolloutStatus
+}
+
+// NewPlan computes the rollout plan for the given strategy, clusters
+// and per-cluster states. Clusters missing from the states are
+// considered not to have been updated.
+func NewPlan(strategy *Strategy, clusters []*fedv1b1.KubeFedCluster, states map[string]ClusterState) (*Plan, error) {
+	waves, err := assignWaves(strategy.Waves, clusters)
+	if err != nil {
+		return nil, err
+	}
+
+	rolloutStatus := &status.RolloutStatus{
+		Phase:    status.RolloutComplete,
+		Waves:    len(waves),
+		Clusters: len(clusters),
+	}
+	plan := &Plan{
+		allowed: sets.New[string](),
+		Status:  rolloutStatus,
+	}
+
+	// Clusters that have already been updated remain subject to
+	// reconciliation (e.g. to revert drift).
+	unavailable := int64(0)
+	for _, cluster := range clusters {
+		state := states[cluster.Name]
+		if !state.Updated {
+			continue
+		}
+		plan.allowed.Insert(cluster.Name)
+		rolloutStatus.UpdatedClusters++
+		if state.Ready {
+			rolloutStatus.ReadyClusters++
+		} else {
+			unavailable++
+		}
+	}
+	for i, wave := range waves {
+		var pending []string
+		waveReady := true
+		for _, clusterName := range wave {
+			state := states[clusterName]
+			if !state.Updated {
+				pending = append(pending, clusterName)
+			}
+			waveReady = waveReady && state.Ready
+		}
+		if len(pending) == 0 && (waveReady || !strategy.WaitForReady) {
+			continue
+		}
+
+		rolloutStatus.CurrentWave = i
+		if strategy.Paused {
+			rolloutStatus.Phase = status.RolloutPaused
+			return plan, nil
+		}
+		rolloutStatus.Phase = status.RolloutProgressing
+		if strategy.MaxUnavailableClusters != nil {
+			budget := *strategy.MaxUnavailableClusters - unavailable
+			if budget < 0 {
+				budget = 0
+			}
+			if int64(len(pending)) > budget {
+				pending = pending[:budget]
+			}
+		}
+		plan.allowed.Insert(pending...)
+		return plan, nil
+	}
+	if len(waves) > 0 {
+		rolloutStatus.CurrentWave = len(waves) - 1
+	}
+	return plan, nil
+}
+
+// Allowed returns whether the resource in the named cluster may be
+// created or updated.
+func (p *Plan) Allowed(clusterName string) bool {
+	return p.allowed.Has(clusterName)
+}
+
+// assignWaves returns the sorted names of the given clusters assigned
+// to each wave. A cluster is assigned to the first wave that matches
+// it, and clusters that are not matched by any wave are assigned to an
+// implicit final wave that is omitted if empty.
+func assignWaves(waves []Wave, clusters []*fedv1b1.KubeFedCluster) ([][]string, error) {
+	selectors := make([]labels.Selector, len(waves))
+	for i, wave := range waves {
+		if len(wave.Clusters) > 0 || wave.ClusterSelector == nil {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(wave.ClusterSelector)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Invalid cluster selector for wave %d", i)
+		}
+		selectors[i] = selector
+	}
+
+	assigned := make([][]string, len(waves)+1)
+	for _, cluster := range clusters {
+		index := len(waves)
+		for i, wave := range waves {
+			if waveMatches(wave, selectors[i], cluster) {
+				index = i
+				break
+			}
+		}
+		assigned[index] = append(assigned[index], cluster.Name)
+	}
+	for _, clusterNames := range assigned {
+		sort.Strings(clusterNames)
+	}
+	if len(assigned[len(waves)]) == 0 {
+		assigned = assigned[:len(waves)]
+	}
+	return assigned, nil
+}
+
+func waveMatches(wave Wave, selector labels.Selector, cluster *fedv1b1.KubeFedCluster) bool {
+	if len(wave.Clusters) > 0 {
+		for _, clusterRef := range wave.Clusters {
+			if clusterRef.Name == cluster.Name {
+				return true
+			}
+		}
+		return false
+	}
+	return selector != nil && selector.Matches(labels.Set(cluster.GetLabels()))
+}
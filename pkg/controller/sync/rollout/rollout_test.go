@@ -0,0 +1,250 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rollout
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
+
+	fedv1b1 "sigs.k8s.io/kubefed/pkg/apis/core/v1beta1"
+	"sigs.k8s.io/kubefed/pkg/controller/sync/status"
+	"sigs.k8s.io/kubefed/pkg/controller/util"
+)
+
+func TestNewPlan(t *testing.T) {
+	clusters := []*fedv1b1.KubeFedCluster{
+		newCluster("canary", "canary"),
+		newCluster("eu-1", "eu"),
+		newCluster("eu-2", "eu"),
+		newCluster("us-1", "us"),
+	}
+	waves := []Wave{
+		{Clusters: []util.GenericClusterReference{{Name: "canary"}}},
+		{ClusterSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"region": "eu"}}},
+	}
+	updated := ClusterState{Updated: true}
+	ready := ClusterState{Updated: true, Ready: true}
+
+	testCases := map[string]struct {
+		strategy        Strategy
+		states          map[string]ClusterState
+		expectedAllowed []string
+		expectedStatus  status.RolloutStatus
+	}{
+		"First wave is rolled out first": {
+			strategy:        Strategy{Waves: waves},
+			expectedAllowed: []string{"canary"},
+			expectedStatus: status.RolloutStatus{
+				Phase:    status.RolloutProgressing,
+				Waves:    3,
+				Clusters: 4,
+			},
+		},
+		"Next wave is rolled out once previous wave is updated": {
+			strategy:        Strategy{Waves: waves},
+			states:          map[string]ClusterState{"canary": updated},
+			expectedAllowed: []string{"canary", "eu-1", "eu-2"},
+			expectedStatus: status.RolloutStatus{
+				Phase:           status.RolloutProgressing,
+				CurrentWave:     1,
+				Waves:           3,
+				Clusters:        4,
+				UpdatedClusters: 1,
+			},
+		},
+		"Next wave waits for previous wave to be ready": {
+			strategy:        Strategy{Waves: waves, WaitForReady: true},
+			states:          map[string]ClusterState{"canary": updated},
+			expectedAllowed: []string{"canary"},
+			expectedStatus: status.RolloutStatus{
+				Phase:           status.RolloutProgressing,
+				Waves:           3,
+				Clusters:        4,
+				UpdatedClusters: 1,
+			},
+		},
+		"Clusters not matched by a wave are rolled out last": {
+			strategy:        Strategy{Waves: waves, WaitForReady: true},
+			states:          map[string]ClusterState{"canary": ready, "eu-1": ready, "eu-2": ready},
+			expectedAllowed: []string{"canary", "eu-1", "eu-2", "us-1"},
+			expectedStatus: status.RolloutStatus{
+				Phase:           status.RolloutProgressing,
+				CurrentWave:     2,
+				Waves:           3,
+				Clusters:        4,
+				UpdatedClusters: 3,
+				ReadyClusters:   3,
+			},
+		},
+		"Max unavailable clusters limits clusters updated at once": {
+			strategy:        Strategy{Waves: waves, MaxUnavailableClusters: ptr.To[int64](2)},
+			states:          map[string]ClusterState{"canary": updated},
+			expectedAllowed: []string{"canary", "eu-1"},
+			expectedStatus: status.RolloutStatus{
+				Phase:           status.RolloutProgressing,
+				CurrentWave:     1,
+				Waves:           3,
+				Clusters:        4,
+				UpdatedClusters: 1,
+			},
+		},
+		"Paused rollout does not update further clusters": {
+			strategy:        Strategy{Waves: waves, Paused: true},
+			states:          map[string]ClusterState{"canary": ready},
+			expectedAllowed: []string{"canary"},
+			expectedStatus: status.RolloutStatus{
+				Phase:           status.RolloutPaused,
+				CurrentWave:     1,
+				Waves:           3,
+				Clusters:        4,
+				UpdatedClusters: 1,
+				ReadyClusters:   1,
+			},
+		},
+		"Rollout without waves is complete once all clusters are updated": {
+			strategy:        Strategy{},
+			states:          map[string]ClusterState{"canary": updated, "eu-1": updated, "eu-2": updated, "us-1": updated},
+			expectedAllowed: []string{"canary", "eu-1", "eu-2", "us-1"},
+			expectedStatus: status.RolloutStatus{
+				Phase:           status.RolloutComplete,
+				Waves:           1,
+				Clusters:        4,
+				UpdatedClusters: 4,
+			},
+		},
+	}
+
+	for testName, tc := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			plan, err := NewPlan(&tc.strategy, clusters, tc.states)
+			require.NoError(t, err)
+			allowed := []string{}
+			for _, cluster := range clusters {
+				if plan.Allowed(cluster.Name) {
+					allowed = append(allowed, cluster.Name)
+				}
+			}
+			assert.Equal(t, tc.expectedAllowed, allowed)
+			assert.Equal(t, tc.expectedStatus, *plan.Status)
+		})
+	}
+}
+
+func TestGetStrategy(t *testing.T) {
+	fedObject := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"spec": map[string]interface{}{},
+		},
+	}
+	strategy, err := GetStrategy(fedObject)
+	require.NoError(t, err)
+	assert.Nil(t, strategy)
+
+	fedObject.Object["spec"] = map[string]interface{}{
+		util.RolloutStrategyField: map[string]interface{}{
+			"maxUnavailableClusters": int64(1),
+			"waves": []interface{}{
+				map[string]interface{}{
+					"clusters": []interface{}{
+						map[string]interface{}{"name": "canary"},
+					},
+				},
+			},
+		},
+	}
+	strategy, err = GetStrategy(fedObject)
+	require.NoError(t, err)
+	assert.Equal(t, &Strategy{
+		Waves:                  []Wave{{Clusters: []util.GenericClusterReference{{Name: "canary"}}}},
+		MaxUnavailableClusters: ptr.To[int64](1),
+	}, strategy)
+}
+
+func TestIsReady(t *testing.T) {
+	testCases := map[string]struct {
+		obj           map[string]interface{}
+		expectedReady bool
+	}{
+		"Resource without status is ready": {
+			obj:           map[string]interface{}{},
+			expectedReady: true,
+		},
+		"Status of a previous generation is not ready": {
+			obj: map[string]interface{}{
+				"metadata": map[string]interface{}{"generation": int64(2)},
+				"status":   map[string]interface{}{"observedGeneration": int64(1)},
+			},
+			expectedReady: false,
+		},
+		"Unavailable condition is not ready": {
+			obj: map[string]interface{}{
+				"status": map[string]interface{}{
+					"conditions": []interface{}{
+						map[string]interface{}{"type": "Available", "status": "False"},
+					},
+				},
+			},
+			expectedReady: false,
+		},
+		"Missing ready replicas is not ready": {
+			obj: map[string]interface{}{
+				"spec":   map[string]interface{}{"replicas": int64(2)},
+				"status": map[string]interface{}{"replicas": int64(2), "updatedReplicas": int64(2)},
+			},
+			expectedReady: false,
+		},
+		"All replicas updated and available is ready": {
+			obj: map[string]interface{}{
+				"metadata": map[string]interface{}{"generation": int64(2)},
+				"spec":     map[string]interface{}{"replicas": int64(2)},
+				"status": map[string]interface{}{
+					"observedGeneration": int64(2),
+					"replicas":           int64(2),
+					"updatedReplicas":    int64(2),
+					"readyReplicas":      int64(2),
+					"availableReplicas":  int64(2),
+					"conditions": []interface{}{
+						map[string]interface{}{"type": "Available", "status": "True"},
+						map[string]interface{}{"type": "Progressing", "status": "True"},
+					},
+				},
+			},
+			expectedReady: true,
+		},
+	}
+
+	for testName, tc := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			assert.Equal(t, tc.expectedReady, IsReady(&unstructured.Unstructured{Object: tc.obj}))
+		})
+	}
+}
+
+func newCluster(name, region string) *fedv1b1.KubeFedCluster {
+	return &fedv1b1.KubeFedCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{"region": region},
+		},
+	}
+}
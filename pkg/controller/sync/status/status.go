@@ -26,6 +26,7 @@ import (
 	apiv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
 
 	"sigs.k8s.io/kubefed/pkg/controller/util"
@@ -37,15 +38,21 @@ type AggregateReason string
 
 type ConditionType string
 
+type RolloutPhase string
+
 const (
 	ClusterPropagationOK PropagationStatus = ""
 	WaitingForRemoval    PropagationStatus = "WaitingForRemoval"
+	WaitingForRollout    PropagationStatus = "WaitingForRollout"
+	WaitingForDependency PropagationStatus = "WaitingForDependency"
+	OperationInProgress  PropagationStatus = "OperationInProgress"
 
 	// Cluster-specific errors
 	ClusterNotReady        PropagationStatus = "ClusterNotReady"
 	CachedRetrievalFailed  PropagationStatus = "CachedRetrievalFailed"
 	ComputeResourceFailed  PropagationStatus = "ComputeResourceFailed"
 	ApplyOverridesFailed   PropagationStatus = "ApplyOverridesFailed"
+	SubstitutionFailed     PropagationStatus = "SubstitutionFailed"
 	CreationFailed         PropagationStatus = "CreationFailed"
 	UpdateFailed           PropagationStatus = "UpdateFailed"
 	DeletionFailed         PropagationStatus = "DeletionFailed"
@@ -56,6 +63,9 @@ const (
 	VersionRetrievalFailed PropagationStatus = "VersionRetrievalFailed"
 	ClientRetrievalFailed  PropagationStatus = "ClientRetrievalFailed"
 	ManagedLabelFalse      PropagationStatus = "ManagedLabelFalse"
+	FieldManagerConflict   PropagationStatus = "FieldManagerConflict"
+	Drifted                PropagationStatus = "Drifted"
+	DependencyCheckFailed  PropagationStatus = "DependencyCheckFailed"
 
 	// Operation timeout errors
 	CreationTimedOut     PropagationStatus = "CreationTimedOut"
@@ -63,19 +73,26 @@ const (
 	DeletionTimedOut     PropagationStatus = "DeletionTimedOut"
 	LabelRemovalTimedOut PropagationStatus = "LabelRemovalTimedOut"
 
-	AggregateSuccess       AggregateReason = ""
-	ClusterRetrievalFailed AggregateReason = "ClusterRetrievalFailed"
-	ComputePlacementFailed AggregateReason = "ComputePlacementFailed"
-	CheckClusters          AggregateReason = "CheckClusters"
-	NamespaceNotFederated  AggregateReason = "NamespaceNotFederated"
+	AggregateSuccess          AggregateReason = ""
+	ClusterRetrievalFailed    AggregateReason = "ClusterRetrievalFailed"
+	ComputePlacementFailed    AggregateReason = "ComputePlacementFailed"
+	CheckClusters             AggregateReason = "CheckClusters"
+	NamespaceNotFederated     AggregateReason = "NamespaceNotFederated"
+	ComputeRolloutFailed      AggregateReason = "ComputeRolloutFailed"
+	ComputeDependenciesFailed AggregateReason = "ComputeDependenciesFailed"
 
 	PropagationConditionType ConditionType = "Propagation"
+
+	RolloutProgressing RolloutPhase = "Progressing"
+	RolloutPaused      RolloutPhase = "Paused"
+	RolloutComplete    RolloutPhase = "Complete"
 )
 
 type GenericClusterStatus struct {
 	Name         string            `json:"name"`
 	Status       PropagationStatus `json:"status,omitempty"`
 	RemoteStatus interface{}       `json:"remoteStatus,omitempty"`
+	DriftedPaths []string          `json:"driftedPaths,omitempty"`
 }
 
 type GenericCondition struct {
@@ -95,10 +112,29 @@ type GenericCondition struct {
 	Reason AggregateReason `json:"reason,omitempty"`
 }
 
+// RolloutStatus reports the progress of the rollout of the current
+// template and overrides of a federated resource to member clusters.
+type RolloutStatus struct {
+	Phase RolloutPhase `json:"phase"`
+	// Index of the wave currently being rolled out.
+	CurrentWave int `json:"currentWave"`
+	// Total number of waves, including the implicit final wave of
+	// clusters not matched by any configured wave.
+	Waves int `json:"waves"`
+	// Number of selected clusters taking part in the rollout.
+	Clusters int `json:"clusters"`
+	// Number of clusters updated to the current template and overrides.
+	UpdatedClusters int `json:"updatedClusters"`
+	// Number of updated clusters whose resource is ready.
+	ReadyClusters int `json:"readyClusters"`
+}
+
 type GenericFederatedStatus struct {
 	ObservedGeneration int64                  `json:"observedGeneration,omitempty"`
 	Conditions         []*GenericCondition    `json:"conditions,omitempty"`
 	Clusters           []GenericClusterStatus `json:"clusters,omitempty"`
+	Rollout            *RolloutStatus         `json:"rollout,omitempty"`
+	Placement          *util.PlacementStatus  `json:"placement,omitempty"`
 }
 
 type GenericFederatedResource struct {
@@ -113,6 +149,23 @@ type PropagationStatusMap map[string]PropagationStatus
 type CollectedPropagationStatus struct {
 	StatusMap        PropagationStatusMap
 	ResourcesUpdated bool
+	// DriftedPaths holds the paths of the fields that have drifted
+	// from their desired state, keyed by cluster name.
+	DriftedPaths map[string][]string
+	// Rollout holds the progress of a rollout if the federated
+	// resource has a rollout strategy.
+	Rollout *RolloutStatus
+	// RetainedClusters holds the names of selected clusters whose
+	// status is reported by the agent of a pull-mode cluster.
+	RetainedClusters sets.Set[string]
+	// PullClusters holds the names of all pull-mode clusters. Their
+	// entries in status.clusters are retained until they are removed
+	// by their agents, including the entries of clusters that are no
+	// longer selected and whose agents are removing the resource.
+	PullClusters sets.Set[string]
+	// Placement holds the outcome of the placement constraints of the
+	// federated resource, if it has any.
+	Placement *util.PlacementStatus
 }
 
 type CollectedResourceStatus struct {
@@ -164,6 +217,102 @@ func SetFederatedStatus(fedObject *unstructured.Unstructured, reason AggregateRe
 	return true, nil
 }
 
+// ClusterNames returns the names of the clusters that have an entry in
+// the status.clusters field of the federated resource's object map.
+func ClusterNames(fedObject *unstructured.Unstructured) (sets.Set[string], error) {
+	resource := &GenericFederatedResource{}
+	err := util.UnstructuredToInterface(fedObject, resource)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to unmarshall to generic resource")
+	}
+	clusterNames := sets.New[string]()
+	if resource.Status != nil {
+		for _, status := range resource.Status.Clusters {
+			clusterNames.Insert(status.Name)
+		}
+	}
+	return clusterNames, nil
+}
+
+// DriftedPaths returns the drifted paths recorded for the named cluster
+// in the status.clusters field of the federated resource's object map.
+func DriftedPaths(fedObject *unstructured.Unstructured, clusterName string) ([]string, error) {
+	resource := &GenericFederatedResource{}
+	err := util.UnstructuredToInterface(fedObject, resource)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to unmarshall to generic resource")
+	}
+	if resource.Status != nil {
+		for _, status := range resource.Status.Clusters {
+			if status.Name == clusterName {
+				return status.DriftedPaths, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+// SetClusterStatus sets the entry of the named cluster in the
+// status.clusters field of the federated resource's object map, or
+// removes the entry if clusterStatus is nil. It allows the agent of a
+// pull-mode cluster to report the status of its cluster without
+// affecting the entries of other clusters. Returns a boolean indication
+// of whether status should be written to the API.
+func SetClusterStatus(fedObject *unstructured.Unstructured, clusterName string, clusterStatus *GenericClusterStatus) (bool, error) {
+	resource := &GenericFederatedResource{}
+	err := util.UnstructuredToInterface(fedObject, resource)
+	if err != nil {
+		return false, errors.Wrapf(err, "Failed to unmarshall to generic resource")
+	}
+	if resource.Status == nil {
+		resource.Status = &GenericFederatedStatus{}
+	}
+
+	var normalizedStatus *GenericClusterStatus
+	if clusterStatus != nil {
+		// The status is normalized to the representation of existing
+		// entries so that they can be compared.
+		content, err := json.Marshal(clusterStatus)
+		if err != nil {
+			return false, errors.Wrapf(err, "Failed to marshall status of cluster %s", clusterName)
+		}
+		normalizedStatus = &GenericClusterStatus{}
+		if err := json.Unmarshal(content, normalizedStatus); err != nil {
+			return false, errors.Wrapf(err, "Failed to unmarshall status of cluster %s", clusterName)
+		}
+		normalizedStatus.Name = clusterName
+	}
+
+	clusters := []GenericClusterStatus{}
+	var existingStatus *GenericClusterStatus
+	for i, status := range resource.Status.Clusters {
+		if status.Name == clusterName {
+			existingStatus = &resource.Status.Clusters[i]
+			continue
+		}
+		clusters = append(clusters, status)
+	}
+	if reflect.DeepEqual(existingStatus, normalizedStatus) {
+		return false, nil
+	}
+	if normalizedStatus != nil {
+		clusters = append(clusters, *normalizedStatus)
+	}
+	resource.Status.Clusters = clusters
+
+	resourceJSON, err := json.Marshal(resource)
+	if err != nil {
+		return false, errors.Wrapf(err, "Failed to marshall generic status to json")
+	}
+	resourceObj := &unstructured.Unstructured{}
+	err = resourceObj.UnmarshalJSON(resourceJSON)
+	if err != nil {
+		return false, errors.Wrapf(err, "Failed to marshall generic resource json to unstructured")
+	}
+	fedObject.Object[util.StatusField] = resourceObj.Object[util.StatusField]
+	return true, nil
+}
+
 // IsRecoverableError returns whether the given PropagationStatus is a possibly recoverable error.
 func IsRecoverableError(status PropagationStatus) bool {
 	switch status {
@@ -174,6 +323,7 @@ func IsRecoverableError(status PropagationStatus) bool {
 		LabelRemovalFailed,
 		RetrievalFailed,
 		ClientRetrievalFailed,
+		DependencyCheckFailed,
 		CreationTimedOut,
 		UpdateTimedOut,
 		DeletionTimedOut,
@@ -205,8 +355,11 @@ func (s *GenericFederatedStatus) update(generation int64, reason AggregateReason
 			}
 		}
 	}
+	if reason == AggregateSuccess && !s.retainedClustersOK(collectedStatus.RetainedClusters) {
+		reason = CheckClusters
+	}
 
-	clustersChanged := s.setClusters(collectedStatus.StatusMap, collectedResourceStatus.StatusMap, resourceStatusCollection)
+	clustersChanged := s.setClusters(collectedStatus.StatusMap, collectedResourceStatus.StatusMap, collectedStatus.DriftedPaths, collectedStatus.PullClusters, resourceStatusCollection)
 
 	// Indicate that changes were propagated if either status.clusters
 	// was changed or if existing resources were updated (which could
@@ -216,40 +369,79 @@ func (s *GenericFederatedStatus) update(generation int64, reason AggregateReason
 
 	propStatusUpdated := s.setPropagationCondition(reason, changesPropagated)
 
-	statusUpdated := generationUpdated || propStatusUpdated
+	// Rollout progress and the outcome of placement are only known
+	// when the status of clusters was collected, so retain the
+	// existing values otherwise.
+	rolloutUpdated := false
+	placementUpdated := false
+	if collectedStatus.StatusMap != nil {
+		rolloutUpdated = s.setRollout(collectedStatus.Rollout)
+		placementUpdated = s.setPlacement(collectedStatus.Placement)
+	}
+
+	statusUpdated := generationUpdated || propStatusUpdated || rolloutUpdated || placementUpdated
 
 	klog.V(4).Infof("Value of flags: propStatusUpdated: '%v'; statusUpdated '%v'; changesPropagated '%v'", propStatusUpdated, statusUpdated, changesPropagated)
 	return statusUpdated
 }
 
+// retainedClustersOK checks whether the agents of all retained clusters
+// have reported that the resource was propagated successfully.
+func (s *GenericFederatedStatus) retainedClustersOK(retainedClusters sets.Set[string]) bool {
+	reported := sets.New[string]()
+	for _, status := range s.Clusters {
+		if !retainedClusters.Has(status.Name) {
+			continue
+		}
+		if status.Status != ClusterPropagationOK {
+			return false
+		}
+		reported.Insert(status.Name)
+	}
+	return reported.Len() == retainedClusters.Len()
+}
+
 // setClusters sets the status.clusters slice from propagation and resource status
-// maps. Returns a boolean indication of whether the status.clusters was
-// modified.
-func (s *GenericFederatedStatus) setClusters(statusMap PropagationStatusMap, resourceStatusMap map[string]interface{}, resourceStatusCollection bool) bool {
-	if !s.clustersDiffer(statusMap, resourceStatusMap, resourceStatusCollection) {
+// maps, retaining the entries of the given pull-mode clusters. Returns a boolean
+// indication of whether the status.clusters was modified.
+func (s *GenericFederatedStatus) setClusters(statusMap PropagationStatusMap, resourceStatusMap map[string]interface{}, driftedPaths map[string][]string, pullClusters sets.Set[string], resourceStatusCollection bool) bool {
+	retained := []GenericClusterStatus{}
+	propagated := []GenericClusterStatus{}
+	for _, status := range s.Clusters {
+		if pullClusters.Has(status.Name) {
+			retained = append(retained, status)
+		} else {
+			propagated = append(propagated, status)
+		}
+	}
+	if !clustersDiffer(propagated, statusMap, resourceStatusMap, driftedPaths, resourceStatusCollection) {
 		return false
 	}
-	s.Clusters = []GenericClusterStatus{}
+	s.Clusters = retained
 	for clusterName, status := range statusMap {
 		rawResourceStatus := resourceStatusMap[clusterName]
 		s.Clusters = append(s.Clusters, GenericClusterStatus{
 			Name:         clusterName,
 			Status:       status,
 			RemoteStatus: rawResourceStatus,
+			DriftedPaths: driftedPaths[clusterName],
 		})
 	}
 	return true
 }
 
-// clustersDiffer checks whether `status.clusters` differs from the
-// given status map.
-func (s *GenericFederatedStatus) clustersDiffer(statusMap PropagationStatusMap, resourceStatusMap map[string]interface{}, resourceStatusCollection bool) bool {
-	if len(s.Clusters) != len(statusMap) || resourceStatusCollection && len(s.Clusters) != len(resourceStatusMap) {
-		klog.V(4).Infof("Clusters differs from the size: clusters = %v, statusMap = %v, resourceStatusMap = %v", s.Clusters, statusMap, resourceStatusMap)
+// clustersDiffer checks whether the given entries of `status.clusters`
+// differ from the given status map.
+func clustersDiffer(clusters []GenericClusterStatus, statusMap PropagationStatusMap, resourceStatusMap map[string]interface{}, driftedPaths map[string][]string, resourceStatusCollection bool) bool {
+	if len(clusters) != len(statusMap) || resourceStatusCollection && len(clusters) != len(resourceStatusMap) {
+		klog.V(4).Infof("Clusters differs from the size: clusters = %v, statusMap = %v, resourceStatusMap = %v", clusters, statusMap, resourceStatusMap)
 		return true
 	}
-	for _, status := range s.Clusters {
-		if statusMap[status.Name] != status.Status {
+	for _, status := range clusters {
+		if propagationStatus, ok := statusMap[status.Name]; !ok || propagationStatus != status.Status {
+			return true
+		}
+		if !reflect.DeepEqual(driftedPaths[status.Name], status.DriftedPaths) {
 			return true
 		}
 		if !reflect.DeepEqual(resourceStatusMap[status.Name], status.RemoteStatus) {
@@ -260,6 +452,24 @@ func (s *GenericFederatedStatus) clustersDiffer(statusMap PropagationStatusMap,
 	return false
 }
 
+// setRollout sets status.rollout to the given rollout progress. Returns
+// a boolean indication of whether status.rollout was modified.
+func (s *GenericFederatedStatus) setRollout(rollout *RolloutStatus) bool {
+	if reflect.DeepEqual(s.Rollout, rollout) {
+		return false
+	}
+	s.Rollout = rollout
+	return true
+}
+
+func (s *GenericFederatedStatus) setPlacement(placement *util.PlacementStatus) bool {
+	if reflect.DeepEqual(s.Placement, placement) {
+		return false
+	}
+	s.Placement = placement
+	return true
+}
+
 // setPropagationCondition ensures that the Propagation condition is
 // updated to reflect the given reason.  The type of the condition is
 // derived from the reason (empty -> True, not empty -> False).
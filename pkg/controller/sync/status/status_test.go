@@ -21,6 +21,8 @@ import (
 	"testing"
 
 	apiv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/sets"
 )
 
 func TestGenericPropagationStatusUpdateChanged(t *testing.T) {
@@ -30,6 +32,8 @@ func TestGenericPropagationStatusUpdateChanged(t *testing.T) {
 		statusMap                PropagationStatusMap
 		resourceStatusMap        map[string]interface{}
 		remoteStatus             interface{}
+		driftedPaths             map[string][]string
+		rollout                  *RolloutStatus
 		resourcesUpdated         bool
 		expectedChanged          bool
 		resourceStatusCollection bool
@@ -154,6 +158,31 @@ func TestGenericPropagationStatusUpdateChanged(t *testing.T) {
 			resourceStatusCollection: true,
 			expectedChanged:          false,
 		},
+		"Change in drifted paths indicates changed": {
+			statusMap: PropagationStatusMap{
+				"cluster1": ClusterPropagationOK,
+			},
+			driftedPaths: map[string][]string{
+				"cluster1": {"/spec/replicas"},
+			},
+			resourcesUpdated:         false,
+			resourceStatusCollection: false,
+			expectedChanged:          true,
+		},
+		"Change in rollout progress indicates changed": {
+			statusMap: PropagationStatusMap{
+				"cluster1": ClusterPropagationOK,
+			},
+			rollout: &RolloutStatus{
+				Phase:           RolloutProgressing,
+				Waves:           2,
+				Clusters:        2,
+				UpdatedClusters: 1,
+			},
+			resourcesUpdated:         false,
+			resourceStatusCollection: false,
+			expectedChanged:          true,
+		},
 		"Change in clusters indicates changed with status collected enabled": {
 			statusMap: PropagationStatusMap{
 				"cluster1": ClusterPropagationOK,
@@ -210,6 +239,8 @@ func TestGenericPropagationStatusUpdateChanged(t *testing.T) {
 			collectedStatus := CollectedPropagationStatus{
 				StatusMap:        tc.statusMap,
 				ResourcesUpdated: tc.resourcesUpdated,
+				DriftedPaths:     tc.driftedPaths,
+				Rollout:          tc.rollout,
 			}
 			collectedResourceStatus := CollectedResourceStatus{
 				StatusMap:        tc.resourceStatusMap,
@@ -280,3 +311,141 @@ func TestNormalizeStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestGenericPropagationStatusUpdateRetainedClusters(t *testing.T) {
+	testCases := map[string]struct {
+		retainedStatus   PropagationStatus
+		retainedClusters sets.Set[string]
+		pullClusters     sets.Set[string]
+		expectedClusters []string
+		expectedReason   AggregateReason
+	}{
+		"Retained cluster entry is kept": {
+			retainedClusters: sets.New("pull1"),
+			pullClusters:     sets.New("pull1"),
+			expectedClusters: []string{"cluster1", "pull1"},
+			expectedReason:   AggregateSuccess,
+		},
+		"Retained cluster error indicates clusters should be checked": {
+			retainedStatus:   CreationFailed,
+			retainedClusters: sets.New("pull1"),
+			pullClusters:     sets.New("pull1"),
+			expectedClusters: []string{"cluster1", "pull1"},
+			expectedReason:   CheckClusters,
+		},
+		"Unreported retained cluster indicates clusters should be checked": {
+			retainedClusters: sets.New("pull1", "pull2"),
+			pullClusters:     sets.New("pull1", "pull2"),
+			expectedClusters: []string{"cluster1", "pull1"},
+			expectedReason:   CheckClusters,
+		},
+		"Entry of deselected pull cluster is kept until its agent removes it": {
+			retainedStatus:   WaitingForRemoval,
+			pullClusters:     sets.New("pull1"),
+			expectedClusters: []string{"cluster1", "pull1"},
+			expectedReason:   AggregateSuccess,
+		},
+		"Entry of cluster that is no longer a pull cluster is removed": {
+			expectedClusters: []string{"cluster1"},
+			expectedReason:   AggregateSuccess,
+		},
+	}
+	for testName, tc := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			fedStatus := &GenericFederatedStatus{
+				Clusters: []GenericClusterStatus{
+					{
+						Name:   "pull1",
+						Status: tc.retainedStatus,
+					},
+				},
+			}
+			collectedStatus := CollectedPropagationStatus{
+				StatusMap: PropagationStatusMap{
+					"cluster1": ClusterPropagationOK,
+				},
+				RetainedClusters: tc.retainedClusters,
+				PullClusters:     tc.pullClusters,
+			}
+			fedStatus.update(0, AggregateSuccess, collectedStatus, CollectedResourceStatus{}, false)
+
+			clusterNames := sets.New[string]()
+			statuses := map[string]PropagationStatus{}
+			for _, status := range fedStatus.Clusters {
+				clusterNames.Insert(status.Name)
+				statuses[status.Name] = status.Status
+			}
+			if !reflect.DeepEqual(tc.expectedClusters, sets.List(clusterNames)) {
+				t.Fatalf("Expected clusters to be %v, got %v", tc.expectedClusters, sets.List(clusterNames))
+			}
+			if clusterNames.Has("pull1") && statuses["pull1"] != tc.retainedStatus {
+				t.Fatalf("Expected the status of pull1 to be retained as %q, got %q", tc.retainedStatus, statuses["pull1"])
+			}
+			if tc.expectedReason != fedStatus.Conditions[0].Reason {
+				t.Fatalf("Expected reason to be %q, got %q", tc.expectedReason, fedStatus.Conditions[0].Reason)
+			}
+		})
+	}
+}
+
+func TestSetClusterStatus(t *testing.T) {
+	testCases := map[string]struct {
+		clusterStatus    *GenericClusterStatus
+		expectedChanged  bool
+		expectedClusters []interface{}
+	}{
+		"Unchanged entry indicates unchanged": {
+			clusterStatus: &GenericClusterStatus{
+				RemoteStatus: map[string]interface{}{"replicas": 1},
+			},
+			expectedChanged: false,
+		},
+		"Changed entry is replaced": {
+			clusterStatus: &GenericClusterStatus{
+				Status: CreationFailed,
+			},
+			expectedChanged: true,
+			expectedClusters: []interface{}{
+				map[string]interface{}{"name": "cluster1"},
+				map[string]interface{}{"name": "pull1", "status": "CreationFailed"},
+			},
+		},
+		"Nil status removes the entry": {
+			expectedChanged: true,
+			expectedClusters: []interface{}{
+				map[string]interface{}{"name": "cluster1"},
+			},
+		},
+	}
+	for testName, tc := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			fedObject := &unstructured.Unstructured{Object: map[string]interface{}{
+				"apiVersion": "types.kubefed.io/v1beta1",
+				"kind":       "FederatedDeployment",
+				"status": map[string]interface{}{
+					"clusters": []interface{}{
+						map[string]interface{}{"name": "cluster1"},
+						map[string]interface{}{
+							"name":         "pull1",
+							"remoteStatus": map[string]interface{}{"replicas": int64(1)},
+						},
+					},
+				},
+			}}
+			changed, err := SetClusterStatus(fedObject, "pull1", tc.clusterStatus)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if tc.expectedChanged != changed {
+				t.Fatalf("Expected changed to be %v, got %v", tc.expectedChanged, changed)
+			}
+			if !changed {
+				return
+			}
+			clusters, _, _ := unstructured.NestedSlice(fedObject.Object, "status", "clusters")
+			if !reflect.DeepEqual(tc.expectedClusters, clusters) {
+				t.Fatalf("Expected clusters to be %#v, got %#v", tc.expectedClusters, clusters)
+			}
+		})
+	}
+}
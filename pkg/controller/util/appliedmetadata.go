@@ -0,0 +1,76 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	// LastAppliedMetadataAnnotation records the keys of the labels and
+	// annotations of a resource in a member cluster that were last
+	// applied by KubeFed. Keys that are not recorded are owned by the
+	// member cluster and are preserved by updates.
+	LastAppliedMetadataAnnotation = "kubefed.io/last-applied-metadata"
+)
+
+// AppliedMetadata is the content of the last applied metadata
+// annotation.
+type AppliedMetadata struct {
+	Labels      []string `json:"labels,omitempty"`
+	Annotations []string `json:"annotations,omitempty"`
+}
+
+// IsEmpty indicates whether no keys are recorded.
+func (m *AppliedMetadata) IsEmpty() bool {
+	return len(m.Labels) == 0 && len(m.Annotations) == 0
+}
+
+// GetAppliedMetadata returns the metadata keys recorded by the last
+// applied metadata annotation of the given object, or nil if the object
+// does not have the annotation.
+func GetAppliedMetadata(obj *unstructured.Unstructured) (*AppliedMetadata, error) {
+	value, ok := obj.GetAnnotations()[LastAppliedMetadataAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	applied := &AppliedMetadata{}
+	if err := json.Unmarshal([]byte(value), applied); err != nil {
+		return nil, errors.Wrapf(err, "could not deserialize applied metadata from annotation value '%s'", value)
+	}
+	return applied, nil
+}
+
+// SetAppliedMetadata sets the last applied metadata annotation of the
+// given object.
+func SetAppliedMetadata(obj *unstructured.Unstructured, applied *AppliedMetadata) error {
+	value, err := json.Marshal(applied)
+	if err != nil {
+		return errors.Wrapf(err, "could not serialize applied metadata '%v'", applied)
+	}
+
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[LastAppliedMetadataAnnotation] = string(value)
+	obj.SetAnnotations(annotations)
+	return nil
+}
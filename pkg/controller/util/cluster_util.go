@@ -23,19 +23,24 @@ import (
 	"net"
 	"net/http"
 	"net/url"
+	"slices"
 	"time"
 
 	"github.com/pkg/errors"
 	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 
 	apiv1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
 	restclient "k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 	"k8s.io/client-go/transport"
 	"k8s.io/klog/v2"
 
 	fedv1b1 "sigs.k8s.io/kubefed/pkg/apis/core/v1beta1"
 	"sigs.k8s.io/kubefed/pkg/client/generic"
+	"sigs.k8s.io/kubefed/pkg/tunnel"
 )
 
 const (
@@ -60,29 +65,54 @@ func BuildClusterConfig(fedCluster *fedv1b1.KubeFedCluster, client generic.Clien
 		return nil, errors.Errorf("The api endpoint of cluster %s is empty", clusterName)
 	}
 
-	secretName := fedCluster.Spec.SecretRef.Name
-	if secretName == "" {
-		return nil, errors.Errorf("Cluster %s does not have a secret name", clusterName)
-	}
-	secret := &apiv1.Secret{}
-	err := client.Get(context.TODO(), secret, fedNamespace, secretName)
+	clusterConfig, err := clientcmd.BuildConfigFromFlags(apiEndpoint, "")
 	if err != nil {
 		return nil, err
 	}
 
-	token, tokenFound := secret.Data[TokenKey]
-	if !tokenFound || len(token) == 0 {
-		return nil, errors.Errorf("The secret for cluster %s is missing a non-empty value for %q", clusterName, TokenKey)
+	secretName := fedCluster.Spec.SecretRef.Name
+	if secretName == "" && fedCluster.Spec.Exec == nil {
+		return nil, errors.Errorf("Cluster %s does not have a secret name", clusterName)
 	}
-
-	clusterConfig, err := clientcmd.BuildConfigFromFlags(apiEndpoint, "")
-	if err != nil {
-		return nil, err
+	if secretName != "" {
+		secret := &apiv1.Secret{}
+		err := client.Get(context.TODO(), secret, fedNamespace, secretName)
+		if err != nil {
+			return nil, err
+		}
+		if err := setClusterCredentials(clusterConfig, secret); err != nil {
+			return nil, errors.Wrapf(err, "The secret for cluster %s is invalid", clusterName)
+		}
+	}
+	if fedCluster.Spec.Exec != nil {
+		fedConfig := &fedv1b1.KubeFedConfig{}
+		err := client.Get(context.TODO(), fedConfig, fedNamespace, KubeFedConfigName)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+		clusterConfig.ExecProvider, err = execProvider(fedCluster.Spec.Exec, fedConfig.Spec.ExecCredentialCommands)
+		if err != nil {
+			return nil, errors.Wrapf(err, "The exec credentials of cluster %s are not allowed", clusterName)
+		}
 	}
+
 	clusterConfig.CAData = fedCluster.Spec.CABundle
-	clusterConfig.BearerToken = string(token)
+	if fedCluster.Spec.CABundleSecretRef != nil {
+		caSecret := &apiv1.Secret{}
+		err := client.Get(context.TODO(), caSecret, fedNamespace, fedCluster.Spec.CABundleSecretRef.Name)
+		if err != nil {
+			return nil, err
+		}
+		caBundle, found := caSecret.Data[CaCrtKey]
+		if !found || len(caBundle) == 0 {
+			return nil, errors.Errorf("The CA bundle secret for cluster %s is missing a non-empty value for %q", clusterName, CaCrtKey)
+		}
+		clusterConfig.CAData = caBundle
+	}
+
 	clusterConfig.QPS = KubeAPIQPS
 	clusterConfig.Burst = KubeAPIBurst
+	applyClusterClientConfig(clusterConfig, fedCluster.Spec.ClientConfig)
 
 	if fedCluster.Spec.ProxyURL != "" {
 		proxyURL, err := url.Parse(fedCluster.Spec.ProxyURL)
@@ -91,6 +121,10 @@ func BuildClusterConfig(fedCluster *fedv1b1.KubeFedCluster, client generic.Clien
 		}
 		clusterConfig.Proxy = http.ProxyURL(proxyURL)
 	}
+	if fedCluster.Spec.Transport == fedv1b1.ClusterTransportTunnel {
+		clusterConfig.Proxy = nil
+		clusterConfig.Dial = tunnel.Dialer(clusterName)
+	}
 
 	if len(fedCluster.Spec.DisabledTLSValidations) != 0 {
 		klog.V(1).Infof("Cluster %s will use a custom transport for TLS certificate validation", fedCluster.Name)
@@ -102,6 +136,78 @@ func BuildClusterConfig(fedCluster *fedv1b1.KubeFedCluster, client generic.Clien
 	return clusterConfig, nil
 }
 
+// setClusterCredentials configures the client of a member cluster
+// with the bearer token or client certificate held by the given secret.
+func setClusterCredentials(clusterConfig *restclient.Config, secret *apiv1.Secret) error {
+	token := secret.Data[TokenKey]
+	certData := secret.Data[apiv1.TLSCertKey]
+	keyData := secret.Data[apiv1.TLSPrivateKeyKey]
+	if len(token) == 0 && len(certData) == 0 {
+		return errors.Errorf("a non-empty value is required for %q, or for %q and %q", TokenKey, apiv1.TLSCertKey, apiv1.TLSPrivateKeyKey)
+	}
+	if len(certData) != 0 && len(keyData) == 0 {
+		return errors.Errorf("a non-empty value for %q is required with %q", apiv1.TLSPrivateKeyKey, apiv1.TLSCertKey)
+	}
+	clusterConfig.BearerToken = string(token)
+	clusterConfig.CertData = certData
+	clusterConfig.KeyData = keyData
+	return nil
+}
+
+// execProvider returns the client configuration for the given exec
+// credential command, which must be one of the allowed commands.
+// Controllers are not interactive, so the command may not prompt for
+// input.
+func execProvider(execConfig *fedv1b1.ExecConfig, allowedCommands []string) (*clientcmdapi.ExecConfig, error) {
+	if !slices.Contains(allowedCommands, execConfig.Command) {
+		return nil, errors.Errorf("command %q is not one of the execCredentialCommands of the KubeFedConfig", execConfig.Command)
+	}
+	env := make([]clientcmdapi.ExecEnvVar, 0, len(execConfig.Env))
+	for _, envVar := range execConfig.Env {
+		env = append(env, clientcmdapi.ExecEnvVar{Name: envVar.Name, Value: envVar.Value})
+	}
+	return &clientcmdapi.ExecConfig{
+		Command:            execConfig.Command,
+		Args:               execConfig.Args,
+		Env:                env,
+		APIVersion:         execConfig.APIVersion,
+		ProvideClusterInfo: execConfig.ProvideClusterInfo,
+		InteractiveMode:    clientcmdapi.NeverExecInteractiveMode,
+	}, nil
+}
+
+// applyClusterClientConfig overrides the client defaults of a member
+// cluster with the tuning configured on its KubeFedCluster.
+func applyClusterClientConfig(clusterConfig *restclient.Config, clientConfig *fedv1b1.ClusterClientConfig) {
+	if clientConfig == nil {
+		return
+	}
+	if clientConfig.QPS != nil {
+		clusterConfig.QPS = float32(*clientConfig.QPS)
+	}
+	if clientConfig.Burst != nil {
+		clusterConfig.Burst = int(*clientConfig.Burst)
+	}
+	if clientConfig.RequestTimeout != nil {
+		clusterConfig.Timeout = clientConfig.RequestTimeout.Duration
+	}
+	switch clientConfig.ContentType {
+	case fedv1b1.ClientContentTypeJSON:
+		clusterConfig.ContentType = runtime.ContentTypeJSON
+	case fedv1b1.ClientContentTypeProtobuf:
+		clusterConfig.ContentType = runtime.ContentTypeProtobuf
+		// Fall back to JSON for types that cannot be served as protobuf.
+		clusterConfig.AcceptContentTypes = runtime.ContentTypeProtobuf + "," + runtime.ContentTypeJSON
+	}
+}
+
+// IsPullModeCluster returns whether resources are propagated to the
+// given cluster by an agent running in the cluster rather than by the
+// control plane.
+func IsPullModeCluster(cluster *fedv1b1.KubeFedCluster) bool {
+	return cluster.Spec.Mode == fedv1b1.ClusterModePull
+}
+
 // IsPrimaryCluster checks if the caller is working with objects for the
 // primary cluster by checking if the UIDs match for both ObjectMetas passed
 // in.
@@ -131,7 +237,7 @@ func CustomizeTLSTransport(fedCluster *fedv1b1.KubeFedCluster, clientConfig *res
 		}
 
 		// using the same defaults as http.DefaultTransport
-		clientConfig.Transport = &http.Transport{
+		customTransport := &http.Transport{
 			Proxy: http.ProxyFromEnvironment,
 			DialContext: (&net.Dialer{
 				Timeout:   30 * time.Second,
@@ -144,6 +250,12 @@ func CustomizeTLSTransport(fedCluster *fedv1b1.KubeFedCluster, clientConfig *res
 			ExpectContinueTimeout: 1 * time.Second,
 			TLSClientConfig:       transportConfig,
 		}
+		if clientConfig.Dial != nil {
+			// The cluster is accessed through its tunnel.
+			customTransport.Proxy = nil
+			customTransport.DialContext = clientConfig.Dial
+		}
+		clientConfig.Transport = customTransport
 		clientConfig.TLSClientConfig = restclient.TLSClientConfig{}
 	} else {
 		clientConfig.Insecure = true
@@ -0,0 +1,66 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"testing"
+
+	fedv1b1 "sigs.k8s.io/kubefed/pkg/apis/core/v1beta1"
+)
+
+func TestExecProvider(t *testing.T) {
+	allowedCommands := []string{"/usr/local/bin/aws"}
+
+	testCases := map[string]struct {
+		command       string
+		allowed       []string
+		expectedError bool
+	}{
+		"Allowed command": {
+			command: "/usr/local/bin/aws",
+			allowed: allowedCommands,
+		},
+		"Command that is not allowed": {
+			command:       "/bin/sh",
+			allowed:       allowedCommands,
+			expectedError: true,
+		},
+		"Exec credentials are not enabled": {
+			command:       "/usr/local/bin/aws",
+			expectedError: true,
+		},
+	}
+
+	for testName, tc := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			execConfig := &fedv1b1.ExecConfig{Command: tc.command, APIVersion: "client.authentication.k8s.io/v1"}
+			provider, err := execProvider(execConfig, tc.allowed)
+			if tc.expectedError {
+				if err == nil {
+					t.Errorf("Expected an error, got provider %v", provider)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if provider.Command != tc.command {
+				t.Errorf("Expected command %q, got %q", tc.command, provider.Command)
+			}
+		})
+	}
+}
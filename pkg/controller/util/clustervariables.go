@@ -0,0 +1,263 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	fedv1b1 "sigs.k8s.io/kubefed/pkg/apis/core/v1beta1"
+)
+
+const (
+	// ClusterVariablesAnnotation enables the substitution of cluster
+	// variables in the objects propagated for a federated resource if
+	// set to "true".
+	ClusterVariablesAnnotation = "kubefed.io/cluster-variables"
+
+	variablePrefix = "${"
+	variableSuffix = "}"
+	// An escaped prefix is substituted by a literal prefix.
+	escapedVariablePrefix = "$${"
+)
+
+// SubstitutionError indicates that the cluster variables in an object
+// could not be substituted.
+type SubstitutionError struct {
+	err error
+}
+
+func (e *SubstitutionError) Error() string {
+	return e.err.Error()
+}
+
+// ClusterVariablesEnabled returns whether the substitution of cluster
+// variables is enabled for the given federated resource.
+func ClusterVariablesEnabled(fedObject *unstructured.Unstructured) bool {
+	return fedObject.GetAnnotations()[ClusterVariablesAnnotation] == "true"
+}
+
+// SubstituteClusterVariables substitutes the cluster variables in the
+// string values of the given object with their values for the given
+// cluster. The name, namespace and kind of the object are not
+// substituted. A *SubstitutionError is returned if a variable is
+// malformed or has no value for the cluster, or if the cluster is nil.
+func SubstituteClusterVariables(obj *unstructured.Unstructured, cluster *fedv1b1.KubeFedCluster) error {
+	if cluster == nil {
+		return &SubstitutionError{err: errors.New("the cluster is not known")}
+	}
+	for key, value := range obj.Object {
+		if key == "apiVersion" || key == "kind" {
+			continue
+		}
+		if key == MetadataField {
+			metadata, ok := value.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			for metaKey, metaValue := range metadata {
+				if metaKey == "name" || metaKey == "namespace" || metaKey == "generateName" {
+					continue
+				}
+				substituted, err := substituteValue(metaValue, cluster)
+				if err != nil {
+					return &SubstitutionError{err: errors.Wrapf(err, "%s.%s", MetadataField, metaKey)}
+				}
+				metadata[metaKey] = substituted
+			}
+			continue
+		}
+		substituted, err := substituteValue(value, cluster)
+		if err != nil {
+			return &SubstitutionError{err: errors.Wrap(err, key)}
+		}
+		obj.Object[key] = substituted
+	}
+	return nil
+}
+
+// ClusterVariableValues returns the values for the given cluster of
+// the cluster variables in the string values of the given object,
+// keyed by the variable expression. A variable without a value for the
+// cluster is reported by an empty value.
+func ClusterVariableValues(obj map[string]interface{}, cluster *fedv1b1.KubeFedCluster) map[string]string {
+	values := make(map[string]string)
+	visitStrings(obj, func(s string) {
+		for _, expression := range variableExpressions(s) {
+			value, err := variableValue(expression, cluster)
+			if err != nil {
+				value = ""
+			}
+			values[expression] = value
+		}
+	})
+	return values
+}
+
+func substituteValue(value interface{}, cluster *fedv1b1.KubeFedCluster) (interface{}, error) {
+	switch typedValue := value.(type) {
+	case string:
+		return substituteString(typedValue, cluster)
+	case map[string]interface{}:
+		for key, elem := range typedValue {
+			substituted, err := substituteValue(elem, cluster)
+			if err != nil {
+				return nil, errors.Wrap(err, key)
+			}
+			typedValue[key] = substituted
+		}
+	case []interface{}:
+		for i, elem := range typedValue {
+			substituted, err := substituteValue(elem, cluster)
+			if err != nil {
+				return nil, errors.Wrapf(err, "[%d]", i)
+			}
+			typedValue[i] = substituted
+		}
+	}
+	return value, nil
+}
+
+// substituteString substitutes the variables of the form
+// ${expression} in the given string.
+func substituteString(s string, cluster *fedv1b1.KubeFedCluster) (string, error) {
+	if !strings.Contains(s, variablePrefix) {
+		return s, nil
+	}
+	var result strings.Builder
+	for {
+		escaped := strings.Index(s, escapedVariablePrefix)
+		start := strings.Index(s, variablePrefix)
+		if start < 0 {
+			result.WriteString(s)
+			return result.String(), nil
+		}
+		if escaped >= 0 && escaped < start {
+			result.WriteString(s[:escaped] + variablePrefix)
+			s = s[escaped+len(escapedVariablePrefix):]
+			continue
+		}
+		end := strings.Index(s[start:], variableSuffix)
+		if end < 0 {
+			return "", errors.Errorf("unterminated variable in %q", s)
+		}
+		expression := s[start+len(variablePrefix) : start+end]
+		value, err := variableValue(expression, cluster)
+		if err != nil {
+			return "", err
+		}
+		result.WriteString(s[:start] + value)
+		s = s[start+end+len(variableSuffix):]
+	}
+}
+
+// variableExpressions returns the expressions of the variables in the
+// given string, skipping escaped and unterminated variables.
+func variableExpressions(s string) []string {
+	var expressions []string
+	for {
+		escaped := strings.Index(s, escapedVariablePrefix)
+		start := strings.Index(s, variablePrefix)
+		if start < 0 {
+			return expressions
+		}
+		if escaped >= 0 && escaped < start {
+			s = s[escaped+len(escapedVariablePrefix):]
+			continue
+		}
+		end := strings.Index(s[start:], variableSuffix)
+		if end < 0 {
+			return expressions
+		}
+		expressions = append(expressions, s[start+len(variablePrefix):start+end])
+		s = s[start+end+len(variableSuffix):]
+	}
+}
+
+// variableValue returns the value of a variable expression for the
+// given cluster. The supported expressions are cluster.name,
+// cluster.region, cluster.zones (comma-separated),
+// cluster.labels['key'] and cluster.annotations['key'].
+func variableValue(expression string, cluster *fedv1b1.KubeFedCluster) (string, error) {
+	expression = strings.TrimSpace(expression)
+	switch expression {
+	case "cluster.name":
+		return cluster.Name, nil
+	case "cluster.region":
+		if cluster.Status.Region == nil || *cluster.Status.Region == "" {
+			return "", errors.Errorf("cluster %q has no region", cluster.Name)
+		}
+		return *cluster.Status.Region, nil
+	case "cluster.zones":
+		if len(cluster.Status.Zones) == 0 {
+			return "", errors.Errorf("cluster %q has no zones", cluster.Name)
+		}
+		return strings.Join(cluster.Status.Zones, ","), nil
+	}
+
+	for field, values := range map[string]map[string]string{
+		"labels":      cluster.Labels,
+		"annotations": cluster.Annotations,
+	} {
+		key, ok := mapKey(expression, "cluster."+field)
+		if !ok {
+			continue
+		}
+		value, ok := values[key]
+		if !ok {
+			return "", errors.Errorf("cluster %q has no %s %q", cluster.Name, strings.TrimSuffix(field, "s"), key)
+		}
+		return value, nil
+	}
+	return "", errors.Errorf("unknown variable %q", expression)
+}
+
+// mapKey returns the quoted key of an expression of the form
+// prefix['key'] or prefix["key"].
+func mapKey(expression, prefix string) (string, bool) {
+	rest, ok := strings.CutPrefix(expression, prefix+"[")
+	if !ok {
+		return "", false
+	}
+	rest, ok = strings.CutSuffix(rest, "]")
+	if !ok || len(rest) < 2 {
+		return "", false
+	}
+	quote := rest[0]
+	if (quote != '\'' && quote != '"') || rest[len(rest)-1] != quote {
+		return "", false
+	}
+	return rest[1 : len(rest)-1], true
+}
+
+func visitStrings(value interface{}, visit func(string)) {
+	switch typedValue := value.(type) {
+	case string:
+		visit(typedValue)
+	case map[string]interface{}:
+		for _, elem := range typedValue {
+			visitStrings(elem, visit)
+		}
+	case []interface{}:
+		for _, elem := range typedValue {
+			visitStrings(elem, visit)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	fedv1b1 "sigs.k8s.io/kubefed/pkg/apis/core/v1beta1"
+)
+
+func TestSubstituteClusterVariables(t *testing.T) {
+	region := "us-east1"
+	cluster := &fedv1b1.KubeFedCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "cluster1",
+			Labels:      map[string]string{"env": "prod"},
+			Annotations: map[string]string{"team": "payments"},
+		},
+		Status: fedv1b1.KubeFedClusterStatus{
+			Region: &region,
+			Zones:  []string{"us-east1-b", "us-east1-c"},
+		},
+	}
+
+	testCases := map[string]struct {
+		value       string
+		expected    string
+		expectedErr bool
+	}{
+		"string without variables is unchanged": {
+			value:    "app:1",
+			expected: "app:1",
+		},
+		"cluster name": {
+			value:    "${cluster.name}",
+			expected: "cluster1",
+		},
+		"cluster region and zones": {
+			value:    "${cluster.region}/${cluster.zones}",
+			expected: "us-east1/us-east1-b,us-east1-c",
+		},
+		"cluster label": {
+			value:    "app:${cluster.labels['env']}",
+			expected: "app:prod",
+		},
+		"cluster annotation with double quotes": {
+			value:    `${ cluster.annotations["team"] }`,
+			expected: "payments",
+		},
+		"escaped variable is substituted by a literal": {
+			value:    "$${cluster.name}-${cluster.name}",
+			expected: "${cluster.name}-cluster1",
+		},
+		"missing label": {
+			value:       "${cluster.labels['tier']}",
+			expectedErr: true,
+		},
+		"unknown variable": {
+			value:       "${cluster.uid}",
+			expectedErr: true,
+		},
+		"unterminated variable": {
+			value:       "${cluster.name",
+			expectedErr: true,
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			obj := &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"kind": "ConfigMap",
+					MetadataField: map[string]interface{}{
+						"name": "${cluster.name}",
+					},
+					"data": map[string]interface{}{
+						"value": testCase.value,
+					},
+				},
+			}
+			err := SubstituteClusterVariables(obj, cluster)
+			if testCase.expectedErr {
+				var substitutionErr *SubstitutionError
+				if !errors.As(err, &substitutionErr) {
+					t.Fatalf("Expected a substitution error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			value, _, _ := unstructured.NestedString(obj.Object, "data", "value")
+			if value != testCase.expected {
+				t.Fatalf("Expected %q, got %q", testCase.expected, value)
+			}
+			if obj.GetName() != "${cluster.name}" {
+				t.Fatalf("Expected the name not to be substituted, got %q", obj.GetName())
+			}
+		})
+	}
+}
@@ -61,13 +61,25 @@ const (
 	PlacementField       = "placement"
 	ClusterSelectorField = "clusterSelector"
 	MatchLabelsField     = "matchLabels"
+	TolerationsField     = "tolerations"
 
 	// Override fields
-	OverridesField        = "overrides"
-	ClusterNameField      = "clusterName"
-	ClusterOverridesField = "clusterOverrides"
-	PathField             = "path"
-	ValueField            = "value"
+	OverridesField           = "overrides"
+	ClusterNameField         = "clusterName"
+	ClusterOverridesField    = "clusterOverrides"
+	StrategicMergePatchField = "strategicMergePatch"
+	MergePatchField          = "mergePatch"
+	PathField                = "path"
+	ValueField               = "value"
+
+	// Rollout fields
+	RolloutStrategyField = "rolloutStrategy"
+
+	// Dependency fields
+	DependsOnField = "dependsOn"
+
+	// Drift fields
+	DriftPolicyField = "driftPolicy"
 
 	// Cluster reference
 	ClustersField = "clusters"
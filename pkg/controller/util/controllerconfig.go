@@ -74,9 +74,16 @@ type ControllerConfig struct {
 	MinimizeLatency               bool
 	CacheSyncTimeout              time.Duration
 	MaxConcurrentSyncReconciles   int64
+	ClusterOperationTimeout       time.Duration
+	ClusterInitialBackoff         time.Duration
+	ClusterMaxBackoff             time.Duration
 	MaxConcurrentStatusReconciles int64
 	SkipAdoptingResources         bool
 	RawResourceStatusCollection   bool
+	CredentialRotationPeriod      time.Duration
+	// Executor shared by controllers for operations on resources in
+	// member clusters.
+	ClusterExecutor *ClusterExecutor
 }
 
 func (c *ControllerConfig) LimitedScope() bool {
@@ -0,0 +1,194 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// DriftPolicy determines how the sync controller handles a resource in
+// a member cluster that no longer matches its desired state even though
+// the federated resource has not changed since it was last propagated.
+type DriftPolicy string
+
+const (
+	// DriftPolicyRevert reverts drifted resources to their desired
+	// state (the default).
+	DriftPolicyRevert DriftPolicy = "Revert"
+	// DriftPolicyReport leaves drifted resources untouched and reports
+	// the drifted fields in the status of the federated resource.
+	DriftPolicyReport DriftPolicy = "Report"
+	// DriftPolicyIgnore leaves drifted resources untouched without
+	// reporting them.
+	DriftPolicyIgnore DriftPolicy = "Ignore"
+)
+
+// GetDriftPolicy returns the drift policy configured by the
+// spec.driftPolicy field of the given federated resource. If the field
+// is not set or has an unknown value, DriftPolicyRevert is returned.
+func GetDriftPolicy(obj *unstructured.Unstructured) DriftPolicy {
+	value, _, _ := unstructured.NestedString(obj.Object, SpecField, DriftPolicyField)
+	switch policy := DriftPolicy(value); policy {
+	case DriftPolicyReport, DriftPolicyIgnore:
+		return policy
+	default:
+		return DriftPolicyRevert
+	}
+}
+
+// DriftedPaths returns the sorted JSON pointer paths of the fields of
+// the desired object whose value differs from the cluster object. Only
+// fields set in the desired object are compared so that fields defaulted
+// or allocated in the member cluster are not considered drift. Metadata
+// other than labels and annotations, the last applied metadata
+// annotation and status are ignored.
+func DriftedPaths(desiredObj, clusterObj *unstructured.Unstructured) []string {
+	paths := []string{}
+	for key, desiredValue := range desiredObj.Object {
+		switch key {
+		case "apiVersion", "kind", StatusField:
+			continue
+		case MetadataField:
+			desiredMeta, ok := desiredValue.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			clusterMeta, _ := clusterObj.Object[MetadataField].(map[string]interface{})
+			for _, metaKey := range []string{"labels", "annotations"} {
+				value, ok := desiredMeta[metaKey]
+				if !ok {
+					continue
+				}
+				if annotations, ok := value.(map[string]interface{}); ok && metaKey == "annotations" {
+					// The last applied metadata only records which keys
+					// are owned by KubeFed and is not desired state.
+					value = withoutKey(annotations, LastAppliedMetadataAnnotation)
+				}
+				paths = appendDriftedPaths(paths, "/"+MetadataField+"/"+metaKey, value, clusterMeta[metaKey])
+			}
+		default:
+			paths = appendDriftedPaths(paths, "/"+escapeJSONPointer(key), desiredValue, clusterObj.Object[key])
+		}
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+func withoutKey(value map[string]interface{}, key string) map[string]interface{} {
+	if _, ok := value[key]; !ok {
+		return value
+	}
+	result := make(map[string]interface{}, len(value))
+	for k, v := range value {
+		if k != key {
+			result[k] = v
+		}
+	}
+	return result
+}
+
+func appendDriftedPaths(paths []string, path string, desired, actual interface{}) []string {
+	switch desiredValue := desired.(type) {
+	case nil:
+		// A null value may be dropped by the member cluster.
+		return paths
+	case map[string]interface{}:
+		actualValue, ok := actual.(map[string]interface{})
+		if !ok && actual != nil {
+			return append(paths, path)
+		}
+		for key, value := range desiredValue {
+			paths = appendDriftedPaths(paths, path+"/"+escapeJSONPointer(key), value, actualValue[key])
+		}
+		return paths
+	case []interface{}:
+		actualValue, ok := actual.([]interface{})
+		if !ok {
+			if len(desiredValue) == 0 && actual == nil {
+				return paths
+			}
+			return append(paths, path)
+		}
+		if len(desiredValue) != len(actualValue) {
+			return append(paths, path)
+		}
+		for i := range desiredValue {
+			paths = appendDriftedPaths(paths, fmt.Sprintf("%s/%d", path, i), desiredValue[i], actualValue[i])
+		}
+		return paths
+	default:
+		if !scalarsEquivalent(desired, actual) {
+			return append(paths, path)
+		}
+		return paths
+	}
+}
+
+// scalarsEquivalent compares scalar values while tolerating the
+// normalization performed by the API server on numbers and quantities.
+func scalarsEquivalent(desired, actual interface{}) bool {
+	if reflect.DeepEqual(desired, actual) {
+		return true
+	}
+	if desiredNumber, ok := toFloat64(desired); ok {
+		actualNumber, ok := toFloat64(actual)
+		return ok && desiredNumber == actualNumber
+	}
+	desiredString, ok := desired.(string)
+	if !ok {
+		return false
+	}
+	actualString, ok := actual.(string)
+	if !ok {
+		return false
+	}
+	desiredQuantity, err := resource.ParseQuantity(desiredString)
+	if err != nil {
+		return false
+	}
+	actualQuantity, err := resource.ParseQuantity(actualString)
+	if err != nil {
+		return false
+	}
+	return desiredQuantity.Cmp(actualQuantity) == 0
+}
+
+func toFloat64(value interface{}) (float64, bool) {
+	switch number := value.(type) {
+	case int64:
+		return float64(number), true
+	case int32:
+		return float64(number), true
+	case int:
+		return float64(number), true
+	case float64:
+		return number, true
+	}
+	return 0, false
+}
+
+// escapeJSONPointer escapes a key for use as a JSON pointer token as per
+// RFC 6901.
+func escapeJSONPointer(key string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(key, "~", "~0"), "/", "~1")
+}
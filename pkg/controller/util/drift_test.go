@@ -0,0 +1,168 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestDriftedPaths(t *testing.T) {
+	desiredObj := func() *unstructured.Unstructured {
+		return &unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": "apps/v1",
+				"kind":       "Deployment",
+				"metadata": map[string]interface{}{
+					"name": "foo",
+					"labels": map[string]interface{}{
+						"app.kubernetes.io/name": "foo",
+					},
+					"annotations": map[string]interface{}{
+						LastAppliedMetadataAnnotation: `{"labels":["app.kubernetes.io/name"]}`,
+					},
+				},
+				"spec": map[string]interface{}{
+					"replicas": int64(2),
+					"template": map[string]interface{}{
+						"spec": map[string]interface{}{
+							"containers": []interface{}{
+								map[string]interface{}{
+									"name":  "foo",
+									"image": "foo:v1",
+									"resources": map[string]interface{}{
+										"limits": map[string]interface{}{
+											"cpu": "0.5",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	testCases := map[string]struct {
+		mutate        func(clusterObj *unstructured.Unstructured)
+		expectedPaths []string
+	}{
+		"Identical objects have not drifted": {
+			mutate:        func(clusterObj *unstructured.Unstructured) {},
+			expectedPaths: []string{},
+		},
+		"Fields defaulted in the cluster are not drift": {
+			mutate: func(clusterObj *unstructured.Unstructured) {
+				_ = unstructured.SetNestedField(clusterObj.Object, "RollingUpdate", "spec", "strategy", "type")
+				_ = unstructured.SetNestedField(clusterObj.Object, "42", "metadata", "resourceVersion")
+				_ = unstructured.SetNestedField(clusterObj.Object, int64(2), "status", "replicas")
+			},
+			expectedPaths: []string{},
+		},
+		"Normalized quantities are not drift": {
+			mutate: func(clusterObj *unstructured.Unstructured) {
+				containers, _, _ := unstructured.NestedSlice(clusterObj.Object, "spec", "template", "spec", "containers")
+				_ = unstructured.SetNestedField(containers[0].(map[string]interface{}), "500m", "resources", "limits", "cpu")
+				_ = unstructured.SetNestedSlice(clusterObj.Object, containers, "spec", "template", "spec", "containers")
+			},
+			expectedPaths: []string{},
+		},
+		"Changed fields are drift": {
+			mutate: func(clusterObj *unstructured.Unstructured) {
+				_ = unstructured.SetNestedField(clusterObj.Object, int64(3), "spec", "replicas")
+				containers, _, _ := unstructured.NestedSlice(clusterObj.Object, "spec", "template", "spec", "containers")
+				containers[0].(map[string]interface{})["image"] = "foo:v2"
+				_ = unstructured.SetNestedSlice(clusterObj.Object, containers, "spec", "template", "spec", "containers")
+			},
+			expectedPaths: []string{
+				"/spec/replicas",
+				"/spec/template/spec/containers/0/image",
+			},
+		},
+		"Changed list length is drift": {
+			mutate: func(clusterObj *unstructured.Unstructured) {
+				containers, _, _ := unstructured.NestedSlice(clusterObj.Object, "spec", "template", "spec", "containers")
+				containers = append(containers, map[string]interface{}{"name": "bar"})
+				_ = unstructured.SetNestedSlice(clusterObj.Object, containers, "spec", "template", "spec", "containers")
+			},
+			expectedPaths: []string{
+				"/spec/template/spec/containers",
+			},
+		},
+		"Removed label is drift": {
+			mutate: func(clusterObj *unstructured.Unstructured) {
+				clusterObj.SetLabels(nil)
+			},
+			expectedPaths: []string{
+				"/metadata/labels/app.kubernetes.io~1name",
+			},
+		},
+		"Missing last applied metadata is not drift": {
+			mutate: func(clusterObj *unstructured.Unstructured) {
+				clusterObj.SetAnnotations(nil)
+			},
+			expectedPaths: []string{},
+		},
+	}
+
+	for testName, tc := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			clusterObj := desiredObj()
+			tc.mutate(clusterObj)
+			assert.Equal(t, tc.expectedPaths, DriftedPaths(desiredObj(), clusterObj))
+		})
+	}
+}
+
+func TestGetDriftPolicy(t *testing.T) {
+	testCases := map[string]struct {
+		policy         string
+		expectedPolicy DriftPolicy
+	}{
+		"Missing policy defaults to Revert": {
+			expectedPolicy: DriftPolicyRevert,
+		},
+		"Unknown policy defaults to Revert": {
+			policy:         "Unknown",
+			expectedPolicy: DriftPolicyRevert,
+		},
+		"Report policy": {
+			policy:         string(DriftPolicyReport),
+			expectedPolicy: DriftPolicyReport,
+		},
+		"Ignore policy": {
+			policy:         string(DriftPolicyIgnore),
+			expectedPolicy: DriftPolicyIgnore,
+		},
+	}
+
+	for testName, tc := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			obj := &unstructured.Unstructured{Object: map[string]interface{}{}}
+			if tc.policy != "" {
+				err := unstructured.SetNestedField(obj.Object, tc.policy, SpecField, DriftPolicyField)
+				if err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+			}
+			assert.Equal(t, tc.expectedPolicy, GetDriftPolicy(obj))
+		})
+	}
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"sync"
+	"time"
+
+	"sigs.k8s.io/kubefed/pkg/metrics"
+)
+
+const (
+	DefaultMaxConcurrentClusterOperations    = 100
+	DefaultMaxConcurrentOperationsPerCluster = 10
+)
+
+// ClusterExecutor runs operations against member clusters with bounded
+// concurrency. At most maxConcurrent operations run at any one time,
+// and at most maxConcurrentPerCluster of those target the same
+// cluster. Operations that cannot run immediately are queued per
+// cluster and clusters with queued operations are served in turn, so
+// that a slow cluster does not delay operations for other clusters.
+// A goroutine is only started for an operation once it can run.
+type ClusterExecutor struct {
+	sync.Mutex
+
+	maxConcurrent           int
+	maxConcurrentPerCluster int
+
+	running  int
+	clusters map[string]*clusterExecutorQueue
+
+	// Clusters with queued operations in the order they are to be
+	// served.
+	pending []string
+}
+
+type clusterExecutorQueue struct {
+	running    int
+	operations []queuedOperation
+}
+
+type queuedOperation struct {
+	run      func()
+	queuedAt time.Time
+}
+
+// NewClusterExecutor returns an executor with the given limits. A
+// limit that is not greater than zero is replaced by its default.
+func NewClusterExecutor(maxConcurrent, maxConcurrentPerCluster int) *ClusterExecutor {
+	if maxConcurrent <= 0 {
+		maxConcurrent = DefaultMaxConcurrentClusterOperations
+	}
+	if maxConcurrentPerCluster <= 0 {
+		maxConcurrentPerCluster = DefaultMaxConcurrentOperationsPerCluster
+	}
+	return &ClusterExecutor{
+		maxConcurrent:           maxConcurrent,
+		maxConcurrentPerCluster: maxConcurrentPerCluster,
+		clusters:                make(map[string]*clusterExecutorQueue),
+	}
+}
+
+// Execute runs the given operation against the named cluster once the
+// limits of the executor allow. It does not wait for the operation to
+// run.
+func (e *ClusterExecutor) Execute(clusterName string, operation func()) {
+	e.Lock()
+	defer e.Unlock()
+
+	queue, ok := e.clusters[clusterName]
+	if !ok {
+		queue = &clusterExecutorQueue{}
+		e.clusters[clusterName] = queue
+	}
+	if len(queue.operations) == 0 {
+		e.pending = append(e.pending, clusterName)
+	}
+	queue.operations = append(queue.operations, queuedOperation{run: operation, queuedAt: time.Now()})
+	metrics.ClusterOperationsQueuedInc(clusterName)
+
+	e.startOperations()
+}
+
+// startOperations starts queued operations until a limit is reached.
+// Must be called with the lock held.
+func (e *ClusterExecutor) startOperations() {
+	for e.running < e.maxConcurrent {
+		clusterName, ok := e.nextCluster()
+		if !ok {
+			return
+		}
+		queue := e.clusters[clusterName]
+		operation := queue.operations[0]
+		queue.operations[0] = queuedOperation{}
+		queue.operations = queue.operations[1:]
+		if len(queue.operations) > 0 {
+			// Serve other clusters before this one again.
+			e.pending = append(e.pending, clusterName)
+		}
+		queue.running++
+		e.running++
+		metrics.ClusterOperationsQueuedDec(clusterName)
+		metrics.ClusterOperationsRunningInc(clusterName)
+		metrics.ClusterOperationQueueDurationFromStart(operation.queuedAt)
+		go e.run(clusterName, operation.run)
+	}
+}
+
+// nextCluster removes and returns the first pending cluster that is
+// below its concurrency limit. Must be called with the lock held.
+func (e *ClusterExecutor) nextCluster() (string, bool) {
+	for i, clusterName := range e.pending {
+		if e.clusters[clusterName].running < e.maxConcurrentPerCluster {
+			e.pending = append(e.pending[:i], e.pending[i+1:]...)
+			return clusterName, true
+		}
+	}
+	return "", false
+}
+
+func (e *ClusterExecutor) run(clusterName string, operation func()) {
+	defer func() {
+		e.Lock()
+		defer e.Unlock()
+		queue := e.clusters[clusterName]
+		queue.running--
+		e.running--
+		metrics.ClusterOperationsRunningDec(clusterName)
+		if queue.running == 0 && len(queue.operations) == 0 {
+			delete(e.clusters, clusterName)
+		}
+		e.startOperations()
+	}()
+	operation()
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+func TestClusterExecutor(t *testing.T) {
+	testCases := map[string]struct {
+		maxConcurrent           int
+		maxConcurrentPerCluster int
+		// Number of operations queued per cluster.
+		operations      map[string]int
+		expectedRunning map[string]int
+	}{
+		"Operations are limited per cluster": {
+			maxConcurrent:           10,
+			maxConcurrentPerCluster: 2,
+			operations:              map[string]int{"cluster1": 5, "cluster2": 1},
+			expectedRunning:         map[string]int{"cluster1": 2, "cluster2": 1},
+		},
+		"Operations are limited globally and shared between clusters": {
+			maxConcurrent:           4,
+			maxConcurrentPerCluster: 10,
+			operations:              map[string]int{"cluster1": 10, "cluster2": 10},
+			expectedRunning:         map[string]int{"cluster1": 2, "cluster2": 2},
+		},
+	}
+
+	for testName, tc := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			executor := NewClusterExecutor(tc.maxConcurrent, tc.maxConcurrentPerCluster)
+			release := make(chan struct{})
+			started := make(chan string, 100)
+			var completed sync.WaitGroup
+
+			// Interleave the operations of clusters as a storm of
+			// reconciles would.
+			for i := 0; i < 10; i++ {
+				for _, clusterName := range []string{"cluster1", "cluster2"} {
+					if i >= tc.operations[clusterName] {
+						continue
+					}
+					clusterName := clusterName
+					completed.Add(1)
+					executor.Execute(clusterName, func() {
+						defer completed.Done()
+						started <- clusterName
+						<-release
+					})
+				}
+			}
+
+			running := map[string]int{}
+			expectedTotal := 0
+			for _, count := range tc.expectedRunning {
+				expectedTotal += count
+			}
+			for i := 0; i < expectedTotal; i++ {
+				select {
+				case clusterName := <-started:
+					running[clusterName]++
+				case <-time.After(wait.ForeverTestTimeout):
+					require.FailNow(t, "operations did not start")
+				}
+			}
+			select {
+			case clusterName := <-started:
+				assert.Failf(t, "operation exceeded limits", "unexpected operation started for %s", clusterName)
+			case <-time.After(100 * time.Millisecond):
+			}
+			assert.Equal(t, tc.expectedRunning, running)
+
+			// All queued operations run once running ones complete.
+			close(release)
+			completed.Wait()
+			assert.Eventually(t, func() bool {
+				executor.Lock()
+				defer executor.Unlock()
+				return executor.running == 0 && len(executor.clusters) == 0
+			}, wait.ForeverTestTimeout, 10*time.Millisecond)
+		})
+	}
+}
@@ -89,6 +89,15 @@ type RegisteredClustersView interface {
 	// GetClusters returns a list of all clusters.
 	GetClusters() ([]*fedv1b1.KubeFedCluster, error)
 
+	// GetPullClusters returns a list of all pull-mode clusters. Pull-mode
+	// clusters are excluded from the results of the other methods since
+	// they are not accessed by the control plane.
+	GetPullClusters() ([]*fedv1b1.KubeFedCluster, error)
+
+	// GetPlacementClusters returns the push-mode and pull-mode
+	// clusters that placement is computed for.
+	GetPlacementClusters() ([]*fedv1b1.KubeFedCluster, error)
+
 	// GetReadyCluster returns the cluster with the given name, if found.
 	GetReadyCluster(name string) (*fedv1b1.KubeFedCluster, bool, error)
 
@@ -132,6 +141,9 @@ type ClusterLifecycleHandlerFuncs struct {
 	// Fired when the cluster becomes unavailable. The second arg contains data that was present
 	// in the cluster before deletion.
 	ClusterUnavailable func(*fedv1b1.KubeFedCluster, []interface{})
+	// Fired when the region or zones of the cluster change without
+	// its availability changing.
+	ClusterTopologyChanged func(*fedv1b1.KubeFedCluster)
 }
 
 // Builds a FederatedInformer for the given configuration.
@@ -142,7 +154,11 @@ func NewFederatedInformer(
 	triggerFunc func(runtimeclient.Object),
 	clusterLifecycle *ClusterLifecycleHandlerFuncs) (FederatedInformer, error) {
 	targetInformerFactory := func(cluster *fedv1b1.KubeFedCluster, clusterConfig *restclient.Config) (cache.Store, cache.Controller, error) {
-		resourceClient, err := NewResourceClient(clusterConfig, apiResource)
+		// The request timeout of the cluster would otherwise terminate
+		// long-running watches.
+		watchConfig := restclient.CopyConfig(clusterConfig)
+		watchConfig.Timeout = 0
+		resourceClient, err := NewResourceClient(watchConfig, apiResource)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -169,15 +185,6 @@ func NewFederatedInformer(
 		clusterClients:  make(map[string]generic.Client),
 	}
 
-	getClusterData := func(name string) []interface{} {
-		data, err := federatedInformer.GetTargetStore().ListFromCluster(name)
-		if err != nil {
-			klog.Errorf("Failed to list %s content: %v", name, err)
-			return make([]interface{}, 0)
-		}
-		return data
-	}
-
 	var err error
 	federatedInformer.clusterInformer.store, federatedInformer.clusterInformer.controller, err = NewGenericInformerWithEventHandler(
 		config.KubeConfig,
@@ -190,7 +197,7 @@ func NewFederatedInformer(
 				if ok {
 					var data []interface{}
 					if clusterLifecycle.ClusterUnavailable != nil {
-						data = getClusterData(oldCluster.Name)
+						data = federatedInformer.clusterData(oldCluster.Name)
 					}
 					federatedInformer.deleteCluster(oldCluster)
 					if clusterLifecycle.ClusterUnavailable != nil {
@@ -203,6 +210,8 @@ func NewFederatedInformer(
 				switch {
 				case !ok:
 					klog.Errorf("Cluster %v/%v not added; incorrect type", curCluster.Namespace, curCluster.Name)
+				case IsPullModeCluster(curCluster):
+					klog.V(4).Infof("Cluster %v/%v not added; it is in pull mode.", curCluster.Namespace, curCluster.Name)
 				case IsClusterReady(&curCluster.Status):
 					federatedInformer.addCluster(curCluster)
 					klog.Infof("Cluster %v/%v is ready", curCluster.Namespace, curCluster.Name)
@@ -224,31 +233,21 @@ func NewFederatedInformer(
 					klog.Errorf("Internal error: Cluster %v not updated. New cluster not of correct type.", cur)
 					return
 				}
-				if IsClusterReady(&oldCluster.Status) != IsClusterReady(&curCluster.Status) || !reflect.DeepEqual(oldCluster.Spec, curCluster.Spec) || !reflect.DeepEqual(oldCluster.ObjectMeta.Labels, curCluster.ObjectMeta.Labels) || !reflect.DeepEqual(oldCluster.ObjectMeta.Annotations, curCluster.ObjectMeta.Annotations) {
-					var data []interface{}
-					if clusterLifecycle.ClusterUnavailable != nil {
-						data = getClusterData(oldCluster.Name)
-					}
-					federatedInformer.deleteCluster(oldCluster)
-					if clusterLifecycle.ClusterUnavailable != nil {
-						clusterLifecycle.ClusterUnavailable(oldCluster, data)
-					}
-
-					if IsClusterReady(&curCluster.Status) {
-						federatedInformer.addCluster(curCluster)
-						if clusterLifecycle.ClusterAvailable != nil {
-							clusterLifecycle.ClusterAvailable(curCluster)
-						}
-					}
-				} else {
-					klog.V(7).Infof("Cluster %v not updated to %v as ready status and specs are identical", oldCluster, curCluster)
-				}
+				federatedInformer.updateCluster(oldCluster, curCluster, clusterLifecycle)
 			},
 		},
 	)
 	return federatedInformer, err
 }
 
+// clusterTopologyChanged returns whether the region or zones reported
+// in the status of a cluster changed, which the placement of federated
+// resources depends on.
+func clusterTopologyChanged(oldCluster, curCluster *fedv1b1.KubeFedCluster) bool {
+	return !reflect.DeepEqual(oldCluster.Status.Region, curCluster.Status.Region) ||
+		!reflect.DeepEqual(oldCluster.Status.Zones, curCluster.Status.Zones)
+}
+
 func IsClusterReady(clusterStatus *fedv1b1.KubeFedClusterStatus) bool {
 	for _, condition := range clusterStatus.Conditions {
 		if condition.Type == fedcommon.ClusterReady {
@@ -362,7 +361,7 @@ func (f *federatedInformerImpl) GetUnreadyClusters() ([]*fedv1b1.KubeFedCluster,
 	result := make([]*fedv1b1.KubeFedCluster, 0, len(items))
 	for _, item := range items {
 		if cluster, ok := item.(*fedv1b1.KubeFedCluster); ok {
-			if !IsClusterReady(&cluster.Status) {
+			if !IsClusterReady(&cluster.Status) && !IsPullModeCluster(cluster) {
 				result = append(result, cluster)
 			}
 		} else {
@@ -382,6 +381,33 @@ func (f *federatedInformerImpl) GetClusters() ([]*fedv1b1.KubeFedCluster, error)
 	return f.getClusters(false)
 }
 
+// GetPullClusters returns all pull-mode clusters regardless of ready state.
+func (f *federatedInformerImpl) GetPullClusters() ([]*fedv1b1.KubeFedCluster, error) {
+	f.Lock()
+	defer f.Unlock()
+
+	items := f.clusterInformer.store.List()
+	result := make([]*fedv1b1.KubeFedCluster, 0, len(items))
+	for _, item := range items {
+		if cluster, ok := item.(*fedv1b1.KubeFedCluster); ok {
+			if IsPullModeCluster(cluster) {
+				result = append(result, cluster)
+			}
+		} else {
+			return nil, errors.Errorf("wrong data in FederatedInformerImpl cluster store: %v", item)
+		}
+	}
+	return result, nil
+}
+
+// GetPlacementClusters returns the clusters that placement is computed
+// for, as determined by PlacementClusters.
+func (f *federatedInformerImpl) GetPlacementClusters() ([]*fedv1b1.KubeFedCluster, error) {
+	f.Lock()
+	defer f.Unlock()
+	return PlacementClusters(f.clusterInformer.store.List())
+}
+
 // GetReadyClusters returns only ready clusters if onlyReady is true and all clusters otherwise.
 func (f *federatedInformerImpl) getClusters(onlyReady bool) ([]*fedv1b1.KubeFedCluster, error) {
 	f.Lock()
@@ -391,6 +417,9 @@ func (f *federatedInformerImpl) getClusters(onlyReady bool) ([]*fedv1b1.KubeFedC
 	result := make([]*fedv1b1.KubeFedCluster, 0, len(items))
 	for _, item := range items {
 		if cluster, ok := item.(*fedv1b1.KubeFedCluster); ok {
+			if IsPullModeCluster(cluster) {
+				continue
+			}
 			if !onlyReady || IsClusterReady(&cluster.Status) {
 				result = append(result, cluster)
 			}
@@ -412,7 +441,7 @@ func (f *federatedInformerImpl) getReadyClusterUnlocked(name string) (*fedv1b1.K
 	key := fmt.Sprintf("%s/%s", f.fedNamespace, name)
 	if obj, exist, err := f.clusterInformer.store.GetByKey(key); exist && err == nil {
 		if cluster, ok := obj.(*fedv1b1.KubeFedCluster); ok {
-			if IsClusterReady(&cluster.Status) {
+			if IsClusterReady(&cluster.Status) && !IsPullModeCluster(cluster) {
 				return cluster, true, nil
 			}
 			return nil, false, nil
@@ -453,6 +482,45 @@ func (f *federatedInformerImpl) addCluster(cluster *fedv1b1.KubeFedCluster) {
 	}
 }
 
+// updateCluster handles an update of a cluster. The informer of a
+// cluster is recreated when its readiness, spec or metadata change.
+func (f *federatedInformerImpl) updateCluster(oldCluster, curCluster *fedv1b1.KubeFedCluster, clusterLifecycle *ClusterLifecycleHandlerFuncs) {
+	if IsClusterReady(&oldCluster.Status) != IsClusterReady(&curCluster.Status) || !reflect.DeepEqual(oldCluster.Spec, curCluster.Spec) || !reflect.DeepEqual(oldCluster.ObjectMeta.Labels, curCluster.ObjectMeta.Labels) || !reflect.DeepEqual(oldCluster.ObjectMeta.Annotations, curCluster.ObjectMeta.Annotations) {
+		var data []interface{}
+		if clusterLifecycle.ClusterUnavailable != nil {
+			data = f.clusterData(oldCluster.Name)
+		}
+		f.deleteCluster(oldCluster)
+		if clusterLifecycle.ClusterUnavailable != nil {
+			clusterLifecycle.ClusterUnavailable(oldCluster, data)
+		}
+
+		if IsClusterReady(&curCluster.Status) && !IsPullModeCluster(curCluster) {
+			f.addCluster(curCluster)
+			if clusterLifecycle.ClusterAvailable != nil {
+				clusterLifecycle.ClusterAvailable(curCluster)
+			}
+		}
+	} else if clusterTopologyChanged(oldCluster, curCluster) {
+		if clusterLifecycle.ClusterTopologyChanged != nil {
+			clusterLifecycle.ClusterTopologyChanged(curCluster)
+		}
+	} else {
+		klog.V(7).Infof("Cluster %v not updated to %v as ready status and specs are identical", oldCluster, curCluster)
+	}
+}
+
+// clusterData returns the objects of the named cluster in the target
+// store.
+func (f *federatedInformerImpl) clusterData(name string) []interface{} {
+	data, err := f.GetTargetStore().ListFromCluster(name)
+	if err != nil {
+		klog.Errorf("Failed to list %s content: %v", name, err)
+		return make([]interface{}, 0)
+	}
+	return data
+}
+
 // Removes the cluster from federated informer.
 func (f *federatedInformerImpl) deleteCluster(cluster *fedv1b1.KubeFedCluster) {
 	f.Lock()
@@ -0,0 +1,107 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	fedv1b1 "sigs.k8s.io/kubefed/pkg/apis/core/v1beta1"
+	"sigs.k8s.io/kubefed/pkg/client/generic"
+)
+
+func TestUpdateCluster(t *testing.T) {
+	region := func(name string) *string { return &name }
+	newCluster := func(ready bool, region *string, zones ...string) *fedv1b1.KubeFedCluster {
+		cluster := newReadyCluster("cluster1")
+		if !ready {
+			cluster.Status.Conditions = nil
+		}
+		cluster.Status.Region = region
+		cluster.Status.Zones = zones
+		return cluster
+	}
+
+	testCases := map[string]struct {
+		oldCluster          *fedv1b1.KubeFedCluster
+		curCluster          *fedv1b1.KubeFedCluster
+		expectUnavailable   bool
+		expectTopologyEvent bool
+	}{
+		"Unchanged cluster fires no event": {
+			oldCluster: newCluster(true, region("us-east1"), "us-east1-b"),
+			curCluster: newCluster(true, region("us-east1"), "us-east1-b"),
+		},
+		"Heartbeat fires no event": {
+			oldCluster: newCluster(true, region("us-east1"), "us-east1-b"),
+			curCluster: func() *fedv1b1.KubeFedCluster {
+				cluster := newCluster(true, region("us-east1"), "us-east1-b")
+				cluster.Status.Conditions[0].LastProbeTime = metav1.Now()
+				return cluster
+			}(),
+		},
+		"Changed region fires a topology event": {
+			oldCluster:          newCluster(true, region("us-east1"), "us-east1-b"),
+			curCluster:          newCluster(true, region("us-west1"), "us-east1-b"),
+			expectTopologyEvent: true,
+		},
+		"Reported region fires a topology event": {
+			oldCluster:          newCluster(true, nil),
+			curCluster:          newCluster(true, region("us-east1")),
+			expectTopologyEvent: true,
+		},
+		"Changed zones fire a topology event": {
+			oldCluster:          newCluster(true, region("us-east1"), "us-east1-b"),
+			curCluster:          newCluster(true, region("us-east1"), "us-east1-b", "us-east1-c"),
+			expectTopologyEvent: true,
+		},
+		"Cluster becoming unavailable fires an unavailable event only": {
+			oldCluster:        newCluster(true, region("us-east1")),
+			curCluster:        newCluster(false, region("us-west1")),
+			expectUnavailable: true,
+		},
+	}
+
+	for testName, tc := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			federatedInformer := &federatedInformerImpl{
+				targetInformers: map[string]informer{
+					"cluster1": {store: cache.NewStore(cache.MetaNamespaceKeyFunc), stopChan: make(chan struct{})},
+				},
+				clusterClients: make(map[string]generic.Client),
+			}
+			unavailable, topologyChanged := false, false
+			federatedInformer.updateCluster(tc.oldCluster, tc.curCluster, &ClusterLifecycleHandlerFuncs{
+				ClusterAvailable: func(*fedv1b1.KubeFedCluster) {
+					t.Fatalf("Unexpected available event")
+				},
+				ClusterUnavailable: func(*fedv1b1.KubeFedCluster, []interface{}) {
+					unavailable = true
+				},
+				ClusterTopologyChanged: func(*fedv1b1.KubeFedCluster) {
+					topologyChanged = true
+				},
+			})
+			assert.Equal(t, tc.expectUnavailable, unavailable)
+			assert.Equal(t, tc.expectTopologyEvent, topologyChanged)
+		})
+	}
+}
@@ -18,13 +18,18 @@ package util
 
 import (
 	"encoding/json"
+	"fmt"
 
 	jsonpatch "github.com/evanphx/json-patch"
 	"github.com/pkg/errors"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"k8s.io/client-go/kubernetes/scheme"
 )
 
 type ClusterOverride struct {
@@ -33,9 +38,27 @@ type ClusterOverride struct {
 	Value interface{} `json:"value,omitempty"`
 }
 
+// GenericOverrideItem holds the overrides for either the named cluster
+// or the clusters matching a label selector.
 type GenericOverrideItem struct {
-	ClusterName      string            `json:"clusterName"`
-	ClusterOverrides []ClusterOverride `json:"clusterOverrides,omitempty"`
+	ClusterName      string                `json:"clusterName,omitempty"`
+	ClusterSelector  *metav1.LabelSelector `json:"clusterSelector,omitempty"`
+	ClusterOverrides []ClusterOverride     `json:"clusterOverrides,omitempty"`
+	MergePatches     `json:",inline"`
+}
+
+// MergePatches holds the merge patches of an override item, which are
+// applied after the JSON patches of all override items.
+type MergePatches struct {
+	// A strategic merge patch, applied as a JSON merge patch if the
+	// schema of the target type is not known.
+	StrategicMergePatch map[string]interface{} `json:"strategicMergePatch,omitempty"`
+	// A JSON merge patch (RFC 7386).
+	MergePatch map[string]interface{} `json:"mergePatch,omitempty"`
+}
+
+func (p MergePatches) empty() bool {
+	return p.StrategicMergePatch == nil && p.MergePatch == nil
 }
 
 type GenericOverrideSpec struct {
@@ -73,6 +96,14 @@ type ClusterOverrides []ClusterOverride
 // Mapping of clusterName to overrides for the cluster
 type OverridesMap map[string]ClusterOverrides
 
+// SelectorOverride holds the overrides for the clusters matching a
+// label selector.
+type SelectorOverride struct {
+	Selector         labels.Selector
+	ClusterOverrides ClusterOverrides
+	MergePatches     MergePatches
+}
+
 // ToUnstructuredSlice converts the map of overrides to a slice of
 // interfaces that can be set in an unstructured object.
 func (m OverridesMap) ToUnstructuredSlice() []interface{} {
@@ -108,23 +139,23 @@ func GetOverrides(rawObj *unstructured.Unstructured) (OverridesMap, error) {
 	}
 
 	for _, overrideItem := range genericFedObject.Spec.Overrides {
+		if overrideItem.ClusterSelector != nil {
+			if overrideItem.ClusterName != "" {
+				return nil, errors.Errorf("overrides for cluster %q may not also specify a cluster selector", overrideItem.ClusterName)
+			}
+			// Overrides targeting a cluster selector are returned by
+			// GetSelectorOverrides.
+			continue
+		}
+
 		clusterName := overrideItem.ClusterName
 		if _, ok := overridesMap[clusterName]; ok {
 			return nil, errors.Errorf("cluster %q appears more than once", clusterName)
 		}
 
 		clusterOverrides := overrideItem.ClusterOverrides
-
-		paths := sets.New[string]()
-		for i, clusterOverride := range clusterOverrides {
-			path := clusterOverride.Path
-			if invalidPaths.Has(path) {
-				return nil, errors.Errorf("override[%d] for cluster %q has an invalid path: %s", i, clusterName, path)
-			}
-			if paths.Has(path) {
-				return nil, errors.Errorf("path %q appears more than once for cluster %q", path, clusterName)
-			}
-			paths.Insert(path)
+		if err := validateClusterOverrides(clusterOverrides, fmt.Sprintf("cluster %q", clusterName)); err != nil {
+			return nil, err
 		}
 		overridesMap[clusterName] = clusterOverrides
 	}
@@ -132,6 +163,158 @@ func GetOverrides(rawObj *unstructured.Unstructured) (OverridesMap, error) {
 	return overridesMap, nil
 }
 
+// GetSelectorOverrides returns the overrides targeting clusters by
+// label selector populated from the given unstructured object, in the
+// order in which they are defined.
+func GetSelectorOverrides(rawObj *unstructured.Unstructured) ([]SelectorOverride, error) {
+	if rawObj == nil {
+		return nil, nil
+	}
+
+	genericFedObject := GenericOverride{}
+	err := UnstructuredToInterface(rawObj, &genericFedObject)
+	if err != nil {
+		return nil, err
+	}
+	if genericFedObject.Spec == nil {
+		return nil, nil
+	}
+
+	var selectorOverrides []SelectorOverride
+	for i, overrideItem := range genericFedObject.Spec.Overrides {
+		if overrideItem.ClusterSelector == nil {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(overrideItem.ClusterSelector)
+		if err != nil {
+			return nil, errors.Wrapf(err, "overrides[%d] has an invalid cluster selector", i)
+		}
+		clusterOverrides := overrideItem.ClusterOverrides
+		target := fmt.Sprintf("cluster selector %q", selector)
+		if err := validateClusterOverrides(clusterOverrides, target); err != nil {
+			return nil, err
+		}
+		if err := validateMergePatches(overrideItem.MergePatches, target); err != nil {
+			return nil, err
+		}
+		selectorOverrides = append(selectorOverrides, SelectorOverride{
+			Selector:         selector,
+			ClusterOverrides: clusterOverrides,
+			MergePatches:     overrideItem.MergePatches,
+		})
+	}
+	return selectorOverrides, nil
+}
+
+// GetMergePatches returns a map of the merge patches of the overrides
+// for cluster names populated from the given unstructured object.
+func GetMergePatches(rawObj *unstructured.Unstructured) (map[string]MergePatches, error) {
+	mergePatches := make(map[string]MergePatches)
+	if rawObj == nil {
+		return mergePatches, nil
+	}
+
+	genericFedObject := GenericOverride{}
+	err := UnstructuredToInterface(rawObj, &genericFedObject)
+	if err != nil {
+		return nil, err
+	}
+	if genericFedObject.Spec == nil {
+		return mergePatches, nil
+	}
+
+	for _, overrideItem := range genericFedObject.Spec.Overrides {
+		if overrideItem.ClusterSelector != nil || overrideItem.MergePatches.empty() {
+			continue
+		}
+		clusterName := overrideItem.ClusterName
+		if err := validateMergePatches(overrideItem.MergePatches, fmt.Sprintf("cluster %q", clusterName)); err != nil {
+			return nil, err
+		}
+		mergePatches[clusterName] = overrideItem.MergePatches
+	}
+	return mergePatches, nil
+}
+
+// validateMergePatches checks that the merge patches do not modify
+// the fields that may not be overridden.
+func validateMergePatches(mergePatches MergePatches, target string) error {
+	for patchField, patch := range map[string]map[string]interface{}{
+		StrategicMergePatchField: mergePatches.StrategicMergePatch,
+		MergePatchField:          mergePatches.MergePatch,
+	} {
+		if _, ok := patch["kind"]; ok {
+			return errors.Errorf("%s for %s may not modify /kind", patchField, target)
+		}
+		metadata, ok := patch[MetadataField].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for _, field := range []string{"namespace", "name", "generateName"} {
+			if _, ok := metadata[field]; ok {
+				return errors.Errorf("%s for %s may not modify /metadata/%s", patchField, target, field)
+			}
+		}
+	}
+	return nil
+}
+
+func validateClusterOverrides(clusterOverrides ClusterOverrides, target string) error {
+	paths := sets.New[string]()
+	for i, clusterOverride := range clusterOverrides {
+		path := clusterOverride.Path
+		if invalidPaths.Has(path) {
+			return errors.Errorf("override[%d] for %s has an invalid path: %s", i, target, path)
+		}
+		if paths.Has(path) {
+			return errors.Errorf("path %q appears more than once for %s", path, target)
+		}
+		paths.Insert(path)
+	}
+	return nil
+}
+
+// OverridesForCluster returns the JSON patches and merge patches for a
+// cluster with the given name and labels. The overrides targeting a
+// cluster selector matching the labels are applied first, in the order
+// in which they are defined, followed by the overrides for the cluster
+// name, which thereby take precedence. JSON patches for the same path
+// by more than one matching cluster selector conflict and result in an
+// error.
+func OverridesForCluster(clusterName string, clusterLabels labels.Set, overridesMap OverridesMap,
+	mergePatchesMap map[string]MergePatches, selectorOverrides []SelectorOverride) (ClusterOverrides, []MergePatches, error) {
+	var mergePatches []MergePatches
+	if len(selectorOverrides) == 0 {
+		if patches, ok := mergePatchesMap[clusterName]; ok {
+			mergePatches = append(mergePatches, patches)
+		}
+		return overridesMap[clusterName], mergePatches, nil
+	}
+
+	var clusterOverrides ClusterOverrides
+	selectorPaths := make(map[string]labels.Selector)
+	for _, selectorOverride := range selectorOverrides {
+		if !selectorOverride.Selector.Matches(clusterLabels) {
+			continue
+		}
+		for _, clusterOverride := range selectorOverride.ClusterOverrides {
+			if selector, ok := selectorPaths[clusterOverride.Path]; ok {
+				return nil, nil, errors.Errorf("path %q of cluster %q is overridden by both cluster selector %q and cluster selector %q",
+					clusterOverride.Path, clusterName, selector, selectorOverride.Selector)
+			}
+			selectorPaths[clusterOverride.Path] = selectorOverride.Selector
+		}
+		clusterOverrides = append(clusterOverrides, selectorOverride.ClusterOverrides...)
+		if !selectorOverride.MergePatches.empty() {
+			mergePatches = append(mergePatches, selectorOverride.MergePatches)
+		}
+	}
+	if patches, ok := mergePatchesMap[clusterName]; ok {
+		mergePatches = append(mergePatches, patches)
+	}
+	return append(clusterOverrides, overridesMap[clusterName]...), mergePatches, nil
+}
+
 // SetOverrides sets the spec.overrides field of the unstructured
 // object from the provided overrides map.
 func SetOverrides(fedObject *unstructured.Unstructured, overridesMap OverridesMap) error {
@@ -145,7 +328,41 @@ func SetOverrides(fedObject *unstructured.Unstructured, overridesMap OverridesMa
 	if !ok {
 		return errors.Errorf("Unable to set overrides since %q is not an object: %T", SpecField, rawSpec)
 	}
-	spec[OverridesField] = overridesMap.ToUnstructuredSlice()
+	// Overrides targeting a cluster selector and the merge patches of
+	// the overrides for cluster names are retained.
+	overrides := []interface{}{}
+	remaining := make(OverridesMap)
+	for clusterName, clusterOverrides := range overridesMap {
+		remaining[clusterName] = clusterOverrides
+	}
+	if rawOverrides, ok := spec[OverridesField].([]interface{}); ok {
+		for _, rawItem := range rawOverrides {
+			item, ok := rawItem.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if item[ClusterSelectorField] != nil {
+				overrides = append(overrides, item)
+				continue
+			}
+			if item[StrategicMergePatchField] == nil && item[MergePatchField] == nil {
+				continue
+			}
+			clusterName, _ := item[ClusterNameField].(string)
+			retained := map[string]interface{}{}
+			for key, value := range item {
+				retained[key] = value
+			}
+			if clusterOverrides, ok := remaining[clusterName]; ok {
+				retained[ClusterOverridesField] = clusterOverrides
+				delete(remaining, clusterName)
+			} else {
+				delete(retained, ClusterOverridesField)
+			}
+			overrides = append(overrides, retained)
+		}
+	}
+	spec[OverridesField] = append(overrides, remaining.ToUnstructuredSlice()...)
 	return nil
 }
 
@@ -159,6 +376,54 @@ func UnstructuredToInterface(rawObj *unstructured.Unstructured, obj interface{})
 	return json.Unmarshal(content, obj)
 }
 
+// ApplyMergePatches applies the merge patches of the given override
+// items in order on to the given unstructured object, so that a later
+// item takes precedence over an earlier one regardless of the kind of
+// its patches. The strategic merge patch of an item is applied before
+// its JSON merge patch. A strategic merge patch is applied as a JSON
+// merge patch if the type of the object is not known.
+func ApplyMergePatches(obj *unstructured.Unstructured, mergePatches []MergePatches) error {
+	if len(mergePatches) == 0 {
+		return nil
+	}
+	var dataStruct runtime.Object
+	if typed, err := scheme.Scheme.New(obj.GroupVersionKind()); err == nil {
+		dataStruct = typed
+	}
+
+	objectJSONBytes, err := obj.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	for _, patches := range mergePatches {
+		if patches.StrategicMergePatch != nil {
+			patchBytes, err := json.Marshal(patches.StrategicMergePatch)
+			if err != nil {
+				return err
+			}
+			if dataStruct != nil {
+				objectJSONBytes, err = strategicpatch.StrategicMergePatch(objectJSONBytes, patchBytes, dataStruct)
+			} else {
+				objectJSONBytes, err = jsonpatch.MergePatch(objectJSONBytes, patchBytes)
+			}
+			if err != nil {
+				return errors.Wrap(err, "failed to apply strategic merge patch")
+			}
+		}
+		if patches.MergePatch != nil {
+			patchBytes, err := json.Marshal(patches.MergePatch)
+			if err != nil {
+				return err
+			}
+			objectJSONBytes, err = jsonpatch.MergePatch(objectJSONBytes, patchBytes)
+			if err != nil {
+				return errors.Wrap(err, "failed to apply merge patch")
+			}
+		}
+	}
+	return obj.UnmarshalJSON(objectJSONBytes)
+}
+
 // ApplyJSONPatch applies the override on to the given unstructured object.
 func ApplyJSONPatch(obj *unstructured.Unstructured, overrides ClusterOverrides) error {
 	// TODO: Do the defaulting of "op" field to "replace" in API defaulting
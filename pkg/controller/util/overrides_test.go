@@ -0,0 +1,337 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func TestOverridesForCluster(t *testing.T) {
+	testCases := map[string]struct {
+		overrides     []interface{}
+		clusterLabels labels.Set
+		expected      ClusterOverrides
+		expectedErr   bool
+	}{
+		"overrides for the cluster name": {
+			overrides: []interface{}{
+				overrideItem("cluster1", nil, "/spec/replicas", 2),
+			},
+			expected: ClusterOverrides{{Path: "/spec/replicas", Value: float64(2)}},
+		},
+		"overrides for a matching cluster selector": {
+			overrides: []interface{}{
+				overrideItem("", map[string]interface{}{"region": "us-east1"}, "/spec/replicas", 3),
+				overrideItem("", map[string]interface{}{"region": "europe-west1"}, "/spec/paused", true),
+			},
+			clusterLabels: labels.Set{"region": "us-east1"},
+			expected:      ClusterOverrides{{Path: "/spec/replicas", Value: float64(3)}},
+		},
+		"overrides for the cluster name take precedence over a cluster selector": {
+			overrides: []interface{}{
+				overrideItem("cluster1", nil, "/spec/replicas", 2),
+				overrideItem("", map[string]interface{}{"region": "us-east1"}, "/spec/replicas", 3),
+			},
+			clusterLabels: labels.Set{"region": "us-east1"},
+			expected: ClusterOverrides{
+				{Path: "/spec/replicas", Value: float64(3)},
+				{Path: "/spec/replicas", Value: float64(2)},
+			},
+		},
+		"matching cluster selectors overriding the same path conflict": {
+			overrides: []interface{}{
+				overrideItem("", map[string]interface{}{"region": "us-east1"}, "/spec/replicas", 3),
+				overrideItem("", map[string]interface{}{"tier": "edge"}, "/spec/replicas", 1),
+			},
+			clusterLabels: labels.Set{"region": "us-east1", "tier": "edge"},
+			expectedErr:   true,
+		},
+		"cluster selectors overriding the same path for different clusters do not conflict": {
+			overrides: []interface{}{
+				overrideItem("", map[string]interface{}{"region": "us-east1"}, "/spec/replicas", 3),
+				overrideItem("", map[string]interface{}{"tier": "edge"}, "/spec/replicas", 1),
+			},
+			clusterLabels: labels.Set{"tier": "edge"},
+			expected:      ClusterOverrides{{Path: "/spec/replicas", Value: float64(1)}},
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			obj := &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					SpecField: map[string]interface{}{
+						OverridesField: testCase.overrides,
+					},
+				},
+			}
+			overridesMap, err := GetOverrides(obj)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			mergePatchesMap, err := GetMergePatches(obj)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			selectorOverrides, err := GetSelectorOverrides(obj)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			overrides, _, err := OverridesForCluster("cluster1", testCase.clusterLabels, overridesMap, mergePatchesMap, selectorOverrides)
+			if testCase.expectedErr {
+				if err == nil {
+					t.Fatalf("Expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(overrides, testCase.expected) {
+				t.Fatalf("Expected overrides %v, got %v", testCase.expected, overrides)
+			}
+		})
+	}
+}
+
+func TestSetOverridesRetainsOverrides(t *testing.T) {
+	patchedItem := overrideItem("cluster3", nil, "/spec/replicas", 4)
+	patchedItem[MergePatchField] = map[string]interface{}{"spec": map[string]interface{}{"paused": true}}
+	obj := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			SpecField: map[string]interface{}{
+				OverridesField: []interface{}{
+					overrideItem("cluster1", nil, "/spec/replicas", 2),
+					overrideItem("", map[string]interface{}{"region": "us-east1"}, "/spec/paused", true),
+					patchedItem,
+				},
+			},
+		},
+	}
+
+	err := SetOverrides(obj, OverridesMap{
+		"cluster2": ClusterOverrides{{Path: "/spec/replicas", Value: 3}},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	overridesMap, err := GetOverrides(obj)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// The item of cluster3 is retained for its merge patch.
+	if _, ok := overridesMap["cluster1"]; ok || len(overridesMap["cluster2"]) != 1 || len(overridesMap["cluster3"]) != 0 {
+		t.Fatalf("Expected only the JSON patches of cluster2, got %v", overridesMap)
+	}
+	selectorOverrides, err := GetSelectorOverrides(obj)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(selectorOverrides) != 1 || selectorOverrides[0].Selector.String() != "region=us-east1" {
+		t.Fatalf("Expected the cluster selector overrides to be retained, got %v", selectorOverrides)
+	}
+	mergePatchesMap, err := GetMergePatches(obj)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, ok := mergePatchesMap["cluster3"]; !ok || len(mergePatchesMap) != 1 {
+		t.Fatalf("Expected the merge patches of cluster3 to be retained, got %v", mergePatchesMap)
+	}
+}
+
+func TestApplyMergePatches(t *testing.T) {
+	deployment := func() *unstructured.Unstructured {
+		return &unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": "apps/v1",
+				"kind":       "Deployment",
+				"metadata": map[string]interface{}{
+					"name": "foo",
+				},
+				"spec": map[string]interface{}{
+					"template": map[string]interface{}{
+						"spec": map[string]interface{}{
+							"containers": []interface{}{
+								map[string]interface{}{"name": "sidecar", "image": "sidecar:1"},
+								map[string]interface{}{"name": "app", "image": "app:1"},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+	containersPatch := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"containers": []interface{}{
+						map[string]interface{}{"name": "app", "image": "app:2"},
+					},
+				},
+			},
+		},
+	}
+
+	testCases := map[string]struct {
+		kind               string
+		mergePatches       []MergePatches
+		expectedContainers []interface{}
+		expectedReplicas   interface{}
+	}{
+		"strategic merge patch merges containers by name": {
+			mergePatches: []MergePatches{{StrategicMergePatch: containersPatch}},
+			expectedContainers: []interface{}{
+				map[string]interface{}{"name": "sidecar", "image": "sidecar:1"},
+				map[string]interface{}{"name": "app", "image": "app:2"},
+			},
+		},
+		"merge patch replaces containers": {
+			mergePatches: []MergePatches{{MergePatch: containersPatch}},
+			expectedContainers: []interface{}{
+				map[string]interface{}{"name": "app", "image": "app:2"},
+			},
+		},
+		"strategic merge patch of an unknown type is applied as a merge patch": {
+			kind:         "Unknown",
+			mergePatches: []MergePatches{{StrategicMergePatch: containersPatch}},
+			expectedContainers: []interface{}{
+				map[string]interface{}{"name": "app", "image": "app:2"},
+			},
+		},
+		"patches of later items take precedence regardless of their kind": {
+			mergePatches: []MergePatches{
+				{MergePatch: map[string]interface{}{"spec": map[string]interface{}{"replicas": int64(3)}}},
+				{StrategicMergePatch: map[string]interface{}{"spec": map[string]interface{}{"replicas": int64(2)}}},
+			},
+			expectedContainers: []interface{}{
+				map[string]interface{}{"name": "sidecar", "image": "sidecar:1"},
+				map[string]interface{}{"name": "app", "image": "app:1"},
+			},
+			expectedReplicas: int64(2),
+		},
+		"merge patch of an item is applied after its strategic merge patch": {
+			mergePatches: []MergePatches{{
+				MergePatch:          map[string]interface{}{"spec": map[string]interface{}{"replicas": int64(3)}},
+				StrategicMergePatch: map[string]interface{}{"spec": map[string]interface{}{"replicas": int64(2)}},
+			}},
+			expectedContainers: []interface{}{
+				map[string]interface{}{"name": "sidecar", "image": "sidecar:1"},
+				map[string]interface{}{"name": "app", "image": "app:1"},
+			},
+			expectedReplicas: int64(3),
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			obj := deployment()
+			if testCase.kind != "" {
+				obj.SetKind(testCase.kind)
+			}
+			if err := ApplyMergePatches(obj, testCase.mergePatches); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			containers, _, err := unstructured.NestedSlice(obj.Object, "spec", "template", "spec", "containers")
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(containers, testCase.expectedContainers) {
+				t.Fatalf("Expected containers %v, got %v", testCase.expectedContainers, containers)
+			}
+			replicas, _, _ := unstructured.NestedFieldNoCopy(obj.Object, "spec", "replicas")
+			if !reflect.DeepEqual(replicas, testCase.expectedReplicas) {
+				t.Fatalf("Expected replicas %v, got %v", testCase.expectedReplicas, replicas)
+			}
+		})
+	}
+}
+
+func TestMergePatchesForCluster(t *testing.T) {
+	// The merge patch of the cluster selector item would take
+	// precedence if all strategic merge patches were applied first.
+	selectorItem := overrideItem("", map[string]interface{}{"region": "us-east1"}, "/spec/paused", true)
+	selectorItem[MergePatchField] = map[string]interface{}{"spec": map[string]interface{}{"replicas": int64(3)}}
+	clusterItem := overrideItem("cluster1", nil, "/spec/paused", false)
+	clusterItem[StrategicMergePatchField] = map[string]interface{}{"spec": map[string]interface{}{"replicas": int64(2)}}
+	fedObject := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			SpecField: map[string]interface{}{
+				OverridesField: []interface{}{clusterItem, selectorItem},
+			},
+		},
+	}
+
+	overridesMap, err := GetOverrides(fedObject)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	mergePatchesMap, err := GetMergePatches(fedObject)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	selectorOverrides, err := GetSelectorOverrides(fedObject)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	_, mergePatches, err := OverridesForCluster("cluster1", labels.Set{"region": "us-east1"}, overridesMap, mergePatchesMap, selectorOverrides)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	obj := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"metadata":   map[string]interface{}{"name": "foo"},
+			"spec":       map[string]interface{}{"replicas": int64(1)},
+		},
+	}
+	if err := ApplyMergePatches(obj, mergePatches); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	replicas, _, _ := unstructured.NestedFieldNoCopy(obj.Object, "spec", "replicas")
+	if replicas != int64(2) {
+		t.Fatalf("Expected the patch for the cluster name to take precedence, got replicas %v", replicas)
+	}
+}
+
+func overrideItem(clusterName string, matchLabels map[string]interface{}, path string, value interface{}) map[string]interface{} {
+	item := map[string]interface{}{
+		ClusterOverridesField: []interface{}{
+			map[string]interface{}{
+				PathField:  path,
+				ValueField: value,
+			},
+		},
+	}
+	if clusterName != "" {
+		item[ClusterNameField] = clusterName
+	}
+	if matchLabels != nil {
+		item[ClusterSelectorField] = map[string]interface{}{
+			MatchLabelsField: matchLabels,
+		}
+	}
+	return item
+}
@@ -17,6 +17,13 @@ limitations under the License.
 package util
 
 import (
+	"fmt"
+	"hash/fnv"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
@@ -32,6 +39,57 @@ type GenericClusterReference struct {
 type GenericPlacementFields struct {
 	Clusters        []GenericClusterReference `json:"clusters,omitempty"`
 	ClusterSelector *metav1.LabelSelector     `json:"clusterSelector,omitempty"`
+	Tolerations     []corev1.Toleration       `json:"tolerations,omitempty"`
+	MaxClusters     *int64                    `json:"maxClusters,omitempty"`
+	MinClusters     *int64                    `json:"minClusters,omitempty"`
+	TopologySpread  *TopologySpread           `json:"topologySpread,omitempty"`
+}
+
+// TopologySpread spreads the placement of a federated resource over
+// the topology domains of clusters, the values of the cluster label
+// with the topology key.
+type TopologySpread struct {
+	TopologyKey string `json:"topologyKey"`
+}
+
+// PlacementReason explains whether a cluster was chosen by the
+// placement constraints of a federated resource.
+type PlacementReason string
+
+const (
+	// The cluster is the highest ranked cluster of its topology domain.
+	PlacementTopologySpread PlacementReason = "TopologySpread"
+	// The cluster was chosen by its rank.
+	PlacementRanked PlacementReason = "Ranked"
+	// The cluster was not chosen since maxClusters higher ranked
+	// clusters were.
+	PlacementMaxClustersExceeded PlacementReason = "MaxClustersExceeded"
+	// The cluster was not chosen since it is not ready.
+	PlacementClusterNotReady PlacementReason = "ClusterNotReady"
+	// Fewer clusters than minClusters were chosen.
+	PlacementMinClustersUnsatisfied PlacementReason = "MinClustersUnsatisfied"
+)
+
+// ClusterPlacementStatus records whether and why a cluster eligible
+// for the placement of a federated resource was chosen.
+type ClusterPlacementStatus struct {
+	Name string `json:"name"`
+	// Topology domain of the cluster, if the placement is spread.
+	Domain   string          `json:"domain,omitempty"`
+	Selected bool            `json:"selected"`
+	Reason   PlacementReason `json:"reason"`
+}
+
+// PlacementStatus records the outcome of the placement constraints of
+// a federated resource.
+type PlacementStatus struct {
+	// Clusters eligible for placement in order of preference. Clusters
+	// that are not selected by the clusters or clusterSelector fields
+	// or whose taints are not tolerated are not eligible.
+	Clusters []ClusterPlacementStatus `json:"clusters,omitempty"`
+	// Set if the placement constraints could not be satisfied.
+	Reason  PlacementReason `json:"reason,omitempty"`
+	Message string          `json:"message,omitempty"`
 }
 
 type GenericPlacementSpec struct {
@@ -94,6 +152,24 @@ func SetClusterSelector(obj *unstructured.Unstructured, clusterSelector map[stri
 	return unstructured.SetNestedStringMap(obj.Object, clusterSelector, SpecField, PlacementField, ClusterSelectorField, MatchLabelsField)
 }
 
+// PlacementClusters returns the KubeFedClusters among the items of a
+// cluster store that placement is computed for: all push-mode and
+// pull-mode clusters, regardless of their readiness. Clusters that are
+// not ready may still be chosen by the clusters and clusterSelector of
+// a placement, for which they are reported as not ready, but are never
+// ranked by placement constraints.
+func PlacementClusters(items []interface{}) ([]*fedv1b1.KubeFedCluster, error) {
+	clusters := make([]*fedv1b1.KubeFedCluster, 0, len(items))
+	for _, item := range items {
+		cluster, ok := item.(*fedv1b1.KubeFedCluster)
+		if !ok {
+			return nil, errors.Errorf("wrong data in the cluster store: %v", item)
+		}
+		clusters = append(clusters, cluster)
+	}
+	return clusters, nil
+}
+
 // ComputeNamespacedPlacement determines placement for namespaced
 // federated resources (e.g. FederatedConfigMap).
 //
@@ -108,36 +184,70 @@ func SetClusterSelector(obj *unstructured.Unstructured, clusterSelector map[stri
 // because the single namespace by definition must exist on member
 // clusters, so namespace placement becomes a mechanism for limiting
 // rather than allowing propagation.
+//
+// The placement constraints of the resource are applied to the
+// clusters allowed by both placements.
 func ComputeNamespacedPlacement(resource, namespace *unstructured.Unstructured, clusters []*fedv1b1.KubeFedCluster, limitedScope bool, selectorOnly bool) (selectedClusters sets.Set[string], err error) {
-	resourceClusters, err := ComputePlacement(resource, clusters, selectorOnly)
+	selectedClusters, _, err = ComputeNamespacedPlacementStatus(resource, namespace, clusters, limitedScope, selectorOnly)
+	return selectedClusters, err
+}
+
+// ComputeNamespacedPlacementStatus determines placement like
+// ComputeNamespacedPlacement and additionally returns the outcome of
+// the placement constraints of the resource, or nil if the resource
+// has none.
+func ComputeNamespacedPlacementStatus(resource, namespace *unstructured.Unstructured, clusters []*fedv1b1.KubeFedCluster, limitedScope bool, selectorOnly bool) (sets.Set[string], *PlacementStatus, error) {
+	resourceClusters, err := eligibleClusterNames(resource, clusters, selectorOnly)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	if namespace == nil {
-		if limitedScope {
-			// Use the resource placement verbatim if no federated
-			// namespace is present and KubeFed is targeting a
-			// single namespace.
-			return resourceClusters, nil
+	switch {
+	case namespace != nil:
+		namespaceClusters, err := ComputePlacement(namespace, clusters, selectorOnly)
+		if err != nil {
+			return nil, nil, err
 		}
+		// If both namespace and resource placement exist, the
+		// desired list of clusters is their intersection.
+		resourceClusters = resourceClusters.Intersection(namespaceClusters)
+	case !limitedScope:
 		// Resource should not exist in any member clusters.
-		return sets.Set[string]{}, nil
+		resourceClusters = sets.Set[string]{}
 	}
+	// Otherwise the resource placement is used verbatim since no
+	// federated namespace is present and KubeFed is targeting a
+	// single namespace.
 
-	namespaceClusters, err := ComputePlacement(namespace, clusters, selectorOnly)
-	if err != nil {
-		return nil, err
-	}
-
-	// If both namespace and resource placement exist, the desired
-	// list of clusters is their intersection.
-	return resourceClusters.Intersection(namespaceClusters), nil
+	return constrainPlacement(resource, clusters, resourceClusters)
 }
 
 // ComputePlacement determines the selected clusters for a federated
-// resource.
+// resource. Clusters with taints not tolerated by the resource are not
+// selected.
 func ComputePlacement(resource *unstructured.Unstructured, clusters []*fedv1b1.KubeFedCluster, selectorOnly bool) (selectedClusters sets.Set[string], err error) {
+	selectedClusters, _, err = ComputePlacementStatus(resource, clusters, selectorOnly)
+	return selectedClusters, err
+}
+
+// ComputePlacementStatus determines placement like ComputePlacement
+// and additionally returns the outcome of the placement constraints of
+// the resource, or nil if the resource has none.
+func ComputePlacementStatus(resource *unstructured.Unstructured, clusters []*fedv1b1.KubeFedCluster, selectorOnly bool) (sets.Set[string], *PlacementStatus, error) {
+	clusterNames, err := eligibleClusterNames(resource, clusters, selectorOnly)
+	if err != nil {
+		return nil, nil, err
+	}
+	return constrainPlacement(resource, clusters, clusterNames)
+}
+
+// eligibleClusterNames returns the names of the clusters selected by
+// the placement of a federated resource whose taints are tolerated.
+func eligibleClusterNames(resource *unstructured.Unstructured, clusters []*fedv1b1.KubeFedCluster, selectorOnly bool) (sets.Set[string], error) {
+	clusters, err := TolerantClusters(resource, clusters)
+	if err != nil {
+		return nil, err
+	}
 	selectedNames, err := selectedClusterNames(resource, clusters, selectorOnly)
 	if err != nil {
 		return nil, err
@@ -146,6 +256,232 @@ func ComputePlacement(resource *unstructured.Unstructured, clusters []*fedv1b1.K
 	return clusterNames.Intersection(selectedNames), nil
 }
 
+// constrainPlacement chooses among the eligible clusters according to
+// the placement constraints of a federated resource. Clusters are
+// ranked by a hash of their name and the name of the resource so that
+// the choice is stable and differs between resources. If the placement
+// is spread over a topology, the clusters are ranked by taking the
+// highest ranked remaining cluster of each topology domain in turn,
+// and clusters not in any domain are ranked last. The highest ranked
+// clusters are chosen, up to maxClusters. Clusters that are not ready
+// are not ranked unless the resource has already been propagated to
+// them, so that a new resource is not placed on an unavailable cluster
+// while a cluster whose readiness flaps does not cause the resource to
+// move away and back.
+func constrainPlacement(resource *unstructured.Unstructured, clusters []*fedv1b1.KubeFedCluster, eligibleNames sets.Set[string]) (sets.Set[string], *PlacementStatus, error) {
+	placement, err := UnmarshalGenericPlacement(resource)
+	if err != nil {
+		return nil, nil, err
+	}
+	fields := placement.Spec.Placement
+	if fields.MaxClusters == nil && fields.MinClusters == nil && fields.TopologySpread == nil {
+		return eligibleNames, nil, nil
+	}
+
+	propagatedNames, err := propagatedClusterNames(resource)
+	if err != nil {
+		return nil, nil, err
+	}
+	var eligibleClusters []*fedv1b1.KubeFedCluster
+	notReadyNames := sets.Set[string]{}
+	for _, cluster := range clusters {
+		switch {
+		case !eligibleNames.Has(cluster.Name):
+		case IsClusterReady(&cluster.Status) || propagatedNames.Has(cluster.Name):
+			eligibleClusters = append(eligibleClusters, cluster)
+		default:
+			notReadyNames.Insert(cluster.Name)
+		}
+	}
+	topologyKey := ""
+	if fields.TopologySpread != nil {
+		topologyKey = fields.TopologySpread.TopologyKey
+	}
+	hashKey := NewQualifiedName(resource).String()
+
+	selectedNames := sets.Set[string]{}
+	placementStatus := &PlacementStatus{}
+	for _, ranked := range rankClusters(hashKey, eligibleClusters, topologyKey) {
+		clusterStatus := ClusterPlacementStatus{
+			Name:     ranked.name,
+			Domain:   ranked.domain,
+			Selected: true,
+			Reason:   PlacementRanked,
+		}
+		if ranked.firstInDomain {
+			clusterStatus.Reason = PlacementTopologySpread
+		}
+		if fields.MaxClusters != nil && int64(len(selectedNames)) >= *fields.MaxClusters {
+			clusterStatus.Selected = false
+			clusterStatus.Reason = PlacementMaxClustersExceeded
+		} else {
+			selectedNames.Insert(ranked.name)
+		}
+		placementStatus.Clusters = append(placementStatus.Clusters, clusterStatus)
+	}
+	for _, name := range sets.List(notReadyNames) {
+		placementStatus.Clusters = append(placementStatus.Clusters, ClusterPlacementStatus{
+			Name:   name,
+			Reason: PlacementClusterNotReady,
+		})
+	}
+
+	if fields.MinClusters != nil && int64(len(selectedNames)) < *fields.MinClusters {
+		placementStatus.Reason = PlacementMinClustersUnsatisfied
+		placementStatus.Message = fmt.Sprintf("%d clusters were chosen but at least %d are required", len(selectedNames), *fields.MinClusters)
+	}
+	return selectedNames, placementStatus, nil
+}
+
+type rankedCluster struct {
+	name   string
+	domain string
+	hash   uint64
+	// Whether the cluster is the highest ranked cluster of its domain
+	firstInDomain bool
+}
+
+// rankClusters orders clusters by preference for the placement of the
+// resource with the given hash key.
+func rankClusters(hashKey string, clusters []*fedv1b1.KubeFedCluster, topologyKey string) []rankedCluster {
+	ranked := make([]rankedCluster, 0, len(clusters))
+	for _, cluster := range clusters {
+		hasher := fnv.New64a()
+		hasher.Write([]byte(hashKey + "/" + cluster.Name))
+		ranked = append(ranked, rankedCluster{
+			name:   cluster.Name,
+			domain: TopologyDomain(cluster, topologyKey),
+			hash:   hasher.Sum64(),
+		})
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].hash != ranked[j].hash {
+			return ranked[i].hash < ranked[j].hash
+		}
+		return ranked[i].name < ranked[j].name
+	})
+	if topologyKey == "" {
+		return ranked
+	}
+
+	// Domains are ordered by the rank of their highest ranked cluster.
+	var domains []string
+	domainClusters := make(map[string][]rankedCluster)
+	var unknownDomain []rankedCluster
+	for _, cluster := range ranked {
+		if cluster.domain == "" {
+			unknownDomain = append(unknownDomain, cluster)
+			continue
+		}
+		if _, ok := domainClusters[cluster.domain]; !ok {
+			domains = append(domains, cluster.domain)
+		}
+		domainClusters[cluster.domain] = append(domainClusters[cluster.domain], cluster)
+	}
+
+	spread := make([]rankedCluster, 0, len(ranked))
+	for i := 0; len(spread) < len(ranked)-len(unknownDomain); i++ {
+		for _, domain := range domains {
+			if i < len(domainClusters[domain]) {
+				cluster := domainClusters[domain][i]
+				cluster.firstInDomain = i == 0
+				spread = append(spread, cluster)
+			}
+		}
+	}
+	return append(spread, unknownDomain...)
+}
+
+// TopologyDomain returns the value of the cluster label with the given
+// topology key. The region of a cluster, and the zone of a cluster
+// whose nodes are all in the same zone, are also determined from its
+// status if it is not labeled with them.
+func TopologyDomain(cluster *fedv1b1.KubeFedCluster, topologyKey string) string {
+	if topologyKey == "" {
+		return ""
+	}
+	if domain, ok := cluster.Labels[topologyKey]; ok {
+		return domain
+	}
+	switch {
+	case topologyKey == corev1.LabelTopologyRegion && cluster.Status.Region != nil:
+		return *cluster.Status.Region
+	case topologyKey == corev1.LabelTopologyZone && len(cluster.Status.Zones) == 1:
+		return cluster.Status.Zones[0]
+	}
+	return ""
+}
+
+// TolerantClusters returns the clusters whose taints are tolerated by
+// the placement of a federated resource. A NoSchedule taint only
+// prevents new placement, so it is ignored for the clusters the
+// resource has already been propagated to. A NoExecute taint also
+// removes the resource from the clusters it has been propagated to.
+func TolerantClusters(resource *unstructured.Unstructured, clusters []*fedv1b1.KubeFedCluster) ([]*fedv1b1.KubeFedCluster, error) {
+	placement, err := UnmarshalGenericPlacement(resource)
+	if err != nil {
+		return nil, err
+	}
+	propagatedNames, err := propagatedClusterNames(resource)
+	if err != nil {
+		return nil, err
+	}
+
+	var tolerantClusters []*fedv1b1.KubeFedCluster
+	for _, cluster := range clusters {
+		if toleratesTaints(placement.Spec.Placement.Tolerations, cluster.Spec.Taints, propagatedNames.Has(cluster.Name)) {
+			tolerantClusters = append(tolerantClusters, cluster)
+		}
+	}
+	return tolerantClusters, nil
+}
+
+func toleratesTaints(tolerations []corev1.Toleration, taints []corev1.Taint, propagated bool) bool {
+	for i := range taints {
+		taint := &taints[i]
+		switch taint.Effect {
+		case corev1.TaintEffectNoSchedule:
+			if propagated {
+				continue
+			}
+		case corev1.TaintEffectNoExecute:
+		default:
+			continue
+		}
+		tolerated := false
+		for j := range tolerations {
+			if tolerations[j].ToleratesTaint(taint) {
+				tolerated = true
+				break
+			}
+		}
+		if !tolerated {
+			return false
+		}
+	}
+	return true
+}
+
+// propagatedClusterNames returns the names of the clusters in the
+// propagation status of a federated resource.
+func propagatedClusterNames(resource *unstructured.Unstructured) (sets.Set[string], error) {
+	clusterNames := sets.Set[string]{}
+	clusters, _, err := unstructured.NestedSlice(resource.Object, StatusField, ClustersField)
+	if err != nil {
+		return nil, err
+	}
+	for _, cluster := range clusters {
+		clusterMap, ok := cluster.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if name, ok := clusterMap[NameField].(string); ok {
+			clusterNames.Insert(name)
+		}
+	}
+	return clusterNames, nil
+}
+
 func selectedClusterNames(resource *unstructured.Unstructured, clusters []*fedv1b1.KubeFedCluster, selectorOnly bool) (sets.Set[string], error) {
 	placement, err := UnmarshalGenericPlacement(resource)
 	if err != nil {
@@ -20,10 +20,12 @@ import (
 	"reflect"
 	"testing"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/util/sets"
 
+	fedcommon "sigs.k8s.io/kubefed/pkg/apis/core/common"
 	fedv1b1 "sigs.k8s.io/kubefed/pkg/apis/core/v1beta1"
 )
 
@@ -102,3 +104,360 @@ func TestSelectedClusterNames(t *testing.T) {
 		})
 	}
 }
+
+func TestComputePlacementTaints(t *testing.T) {
+	noSchedule := corev1.Taint{Key: "example.com/maintenance", Effect: corev1.TaintEffectNoSchedule}
+	noExecute := corev1.Taint{Key: "example.com/maintenance", Value: "drain", Effect: corev1.TaintEffectNoExecute}
+	clusters := []*fedv1b1.KubeFedCluster{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster1"},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster2"},
+			Spec:       fedv1b1.KubeFedClusterSpec{Taints: []corev1.Taint{noSchedule}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster3"},
+			Spec:       fedv1b1.KubeFedClusterSpec{Taints: []corev1.Taint{noExecute}},
+		},
+	}
+
+	testCases := map[string]struct {
+		tolerations        []interface{}
+		propagatedClusters []string
+		expectedNames      sets.Set[string]
+	}{
+		"tainted clusters are not selected": {
+			expectedNames: sets.New("cluster1"),
+		},
+		"NoSchedule taint does not remove propagated resource": {
+			propagatedClusters: []string{"cluster2", "cluster3"},
+			expectedNames:      sets.New("cluster1", "cluster2"),
+		},
+		"toleration of a taint with any value": {
+			tolerations: []interface{}{
+				map[string]interface{}{"key": "example.com/maintenance", "operator": "Exists"},
+			},
+			expectedNames: sets.New("cluster1", "cluster2", "cluster3"),
+		},
+		"toleration of a taint with the given value and effect": {
+			tolerations: []interface{}{
+				map[string]interface{}{"key": "example.com/maintenance", "value": "drain", "effect": "NoExecute"},
+			},
+			expectedNames: sets.New("cluster1", "cluster3"),
+		},
+		"toleration of a taint with another value": {
+			tolerations: []interface{}{
+				map[string]interface{}{"key": "example.com/maintenance", "value": "upgrade"},
+			},
+			expectedNames: sets.New("cluster1"),
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			obj := &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"spec": map[string]interface{}{
+						"placement": map[string]interface{}{
+							"clusterSelector": map[string]interface{}{},
+						},
+					},
+				},
+			}
+			if testCase.tolerations != nil {
+				if err := unstructured.SetNestedSlice(obj.Object, testCase.tolerations, SpecField, PlacementField, TolerationsField); err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+			}
+			var propagated []interface{}
+			for _, clusterName := range testCase.propagatedClusters {
+				propagated = append(propagated, map[string]interface{}{NameField: clusterName})
+			}
+			if err := unstructured.SetNestedSlice(obj.Object, propagated, StatusField, ClustersField); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			selectedNames, err := ComputePlacement(obj, clusters, false)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(selectedNames, testCase.expectedNames) {
+				t.Fatalf("Expected names %v, got %v", testCase.expectedNames, selectedNames)
+			}
+		})
+	}
+}
+
+func TestPlacementClusters(t *testing.T) {
+	items := []interface{}{
+		newReadyCluster("push-ready"),
+		&fedv1b1.KubeFedCluster{ObjectMeta: metav1.ObjectMeta{Name: "push-not-ready"}},
+		&fedv1b1.KubeFedCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "pull-not-ready"},
+			Spec:       fedv1b1.KubeFedClusterSpec{Mode: fedv1b1.ClusterModePull},
+		},
+	}
+
+	// The sync controller and the agents of pull-mode clusters compute
+	// placement for all clusters, whether ready or not.
+	clusters, err := PlacementClusters(items)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expectedNames := sets.New("push-ready", "push-not-ready", "pull-not-ready")
+	if names := getClusterNames(clusters); !names.Equal(expectedNames) {
+		t.Fatalf("Expected clusters %v, got %v", sets.List(expectedNames), sets.List(names))
+	}
+
+	if _, err := PlacementClusters([]interface{}{"cluster"}); err == nil {
+		t.Fatalf("Expected an error for an item that is not a cluster")
+	}
+}
+
+func TestComputePlacementConstraints(t *testing.T) {
+	region := func(name string) *string { return &name }
+	withLabels := func(cluster *fedv1b1.KubeFedCluster, labels map[string]string) *fedv1b1.KubeFedCluster {
+		cluster.Labels = labels
+		return cluster
+	}
+	cluster4 := newReadyCluster("cluster4")
+	cluster4.Status.Region = region("europe-west1")
+	clusters := []*fedv1b1.KubeFedCluster{
+		withLabels(newReadyCluster("cluster1"), map[string]string{corev1.LabelTopologyRegion: "us-east1"}),
+		withLabels(newReadyCluster("cluster2"), map[string]string{corev1.LabelTopologyRegion: "us-east1"}),
+		withLabels(newReadyCluster("cluster3"), map[string]string{corev1.LabelTopologyRegion: "us-east1"}),
+		cluster4,
+		newReadyCluster("cluster5"),
+		// A cluster that is not ready is not ranked for a resource that
+		// has not been propagated to it, even though it would be the
+		// only cluster of its region.
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster6", Labels: map[string]string{corev1.LabelTopologyRegion: "asia-east1"}},
+		},
+	}
+
+	testCases := map[string]struct {
+		constraints      map[string]interface{}
+		expectedCount    int
+		expectedNames    sets.Set[string]
+		expectedReason   PlacementReason
+		expectNoStatus   bool
+		expectedStatuses int
+	}{
+		"no constraints selects all clusters without status": {
+			expectedCount:  6,
+			expectNoStatus: true,
+		},
+		"maxClusters limits the selected clusters": {
+			constraints:      map[string]interface{}{"maxClusters": int64(2)},
+			expectedCount:    2,
+			expectedStatuses: 6,
+		},
+		"topology spread selects a cluster of each region": {
+			constraints: map[string]interface{}{
+				"maxClusters":    int64(2),
+				"topologySpread": map[string]interface{}{"topologyKey": corev1.LabelTopologyRegion},
+			},
+			expectedCount:    2,
+			expectedNames:    sets.New("cluster4"),
+			expectedStatuses: 6,
+		},
+		"clusters without a region are chosen last": {
+			constraints: map[string]interface{}{
+				"maxClusters":    int64(4),
+				"topologySpread": map[string]interface{}{"topologyKey": corev1.LabelTopologyRegion},
+			},
+			expectedCount:    4,
+			expectedNames:    sets.New("cluster1", "cluster2", "cluster3", "cluster4"),
+			expectedStatuses: 6,
+		},
+		"clusters that are not ready are not chosen": {
+			constraints:      map[string]interface{}{"maxClusters": int64(6)},
+			expectedCount:    5,
+			expectedNames:    sets.New("cluster1", "cluster2", "cluster3", "cluster4", "cluster5"),
+			expectedStatuses: 6,
+		},
+		"minClusters not satisfied": {
+			constraints: map[string]interface{}{
+				"maxClusters": int64(1),
+				"minClusters": int64(2),
+			},
+			expectedCount:    1,
+			expectedReason:   PlacementMinClustersUnsatisfied,
+			expectedStatuses: 6,
+		},
+	}
+
+	for testName, testCase := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			placement := map[string]interface{}{
+				"clusterSelector": map[string]interface{}{},
+			}
+			for key, value := range testCase.constraints {
+				placement[key] = value
+			}
+			obj := &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"metadata": map[string]interface{}{
+						"name":      "foo",
+						"namespace": "bar",
+					},
+					"spec": map[string]interface{}{
+						"placement": placement,
+					},
+				},
+			}
+
+			selectedNames, placementStatus, err := ComputePlacementStatus(obj, clusters, false)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if selectedNames.Len() != testCase.expectedCount {
+				t.Fatalf("Expected %d clusters to be selected, got %v", testCase.expectedCount, selectedNames)
+			}
+			if !selectedNames.IsSuperset(testCase.expectedNames) {
+				t.Fatalf("Expected clusters %v to be selected, got %v", testCase.expectedNames, selectedNames)
+			}
+
+			// The choice does not depend on the order of clusters.
+			reversed := make([]*fedv1b1.KubeFedCluster, 0, len(clusters))
+			for i := len(clusters) - 1; i >= 0; i-- {
+				reversed = append(reversed, clusters[i])
+			}
+			reversedNames, _, err := ComputePlacementStatus(obj, reversed, false)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(selectedNames, reversedNames) {
+				t.Fatalf("Expected the same clusters to be selected, got %v and %v", selectedNames, reversedNames)
+			}
+
+			if testCase.expectNoStatus {
+				if placementStatus != nil {
+					t.Fatalf("Expected no placement status, got %v", placementStatus)
+				}
+				return
+			}
+			if placementStatus.Reason != testCase.expectedReason {
+				t.Fatalf("Expected reason %q, got %q", testCase.expectedReason, placementStatus.Reason)
+			}
+			if len(placementStatus.Clusters) != testCase.expectedStatuses {
+				t.Fatalf("Expected %d cluster statuses, got %v", testCase.expectedStatuses, placementStatus.Clusters)
+			}
+			for _, clusterStatus := range placementStatus.Clusters {
+				if clusterStatus.Selected != selectedNames.Has(clusterStatus.Name) {
+					t.Fatalf("Expected the status of cluster %q to match its selection, got %v", clusterStatus.Name, clusterStatus)
+				}
+				expectedReason := PlacementMaxClustersExceeded
+				if clusterStatus.Name == "cluster6" {
+					expectedReason = PlacementClusterNotReady
+				}
+				if !clusterStatus.Selected && clusterStatus.Reason != expectedReason {
+					t.Fatalf("Expected reason %q for cluster %q, got %q", expectedReason, clusterStatus.Name, clusterStatus.Reason)
+				}
+			}
+		})
+	}
+}
+
+func TestComputePlacementWithFlappingReadiness(t *testing.T) {
+	clusters := []*fedv1b1.KubeFedCluster{
+		newReadyCluster("cluster1"),
+		newReadyCluster("cluster2"),
+		newReadyCluster("cluster3"),
+		newReadyCluster("cluster4"),
+	}
+	newResource := func() *unstructured.Unstructured {
+		return &unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"name":      "foo",
+					"namespace": "bar",
+				},
+				"spec": map[string]interface{}{
+					"placement": map[string]interface{}{
+						"clusterSelector": map[string]interface{}{},
+						"maxClusters":     int64(2),
+					},
+				},
+			},
+		}
+	}
+
+	obj := newResource()
+	selectedNames, _, err := ComputePlacementStatus(obj, clusters, false)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if selectedNames.Len() != 2 {
+		t.Fatalf("Expected 2 clusters to be selected, got %v", selectedNames)
+	}
+
+	// Record the propagation to the chosen clusters.
+	var statusClusters []interface{}
+	for _, name := range sets.List(selectedNames) {
+		statusClusters = append(statusClusters, map[string]interface{}{"name": name})
+	}
+	err = unstructured.SetNestedSlice(obj.Object, statusClusters, "status", "clusters")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	flapping := sets.List(selectedNames)[0]
+	for _, cluster := range clusters {
+		if cluster.Name == flapping {
+			cluster.Status.Conditions = nil
+		}
+	}
+
+	// The resource stays on a chosen cluster while it is not ready.
+	names, _, err := ComputePlacementStatus(obj, clusters, false)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !names.Equal(selectedNames) {
+		t.Fatalf("Expected clusters %v to stay selected while %q is not ready, got %v", sets.List(selectedNames), flapping, sets.List(names))
+	}
+
+	// A resource that has not been propagated is not placed on a
+	// cluster that is not ready.
+	names, placementStatus, err := ComputePlacementStatus(newResource(), clusters, false)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if names.Len() != 2 || names.Has(flapping) {
+		t.Fatalf("Expected 2 clusters other than %q to be selected, got %v", flapping, sets.List(names))
+	}
+	for _, clusterStatus := range placementStatus.Clusters {
+		if clusterStatus.Name == flapping && clusterStatus.Reason != PlacementClusterNotReady {
+			t.Fatalf("Expected reason %q for cluster %q, got %q", PlacementClusterNotReady, flapping, clusterStatus.Reason)
+		}
+	}
+
+	// The selection is unchanged once the cluster is ready again.
+	for _, cluster := range clusters {
+		if cluster.Name == flapping {
+			cluster.Status = newReadyCluster(flapping).Status
+		}
+	}
+	names, _, err = ComputePlacementStatus(obj, clusters, false)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !names.Equal(selectedNames) {
+		t.Fatalf("Expected clusters %v to be selected, got %v", sets.List(selectedNames), sets.List(names))
+	}
+}
+
+func newReadyCluster(name string) *fedv1b1.KubeFedCluster {
+	return &fedv1b1.KubeFedCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: fedv1b1.KubeFedClusterStatus{
+			Conditions: []fedv1b1.ClusterCondition{{
+				Type:   fedcommon.ClusterReady,
+				Status: corev1.ConditionTrue,
+			}},
+		},
+	}
+}
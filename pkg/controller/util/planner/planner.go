@@ -27,6 +27,9 @@ import (
 // federated clusters.
 type Planner struct {
 	preferences *fedschedulingv1a1.ReplicaSchedulingPreference
+	// The topology domains of the clusters, if the replicas are
+	// distributed over a topology.
+	clusterDomains map[string]string
 }
 
 type namedClusterPreferences struct {
@@ -52,6 +55,16 @@ func NewPlanner(preferences *fedschedulingv1a1.ReplicaSchedulingPreference) *Pla
 	}
 }
 
+// NewPlannerWithDomains returns a planner that distributes replicas
+// over the given topology domains of the clusters according to the
+// topology of the preferences.
+func NewPlannerWithDomains(preferences *fedschedulingv1a1.ReplicaSchedulingPreference, clusterDomains map[string]string) *Planner {
+	return &Planner{
+		preferences:    preferences,
+		clusterDomains: clusterDomains,
+	}
+}
+
 // Distribute the desired number of replicas among the given cluster according to the planner preferences.
 // The function tries its best to assign each cluster the preferred number of replicas, however if
 // sum of MinReplicas for all cluster is bigger than replicasToDistribute (TotalReplicas) then some cluster
@@ -60,6 +73,8 @@ func NewPlanner(preferences *fedschedulingv1a1.ReplicaSchedulingPreference) *Pla
 // It can also use the current replica count and estimated capacity to provide better planning and
 // adhere to rebalance policy. To avoid prioritization of clusters with smaller lexicographical names
 // a semi-random string (like replica set name) can be provided.
+// Clusters with a higher priority are filled before clusters with a lower priority, and if the
+// preferences have a topology the replicas are first distributed over the topology domains of clusters.
 // Two maps are returned:
 //   - a map that contains information how many replicas will be possible to run in a cluster.
 //   - a map that contains information how many extra replicas would be nice to schedule in a cluster so,
@@ -68,7 +83,6 @@ func (p *Planner) Plan(availableClusters []string, currentReplicaCount map[strin
 	estimatedCapacity map[string]int64, replicaSetKey string) (map[string]int64, map[string]int64, error) {
 	preferences := make([]*namedClusterPreferences, 0, len(availableClusters))
 	plan := make(map[string]int64, len(preferences))
-	overflow := make(map[string]int64, len(preferences))
 
 	named := func(name string, pref fedschedulingv1a1.ClusterPreferences) (*namedClusterPreferences, error) {
 		// Seems to work better than addler for our case.
@@ -108,11 +122,266 @@ func (p *Planner) Plan(availableClusters []string, currentReplicaCount map[strin
 			}
 		}
 	}
-	sort.Sort(byWeight(preferences))
 
 	// This is the requested total replicas in preferences
 	remainingReplicas := int64(p.preferences.Spec.TotalReplicas)
 
+	var distributed, overflow map[string]int64
+	if topology := p.preferences.Spec.Topology; topology != nil {
+		distributed, overflow, remainingReplicas = p.distributeOverDomains(preferences, topology, remainingReplicas,
+			currentReplicaCount, estimatedCapacity, replicaSetKey)
+	} else {
+		distributed, overflow, remainingReplicas = p.distributeByPriority(preferences, remainingReplicas,
+			currentReplicaCount, estimatedCapacity)
+	}
+	for clusterName, replicas := range distributed {
+		plan[clusterName] = replicas
+	}
+
+	if p.preferences.Spec.Rebalance {
+		return plan, overflow, nil
+	} else {
+		// If rebalance = false then overflow is trimmed at the level
+		// of replicas that it failed to place somewhere.
+		newOverflow := make(map[string]int64)
+		for key, value := range overflow {
+			value = minInt64(value, remainingReplicas)
+			if value > 0 {
+				newOverflow[key] = value
+			}
+		}
+		return plan, newOverflow, nil
+	}
+}
+
+// distributeOverDomains distributes the given replicas over the topology
+// domains of the clusters of the given preferences. Replicas are first
+// assigned to each domain up to the minimum per domain and then, if the
+// topology is spread, evenly over the domains. Otherwise the remaining
+// replicas are distributed by priority with the replicas already
+// assigned to each cluster as its minimum. The replicas that could not
+// be distributed are returned with the distribution.
+func (p *Planner) distributeOverDomains(preferences []*namedClusterPreferences, topology *fedschedulingv1a1.ReplicaTopology,
+	replicas int64, currentReplicaCount, estimatedCapacity map[string]int64, replicaSetKey string) (map[string]int64, map[string]int64, int64) {
+	domainPreferences := make(map[string][]*namedClusterPreferences)
+	var domains []string
+	var unknownDomain []*namedClusterPreferences
+	for _, preference := range preferences {
+		domain := p.clusterDomains[preference.clusterName]
+		if domain == "" {
+			unknownDomain = append(unknownDomain, preference)
+			continue
+		}
+		if _, ok := domainPreferences[domain]; !ok {
+			domains = append(domains, domain)
+		}
+		domainPreferences[domain] = append(domainPreferences[domain], preference)
+	}
+	// Domains are ordered by a hash, as clusters are, to avoid
+	// preferring the alphabetically smallest domain for a replica that
+	// cannot be distributed evenly.
+	domainHashes := make(map[string]uint32, len(domains))
+	for _, domain := range domains {
+		hasher := fnv.New32()
+		// Writing to the hash never fails.
+		_, _ = hasher.Write([]byte(domain))
+		_, _ = hasher.Write([]byte(replicaSetKey))
+		domainHashes[domain] = hasher.Sum32()
+	}
+	sort.Slice(domains, func(i, j int) bool {
+		return domainHashes[domains[i]] < domainHashes[domains[j]]
+	})
+
+	spreader := &domainSpreader{
+		planner:             p,
+		domains:             domains,
+		domainPreferences:   domainPreferences,
+		currentReplicaCount: currentReplicaCount,
+		estimatedCapacity:   estimatedCapacity,
+		targets:             make(map[string]int64),
+		plans:               make(map[string]map[string]int64),
+		overflows:           make(map[string]map[string]int64),
+	}
+	if topology.MinReplicasPerDomain > 0 {
+		replicas = spreader.spread(replicas, topology.MinReplicasPerDomain)
+	}
+
+	if !topology.Spread {
+		// The replicas assigned to each cluster are retained as its
+		// minimum.
+		minPreferences := make([]*namedClusterPreferences, 0, len(preferences))
+		for _, preference := range preferences {
+			preference := *preference
+			for _, plan := range spreader.plans {
+				if replicas, ok := plan[preference.clusterName]; ok && replicas > preference.MinReplicas {
+					preference.MinReplicas = replicas
+				}
+			}
+			minPreferences = append(minPreferences, &preference)
+		}
+		return p.distributeByPriority(minPreferences, int64(p.preferences.Spec.TotalReplicas), currentReplicaCount, estimatedCapacity)
+	}
+
+	replicas = spreader.spread(replicas, -1)
+	plan := make(map[string]int64)
+	overflow := make(map[string]int64)
+	if len(unknownDomain) > 0 {
+		var unknownPlan, unknownOverflow map[string]int64
+		unknownPlan, unknownOverflow, replicas = p.distributeByPriority(unknownDomain, replicas, currentReplicaCount, estimatedCapacity)
+		mergeReplicas(plan, unknownPlan)
+		mergeReplicas(overflow, unknownOverflow)
+	}
+	for _, domain := range domains {
+		// Domains without replicas are planned for their minimum
+		// replicas and running replicas.
+		if _, ok := spreader.plans[domain]; !ok {
+			spreader.plans[domain], spreader.overflows[domain], _ = p.distributeByPriority(domainPreferences[domain], 0,
+				currentReplicaCount, estimatedCapacity)
+		}
+		mergeReplicas(plan, spreader.plans[domain])
+		mergeReplicas(overflow, spreader.overflows[domain])
+	}
+	return plan, overflow, replicas
+}
+
+// domainSpreader spreads replicas evenly over topology domains.
+type domainSpreader struct {
+	planner             *Planner
+	domains             []string
+	domainPreferences   map[string][]*namedClusterPreferences
+	currentReplicaCount map[string]int64
+	estimatedCapacity   map[string]int64
+
+	// The replicas assigned to each domain.
+	targets map[string]int64
+	// The distribution of the replicas assigned to each domain over its
+	// clusters.
+	plans     map[string]map[string]int64
+	overflows map[string]map[string]int64
+}
+
+// spread assigns the given replicas evenly to the domains, up to the
+// given limit of replicas per domain if it is not negative, and returns
+// the replicas that could not be assigned. A domain whose clusters
+// cannot take their share leaves it to the other domains.
+func (s *domainSpreader) spread(replicas, limit int64) int64 {
+	var active []string
+	for _, domain := range s.domains {
+		if limit < 0 || s.targets[domain] < limit {
+			active = append(active, domain)
+		}
+	}
+	for replicas > 0 && len(active) > 0 {
+		share := replicas / int64(len(active))
+		extra := replicas % int64(len(active))
+		var next []string
+		for i, domain := range active {
+			add := share
+			if int64(i) < extra {
+				add++
+			}
+			if limit >= 0 {
+				add = minInt64(add, limit-s.targets[domain])
+			}
+			if add > 0 {
+				target := s.targets[domain] + add
+				var remaining int64
+				s.plans[domain], s.overflows[domain], remaining = s.planner.distributeByPriority(s.domainPreferences[domain], target,
+					s.currentReplicaCount, s.estimatedCapacity)
+				s.targets[domain] = target - remaining
+				replicas -= add - remaining
+				if remaining > 0 {
+					// The clusters of the domain are full.
+					continue
+				}
+			}
+			if limit < 0 || s.targets[domain] < limit {
+				next = append(next, domain)
+			}
+		}
+		active = next
+	}
+	return replicas
+}
+
+// distributeByPriority distributes the given replicas among the clusters
+// of the given preferences in the order of their priority. The replicas
+// required by the minimum of clusters of lower priority, and their
+// running replicas if rebalance is false, are reserved for them. The
+// replicas that could not be distributed are returned with the
+// distribution.
+func (p *Planner) distributeByPriority(preferences []*namedClusterPreferences, replicas int64,
+	currentReplicaCount, estimatedCapacity map[string]int64) (map[string]int64, map[string]int64, int64) {
+	tiers := make(map[int64][]*namedClusterPreferences)
+	var priorities []int64
+	for _, preference := range preferences {
+		if _, ok := tiers[preference.Priority]; !ok {
+			priorities = append(priorities, preference.Priority)
+		}
+		tiers[preference.Priority] = append(tiers[preference.Priority], preference)
+	}
+	if len(priorities) <= 1 {
+		return p.distribute(preferences, replicas, currentReplicaCount, estimatedCapacity)
+	}
+	sort.Slice(priorities, func(i, j int) bool { return priorities[i] > priorities[j] })
+
+	reserved := make([]int64, len(priorities))
+	for i := len(priorities) - 2; i >= 0; i-- {
+		reserved[i] = reserved[i+1]
+		for _, preference := range tiers[priorities[i+1]] {
+			reserved[i] += p.reservedReplicas(preference, currentReplicaCount, estimatedCapacity)
+		}
+	}
+
+	plan := make(map[string]int64)
+	overflow := make(map[string]int64)
+	for i, priority := range priorities {
+		var tierReserved int64
+		for _, preference := range tiers[priority] {
+			tierReserved += p.reservedReplicas(preference, currentReplicaCount, estimatedCapacity)
+		}
+		budget := replicas - reserved[i]
+		if budget < tierReserved {
+			budget = minInt64(tierReserved, replicas)
+		}
+		tierPlan, tierOverflow, remaining := p.distribute(tiers[priority], budget, currentReplicaCount, estimatedCapacity)
+		mergeReplicas(plan, tierPlan)
+		mergeReplicas(overflow, tierOverflow)
+		replicas -= budget - remaining
+	}
+	return plan, overflow, replicas
+}
+
+// reservedReplicas returns the replicas that a cluster of a lower
+// priority keeps: its minimum and, if rebalance is false, its running
+// replicas, within its maximum and estimated capacity.
+func (p *Planner) reservedReplicas(preference *namedClusterPreferences, currentReplicaCount, estimatedCapacity map[string]int64) int64 {
+	reserved := preference.MinReplicas
+	if !p.preferences.Spec.Rebalance {
+		current := currentReplicaCount[preference.clusterName]
+		if preference.MaxReplicas != nil {
+			current = minInt64(current, *preference.MaxReplicas)
+		}
+		if current > reserved {
+			reserved = current
+		}
+	}
+	if capacity, hasCapacity := estimatedCapacity[preference.clusterName]; hasCapacity {
+		reserved = minInt64(reserved, capacity)
+	}
+	return reserved
+}
+
+// distribute distributes the given replicas among the clusters of the
+// given preferences by their weight, and returns the replicas that could
+// not be distributed with the distribution.
+func (p *Planner) distribute(preferences []*namedClusterPreferences, remainingReplicas int64,
+	currentReplicaCount, estimatedCapacity map[string]int64) (map[string]int64, map[string]int64, int64) {
+	preferences = append([]*namedClusterPreferences{}, preferences...)
+	sort.Sort(byWeight(preferences))
+	plan := make(map[string]int64, len(preferences))
+	overflow := make(map[string]int64, len(preferences))
+
 	// Assign each cluster the minimum number of replicas it requested.
 	for _, preference := range preferences {
 		min := minInt64(preference.MinReplicas, remainingReplicas)
@@ -227,19 +496,13 @@ func (p *Planner) Plan(availableClusters []string, currentReplicaCount map[strin
 		preferences = newPreferences
 	}
 
-	if p.preferences.Spec.Rebalance {
-		return plan, overflow, nil
-	} else {
-		// If rebalance = false then overflow is trimmed at the level
-		// of replicas that it failed to place somewhere.
-		newOverflow := make(map[string]int64)
-		for key, value := range overflow {
-			value = minInt64(value, remainingReplicas)
-			if value > 0 {
-				newOverflow[key] = value
-			}
-		}
-		return plan, newOverflow, nil
+	return plan, overflow, remainingReplicas
+}
+
+// mergeReplicas adds the given replicas per cluster to total.
+func mergeReplicas(total, replicas map[string]int64) {
+	for clusterName, count := range replicas {
+		total[clusterName] += count
 	}
 }
 
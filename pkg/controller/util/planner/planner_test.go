@@ -358,3 +358,158 @@ func TestWeight(t *testing.T) {
 		91, []string{"A", "B", "C", "D", "E"},
 		map[string]int64{"A": 10, "B": 25, "C": 21, "D": 10, "E": 25})
 }
+
+func TestPriority(t *testing.T) {
+	testCases := map[string]struct {
+		clusters         map[string]fedschedulingv1a1.ClusterPreferences
+		replicas         int64
+		rebalance        bool
+		existing         map[string]int64
+		capacity         map[string]int64
+		expected         map[string]int64
+		expectedOverflow map[string]int64
+	}{
+		"Clusters are filled to their maximum in the order of priority": {
+			clusters: map[string]fedschedulingv1a1.ClusterPreferences{
+				"A": {Weight: 1, Priority: 2, MaxReplicas: pint(5)},
+				"B": {Weight: 1, Priority: 1, MaxReplicas: pint(10)},
+				"C": {Weight: 1}},
+			replicas:  12,
+			rebalance: true,
+			expected:  map[string]int64{"A": 5, "B": 7, "C": 0},
+		},
+		"Replicas beyond the capacity of a cluster spill over": {
+			clusters: map[string]fedschedulingv1a1.ClusterPreferences{
+				"A": {Weight: 1, Priority: 1},
+				"B": {Weight: 1}},
+			replicas:         6,
+			rebalance:        true,
+			capacity:         map[string]int64{"A": 3},
+			expected:         map[string]int64{"A": 3, "B": 3},
+			expectedOverflow: map[string]int64{"A": 3},
+		},
+		"The minimum of clusters of lower priority is reserved": {
+			clusters: map[string]fedschedulingv1a1.ClusterPreferences{
+				"A": {Weight: 1, Priority: 1},
+				"B": {Weight: 1, MinReplicas: 2}},
+			replicas:  5,
+			rebalance: true,
+			expected:  map[string]int64{"A": 3, "B": 2},
+		},
+		"Replicas are distributed by weight among clusters of the same priority": {
+			clusters: map[string]fedschedulingv1a1.ClusterPreferences{
+				"A": {Weight: 1, Priority: 1},
+				"B": {Weight: 2, Priority: 1},
+				"C": {Weight: 1}},
+			replicas:  9,
+			rebalance: true,
+			expected:  map[string]int64{"A": 3, "B": 6, "C": 0},
+		},
+		"Running replicas of clusters of lower priority are not moved without rebalance": {
+			clusters: map[string]fedschedulingv1a1.ClusterPreferences{
+				"A": {Weight: 1, Priority: 1},
+				"B": {Weight: 1}},
+			replicas:         6,
+			existing:         map[string]int64{"B": 4},
+			expected:         map[string]int64{"A": 2, "B": 4},
+			expectedOverflow: map[string]int64{},
+		},
+	}
+
+	for testName, tc := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			planner := NewPlanner(&fedschedulingv1a1.ReplicaSchedulingPreference{
+				Spec: fedschedulingv1a1.ReplicaSchedulingPreferenceSpec{
+					Rebalance:     tc.rebalance,
+					Clusters:      tc.clusters,
+					TotalReplicas: int32(tc.replicas),
+				},
+			})
+			var clusterNames []string
+			for clusterName := range tc.clusters {
+				clusterNames = append(clusterNames, clusterName)
+			}
+			plan, overflow, err := planner.Plan(clusterNames, tc.existing, tc.capacity, "")
+			assert.Nil(t, err)
+			assert.EqualValues(t, tc.expected, plan)
+			if tc.expectedOverflow == nil {
+				tc.expectedOverflow = map[string]int64{}
+			}
+			assert.Equal(t, tc.expectedOverflow, overflow)
+		})
+	}
+}
+
+func TestTopology(t *testing.T) {
+	// A and B are in region r1, C in r2, D in r3, and the region of E is
+	// unknown.
+	clusterDomains := map[string]string{"A": "r1", "B": "r1", "C": "r2", "D": "r3"}
+	clusterNames := []string{"A", "B", "C", "D", "E"}
+
+	testCases := map[string]struct {
+		clusters map[string]fedschedulingv1a1.ClusterPreferences
+		topology *fedschedulingv1a1.ReplicaTopology
+		replicas int64
+		expected map[string]int64
+	}{
+		"Replicas are spread evenly over the domains": {
+			clusters: map[string]fedschedulingv1a1.ClusterPreferences{
+				"A": {Weight: 2},
+				"*": {Weight: 1}},
+			topology: &fedschedulingv1a1.ReplicaTopology{TopologyKey: "region", Spread: true},
+			replicas: 9,
+			expected: map[string]int64{"A": 2, "B": 1, "C": 3, "D": 3, "E": 0},
+		},
+		"Replicas that do not fit a domain are spread over the other domains": {
+			clusters: map[string]fedschedulingv1a1.ClusterPreferences{
+				"A": {Weight: 2},
+				"C": {Weight: 1, MaxReplicas: pint(1)},
+				"*": {Weight: 1}},
+			topology: &fedschedulingv1a1.ReplicaTopology{TopologyKey: "region", Spread: true},
+			replicas: 9,
+			expected: map[string]int64{"A": 3, "B": 1, "C": 1, "D": 4, "E": 0},
+		},
+		"Clusters of unknown domains get the replicas that do not fit the domains": {
+			clusters: map[string]fedschedulingv1a1.ClusterPreferences{
+				"E": {Weight: 1},
+				"*": {Weight: 1, MaxReplicas: pint(1)}},
+			topology: &fedschedulingv1a1.ReplicaTopology{TopologyKey: "region", Spread: true},
+			replicas: 6,
+			expected: map[string]int64{"A": 1, "B": 1, "C": 1, "D": 1, "E": 2},
+		},
+		"Each domain gets its minimum before the primary cluster is filled": {
+			clusters: map[string]fedschedulingv1a1.ClusterPreferences{
+				"A": {Weight: 1, Priority: 1},
+				"*": {Weight: 1}},
+			topology: &fedschedulingv1a1.ReplicaTopology{TopologyKey: "region", MinReplicasPerDomain: 1},
+			replicas: 6,
+			expected: map[string]int64{"A": 4, "B": 0, "C": 1, "D": 1, "E": 0},
+		},
+		"The minimum per domain is limited by the clusters of the domain": {
+			clusters: map[string]fedschedulingv1a1.ClusterPreferences{
+				"A": {Weight: 1, Priority: 1},
+				"C": {Weight: 1, MaxReplicas: pint(1)},
+				"*": {Weight: 1}},
+			topology: &fedschedulingv1a1.ReplicaTopology{TopologyKey: "region", MinReplicasPerDomain: 2},
+			replicas: 6,
+			expected: map[string]int64{"A": 3, "B": 0, "C": 1, "D": 2, "E": 0},
+		},
+	}
+
+	for testName, tc := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			planner := NewPlannerWithDomains(&fedschedulingv1a1.ReplicaSchedulingPreference{
+				Spec: fedschedulingv1a1.ReplicaSchedulingPreferenceSpec{
+					Rebalance:     true,
+					Clusters:      tc.clusters,
+					TotalReplicas: int32(tc.replicas),
+					Topology:      tc.topology,
+				},
+			}, clusterDomains)
+			plan, overflow, err := planner.Plan(clusterNames, map[string]int64{}, map[string]int64{}, "")
+			assert.Nil(t, err)
+			assert.EqualValues(t, tc.expected, plan)
+			assert.Equal(t, 0, len(overflow))
+		})
+	}
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// PodRequests returns the CPU and memory requested by a pod with the
+// given spec, as accounted by the scheduler: the requests of its
+// containers and sidecars, or of its largest init container if that is
+// larger, plus the overhead of the pod.
+func PodRequests(spec *corev1.PodSpec) corev1.ResourceList {
+	requests := corev1.ResourceList{}
+	for _, container := range spec.Containers {
+		addRequests(requests, container.Resources.Requests)
+	}
+
+	// Sidecars run alongside the init containers that follow them and
+	// the containers.
+	sidecars := corev1.ResourceList{}
+	for _, container := range spec.InitContainers {
+		if container.RestartPolicy != nil && *container.RestartPolicy == corev1.ContainerRestartPolicyAlways {
+			addRequests(requests, container.Resources.Requests)
+			addRequests(sidecars, container.Resources.Requests)
+			continue
+		}
+		initRequests := sidecars.DeepCopy()
+		addRequests(initRequests, container.Resources.Requests)
+		for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+			if quantity, ok := initRequests[name]; ok && quantity.Cmp(requests[name]) > 0 {
+				requests[name] = quantity
+			}
+		}
+	}
+
+	addRequests(requests, spec.Overhead)
+	return requests
+}
+
+// addRequests adds the CPU and memory of the given requests to total.
+func addRequests(total, requests corev1.ResourceList) {
+	for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+		quantity, ok := requests[name]
+		if !ok {
+			continue
+		}
+		sum := total[name]
+		sum.Add(quantity)
+		total[name] = sum
+	}
+}
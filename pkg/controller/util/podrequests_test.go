@@ -0,0 +1,85 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+)
+
+func TestPodRequests(t *testing.T) {
+	container := func(cpu, memory string) corev1.Container {
+		return corev1.Container{
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse(cpu),
+					corev1.ResourceMemory: resource.MustParse(memory),
+				},
+			},
+		}
+	}
+	sidecar := container("100m", "64Mi")
+	sidecar.RestartPolicy = ptr.To(corev1.ContainerRestartPolicyAlways)
+
+	testCases := map[string]struct {
+		spec           corev1.PodSpec
+		expectedCPU    string
+		expectedMemory string
+	}{
+		"Requests of the containers are summed": {
+			spec: corev1.PodSpec{
+				Containers: []corev1.Container{container("250m", "128Mi"), container("500m", "256Mi")},
+			},
+			expectedCPU:    "750m",
+			expectedMemory: "384Mi",
+		},
+		"A larger init container determines the requests": {
+			spec: corev1.PodSpec{
+				InitContainers: []corev1.Container{container("1", "64Mi")},
+				Containers:     []corev1.Container{container("250m", "128Mi")},
+			},
+			expectedCPU:    "1",
+			expectedMemory: "128Mi",
+		},
+		"Sidecars and overhead are added": {
+			spec: corev1.PodSpec{
+				InitContainers: []corev1.Container{sidecar},
+				Containers:     []corev1.Container{container("250m", "128Mi")},
+				Overhead: corev1.ResourceList{
+					corev1.ResourceCPU: resource.MustParse("50m"),
+				},
+			},
+			expectedCPU:    "400m",
+			expectedMemory: "192Mi",
+		},
+	}
+
+	for testName, tc := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			requests := PodRequests(&tc.spec)
+			if cpu := requests[corev1.ResourceCPU]; cpu.Cmp(resource.MustParse(tc.expectedCPU)) != 0 {
+				t.Errorf("Expected CPU %s, got %s", tc.expectedCPU, cpu.String())
+			}
+			if memory := requests[corev1.ResourceMemory]; memory.Cmp(resource.MustParse(tc.expectedMemory)) != 0 {
+				t.Errorf("Expected memory %s, got %s", tc.expectedMemory, memory.String())
+			}
+		})
+	}
+}
@@ -58,6 +58,34 @@ func ObjectNeedsUpdate(desiredObj, clusterObj *unstructured.Unstructured, record
 	return strings.HasPrefix(targetVersion, generationPrefix) && !ObjectMetaObjEquivalent(desiredObj, clusterObj)
 }
 
+// ObjectNeedsApply determines whether the given cluster object needs to
+// be updated with server-side apply according to the desired object and
+// the recorded version. An applied object only contains the fields owned
+// by KubeFed, so labels and annotations added to the cluster object by
+// other field managers are not considered to require an update.
+func ObjectNeedsApply(desiredObj, clusterObj *unstructured.Unstructured, recordedVersion string) bool {
+	targetVersion := ObjectVersion(clusterObj)
+
+	if recordedVersion != targetVersion {
+		return true
+	}
+
+	return strings.HasPrefix(targetVersion, generationPrefix) &&
+		!(stringMapContains(clusterObj.GetLabels(), desiredObj.GetLabels()) &&
+			stringMapContains(clusterObj.GetAnnotations(), desiredObj.GetAnnotations()))
+}
+
+// stringMapContains returns whether all the entries of subset are present
+// in m.
+func stringMapContains(m, subset map[string]string) bool {
+	for key, value := range subset {
+		if existing, ok := m[key]; !ok || existing != value {
+			return false
+		}
+	}
+	return true
+}
+
 // SortClusterVersions ASCII sorts the given cluster versions slice
 // based on cluster name.
 func SortClusterVersions(versions []fedv1a1.ClusterObjectVersion) {
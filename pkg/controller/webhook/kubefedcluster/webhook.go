@@ -22,13 +22,17 @@ import (
 	"net/http"
 
 	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/klog/v2"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	"sigs.k8s.io/kubefed/pkg/apis/core/v1beta1"
 	"sigs.k8s.io/kubefed/pkg/apis/core/v1beta1/validation"
+	"sigs.k8s.io/kubefed/pkg/controller/util"
 	"sigs.k8s.io/kubefed/pkg/controller/webhook"
 )
 
@@ -37,7 +41,13 @@ const (
 	resourcePluralName = "kubefedclusters"
 )
 
-type KubeFedClusterAdmissionHook struct{}
+type KubeFedClusterAdmissionHook struct {
+	// Reader used to retrieve the secrets referenced by a
+	// KubeFedCluster so that their contents can be validated, and the
+	// KubeFedConfig allowing exec credential commands. The secrets are
+	// not validated and exec credentials are rejected if nil.
+	Reader runtimeclient.Reader
+}
 
 var _ admission.Handler = &KubeFedClusterAdmissionHook{}
 
@@ -72,6 +82,65 @@ func (a *KubeFedClusterAdmissionHook) Handle(ctx context.Context, admissionSpec
 
 	isStatusSubResource := admissionSpec.SubResource == "status"
 	return webhook.Validate(func() field.ErrorList {
-		return validation.ValidateKubeFedCluster(admittingObject, isStatusSubResource)
+		var allowedExecCommands []string
+		if admittingObject.Spec.Exec != nil && !isStatusSubResource && a.Reader != nil {
+			var err error
+			allowedExecCommands, err = a.getAllowedExecCommands(ctx, admissionSpec.Namespace)
+			if err != nil {
+				return field.ErrorList{field.InternalError(field.NewPath("spec", "exec"), err)}
+			}
+		}
+		errs := validation.ValidateKubeFedCluster(admittingObject, isStatusSubResource, allowedExecCommands)
+		if len(errs) == 0 && !isStatusSubResource && a.Reader != nil {
+			errs = a.validateSecrets(ctx, admissionSpec.Namespace, admittingObject)
+		}
+		return errs
 	})
 }
+
+// validateSecrets validates the contents of the secrets referenced by
+// the given cluster in its namespace. Secrets that do not exist yet are not validated
+// since they may be created after the cluster.
+func (a *KubeFedClusterAdmissionHook) validateSecrets(ctx context.Context, namespace string, cluster *v1beta1.KubeFedCluster) field.ErrorList {
+	secret, err := a.getSecret(ctx, namespace, cluster.Spec.SecretRef.Name)
+	if err != nil {
+		return field.ErrorList{field.InternalError(field.NewPath("spec", "secretRef"), err)}
+	}
+	var caBundleSecret *corev1.Secret
+	if cluster.Spec.CABundleSecretRef != nil {
+		caBundleSecret, err = a.getSecret(ctx, namespace, cluster.Spec.CABundleSecretRef.Name)
+		if err != nil {
+			return field.ErrorList{field.InternalError(field.NewPath("spec", "caBundleSecretRef"), err)}
+		}
+	}
+	return validation.ValidateKubeFedClusterSecrets(&cluster.Spec, secret, caBundleSecret)
+}
+
+// getAllowedExecCommands returns the exec credential commands allowed
+// by the KubeFedConfig in the given namespace, if any.
+func (a *KubeFedClusterAdmissionHook) getAllowedExecCommands(ctx context.Context, namespace string) ([]string, error) {
+	fedConfig := &v1beta1.KubeFedConfig{}
+	err := a.Reader.Get(ctx, runtimeclient.ObjectKey{Namespace: namespace, Name: util.KubeFedConfigName}, fedConfig)
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return fedConfig.Spec.ExecCredentialCommands, nil
+}
+
+func (a *KubeFedClusterAdmissionHook) getSecret(ctx context.Context, namespace, name string) (*corev1.Secret, error) {
+	if name == "" {
+		return nil, nil
+	}
+	secret := &corev1.Secret{}
+	err := a.Reader.Get(ctx, runtimeclient.ObjectKey{Namespace: namespace, Name: name}, secret)
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return secret, nil
+}
@@ -46,6 +46,11 @@ const (
 	//
 	// RawResourceStatusCollection enables the collection of the status of target types when enabled
 	RawResourceStatusCollection featuregate.Feature = "RawResourceStatusCollection"
+
+	// alpha: v0.12
+	//
+	// CredentialRotation periodically replaces the service account tokens used to access member clusters.
+	CredentialRotation featuregate.Feature = "CredentialRotation"
 )
 
 func init() {
@@ -61,4 +66,5 @@ var DefaultKubeFedFeatureGates = map[featuregate.Feature]featuregate.FeatureSpec
 	SchedulerPreferences:        {Default: true, PreRelease: featuregate.Alpha},
 	PushReconciler:              {Default: true, PreRelease: featuregate.Beta},
 	RawResourceStatusCollection: {Default: false, PreRelease: featuregate.Beta},
+	CredentialRotation:          {Default: false, PreRelease: featuregate.Alpha},
 }
@@ -34,7 +34,43 @@ func federatedTypeValidationSchema(templateSchema map[string]v1.JSONSchemaProps)
 					// scheduling mechanism to explicitly indicate
 					// placement. If one or more clusters is provided,
 					// the clusterSelector field will be ignored.
-					"clusters": {
+					"clusters":        clusterReferencesSchema(),
+					"clusterSelector": clusterSelectorSchema(),
+					// Tolerations of the taints of clusters.
+					"tolerations": tolerationsSchema(),
+					// Constraints choosing among the clusters
+					// selected by the fields above.
+					"maxClusters": {
+						Type:    "integer",
+						Format:  "int64",
+						Minimum: ptr.To[float64](0),
+					},
+					"minClusters": {
+						Type:    "integer",
+						Format:  "int64",
+						Minimum: ptr.To[float64](0),
+					},
+					"topologySpread": {
+						Type: "object",
+						Properties: map[string]v1.JSONSchemaProps{
+							"topologyKey": {
+								Type:      "string",
+								MinLength: ptr.To[int64](1),
+							},
+						},
+						Required: []string{
+							"topologyKey",
+						},
+					},
+				},
+			},
+			util.RolloutStrategyField: {
+				Type: "object",
+				Properties: map[string]v1.JSONSchemaProps{
+					// Ordered waves of clusters to roll out changes
+					// to. Clusters not selected by any wave are rolled
+					// out to last.
+					"waves": {
 						Type: "array",
 						Items: &v1.JSONSchemaPropsOrArray{
 							Schema: &v1.JSONSchemaProps{
@@ -43,52 +79,60 @@ func federatedTypeValidationSchema(templateSchema map[string]v1.JSONSchemaProps)
 									"name": {
 										Type: "string",
 									},
-								},
-								Required: []string{
-									"name",
+									"clusters":        clusterReferencesSchema(),
+									"clusterSelector": clusterSelectorSchema(),
 								},
 							},
 						},
 					},
-					"clusterSelector": {
+					"maxUnavailableClusters": {
+						Type:    "integer",
+						Format:  "int64",
+						Minimum: ptr.To[float64](0),
+					},
+					"paused": {
+						Type: "boolean",
+					},
+					"waitForReady": {
+						Type: "boolean",
+					},
+				},
+			},
+			// How resources that have drifted from their desired
+			// state in member clusters are handled.
+			util.DriftPolicyField: {
+				Type: "string",
+				Enum: []v1.JSON{
+					{Raw: []byte(`"Revert"`)},
+					{Raw: []byte(`"Report"`)},
+					{Raw: []byte(`"Ignore"`)},
+				},
+			},
+			// Resources that must be propagated to a cluster and be
+			// ready before the target resource is created in it.
+			util.DependsOnField: {
+				Type: "array",
+				Items: &v1.JSONSchemaPropsOrArray{
+					Schema: &v1.JSONSchemaProps{
 						Type: "object",
 						Properties: map[string]v1.JSONSchemaProps{
-							"matchExpressions": {
-								Type: "array",
-								Items: &v1.JSONSchemaPropsOrArray{
-									Schema: &v1.JSONSchemaProps{
-										Type: "object",
-										Properties: map[string]v1.JSONSchemaProps{
-											"key": {
-												Type: "string",
-											},
-											"operator": {
-												Type: "string",
-											},
-											"values": {
-												Type: "array",
-												Items: &v1.JSONSchemaPropsOrArray{
-													Schema: &v1.JSONSchemaProps{
-														Type: "string",
-													},
-												},
-											},
-										},
-										Required: []string{
-											"key",
-											"operator",
-										},
-									},
-								},
+							"apiVersion": {
+								Type: "string",
 							},
-							"matchLabels": {
-								Type: "object",
-								AdditionalProperties: &v1.JSONSchemaPropsOrBool{
-									Schema: &v1.JSONSchemaProps{
-										Type: "string",
-									},
-								},
+							"kind": {
+								Type: "string",
+							},
+							"name": {
+								Type: "string",
 							},
+							"namespace": {
+								Type: "string",
+							},
+						},
+						Required: []string{
+							"apiVersion",
+							"kind",
+							"name",
 						},
 					},
 				},
@@ -99,9 +143,24 @@ func federatedTypeValidationSchema(templateSchema map[string]v1.JSONSchemaProps)
 					Schema: &v1.JSONSchemaProps{
 						Type: "object",
 						Properties: map[string]v1.JSONSchemaProps{
+							// Overrides target either the named cluster
+							// or the clusters matching the selector.
+							// Overrides for a cluster name take
+							// precedence.
 							"clusterName": {
 								Type: "string",
 							},
+							"clusterSelector": clusterSelectorSchema(),
+							// Merge patches applied after the JSON
+							// patches of all overrides.
+							"strategicMergePatch": {
+								XPreserveUnknownFields: ptr.To(true),
+								Type:                   "object",
+							},
+							"mergePatch": {
+								XPreserveUnknownFields: ptr.To(true),
+								Type:                   "object",
+							},
 							"clusterOverrides": {
 								Type: "array",
 								Items: &v1.JSONSchemaPropsOrArray{
@@ -219,6 +278,14 @@ func ValidationSchema(specProps v1.JSONSchemaProps) *v1.CustomResourceValidation
 											XPreserveUnknownFields: ptr.To(true),
 											Type:                   "object",
 										},
+										"driftedPaths": {
+											Type: "array",
+											Items: &v1.JSONSchemaPropsOrArray{
+												Schema: &v1.JSONSchemaProps{
+													Type: "string",
+												},
+											},
+										},
 									},
 									Required: []string{
 										"name",
@@ -226,6 +293,65 @@ func ValidationSchema(specProps v1.JSONSchemaProps) *v1.CustomResourceValidation
 								},
 							},
 						},
+						"rollout": {
+							Type: "object",
+							Properties: map[string]v1.JSONSchemaProps{
+								"phase": {
+									Type: "string",
+								},
+								"currentWave": {
+									Type: "integer",
+								},
+								"waves": {
+									Type: "integer",
+								},
+								"clusters": {
+									Type: "integer",
+								},
+								"updatedClusters": {
+									Type: "integer",
+								},
+								"readyClusters": {
+									Type: "integer",
+								},
+							},
+						},
+						"placement": {
+							Type: "object",
+							Properties: map[string]v1.JSONSchemaProps{
+								"clusters": {
+									Type: "array",
+									Items: &v1.JSONSchemaPropsOrArray{
+										Schema: &v1.JSONSchemaProps{
+											Type: "object",
+											Properties: map[string]v1.JSONSchemaProps{
+												"name": {
+													Type: "string",
+												},
+												"domain": {
+													Type: "string",
+												},
+												"selected": {
+													Type: "boolean",
+												},
+												"reason": {
+													Type: "string",
+												},
+											},
+											Required: []string{
+												"name",
+											},
+										},
+									},
+								},
+								"reason": {
+									Type: "string",
+								},
+								"message": {
+									Type: "string",
+								},
+							},
+						},
 						"observedGeneration": {
 							Format: "int64",
 							Type:   "integer",
@@ -244,3 +370,99 @@ func ValidationSchema(specProps v1.JSONSchemaProps) *v1.CustomResourceValidation
 		},
 	}
 }
+
+// clusterReferencesSchema returns the schema of a list of references
+// to clusters by name.
+func clusterReferencesSchema() v1.JSONSchemaProps {
+	return v1.JSONSchemaProps{
+		Type: "array",
+		Items: &v1.JSONSchemaPropsOrArray{
+			Schema: &v1.JSONSchemaProps{
+				Type: "object",
+				Properties: map[string]v1.JSONSchemaProps{
+					"name": {
+						Type: "string",
+					},
+				},
+				Required: []string{
+					"name",
+				},
+			},
+		},
+	}
+}
+
+// clusterSelectorSchema returns the schema of a label selector for
+// clusters.
+func clusterSelectorSchema() v1.JSONSchemaProps {
+	return v1.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]v1.JSONSchemaProps{
+			"matchExpressions": {
+				Type: "array",
+				Items: &v1.JSONSchemaPropsOrArray{
+					Schema: &v1.JSONSchemaProps{
+						Type: "object",
+						Properties: map[string]v1.JSONSchemaProps{
+							"key": {
+								Type: "string",
+							},
+							"operator": {
+								Type: "string",
+							},
+							"values": {
+								Type: "array",
+								Items: &v1.JSONSchemaPropsOrArray{
+									Schema: &v1.JSONSchemaProps{
+										Type: "string",
+									},
+								},
+							},
+						},
+						Required: []string{
+							"key",
+							"operator",
+						},
+					},
+				},
+			},
+			"matchLabels": {
+				Type: "object",
+				AdditionalProperties: &v1.JSONSchemaPropsOrBool{
+					Schema: &v1.JSONSchemaProps{
+						Type: "string",
+					},
+				},
+			},
+		},
+	}
+}
+
+// tolerationsSchema returns the schema of a list of tolerations of
+// the taints of clusters.
+func tolerationsSchema() v1.JSONSchemaProps {
+	return v1.JSONSchemaProps{
+		Type: "array",
+		Items: &v1.JSONSchemaPropsOrArray{
+			Schema: &v1.JSONSchemaProps{
+				Type: "object",
+				Properties: map[string]v1.JSONSchemaProps{
+					"key": {
+						Type: "string",
+					},
+					"operator": {
+						Type:    "string",
+						Pattern: "^(Exists|Equal)?$",
+					},
+					"value": {
+						Type: "string",
+					},
+					"effect": {
+						Type:    "string",
+						Pattern: "^(NoSchedule|NoExecute)?$",
+					},
+				},
+			},
+		},
+	}
+}
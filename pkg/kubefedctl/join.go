@@ -39,9 +39,12 @@ import (
 	"k8s.io/utils/ptr"
 	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 
+	"sigs.k8s.io/kubefed/pkg/apis/core/typeconfig"
 	fedv1b1 "sigs.k8s.io/kubefed/pkg/apis/core/v1beta1"
 	genericclient "sigs.k8s.io/kubefed/pkg/client/generic"
+	"sigs.k8s.io/kubefed/pkg/controller/clusterrbac"
 	ctlutil "sigs.k8s.io/kubefed/pkg/controller/util"
+	"sigs.k8s.io/kubefed/pkg/features"
 	"sigs.k8s.io/kubefed/pkg/kubefedctl/options"
 	"sigs.k8s.io/kubefed/pkg/kubefedctl/util"
 	"sigs.k8s.io/kubefed/pkg/metrics"
@@ -96,6 +99,7 @@ type joinFederationOptions struct {
 	hostClusterSecretName string
 	scope                 apiextv1.ResourceScope
 	errorOnExisting       bool
+	leastPrivilegeRBAC    bool
 }
 
 // Bind adds the join specific arguments to the flagset passed in as an
@@ -105,6 +109,8 @@ func (o *joinFederationOptions) Bind(flags *pflag.FlagSet) {
 		"Name of the secret where the cluster's credentials will be stored in the host cluster. This name should be a valid RFC 1035 label. If unspecified, defaults to a generated name containing the cluster name.")
 	flags.BoolVar(&o.errorOnExisting, "error-on-existing", true,
 		"Whether the join operation will throw an error if it encounters existing artifacts with the same name as those it's trying to create. If false, the join operation will update existing artifacts to match its own specification.")
+	flags.BoolVar(&o.leastPrivilegeRBAC, "least-privilege-rbac", false,
+		"Whether to limit the access of the KubeFed control plane to the joining cluster to the target types of enabled FederatedTypeConfigs instead of granting access to all resources. The access is kept in step by the control plane as types are enabled and disabled.")
 }
 
 // NewCmdJoin defines the `join` command that registers a cluster with
@@ -158,9 +164,9 @@ func (j *joinFederation) Complete(args []string) error {
 		klog.Fatal("host-cluster-name must be set if the name of the host cluster context contains one of \":\" or \"/\"")
 	}
 
-	klog.V(2).Infof("Args and flags: name %s, host: %s, host-system-namespace: %s, kubeconfig: %s, cluster-context: %s, secret-name: %s, dry-run: %v",
+	klog.V(2).Infof("Args and flags: name %s, host: %s, host-system-namespace: %s, kubeconfig: %s, cluster-context: %s, secret-name: %s, least-privilege-rbac: %v, dry-run: %v",
 		j.ClusterName, j.HostClusterContext, j.KubeFedNamespace, j.Kubeconfig, j.ClusterContext,
-		j.hostClusterSecretName, j.DryRun)
+		j.hostClusterSecretName, j.leastPrivilegeRBAC, j.DryRun)
 
 	return nil
 }
@@ -197,20 +203,23 @@ func (j *joinFederation) Run(cmdOut io.Writer, config util.FedConfig) error {
 	}
 
 	_, err = JoinCluster(hostConfig, clusterConfig, j.KubeFedNamespace,
-		hostClusterName, j.ClusterName, j.hostClusterSecretName, j.joinFederationOptions.scope, j.DryRun, j.errorOnExisting)
+		hostClusterName, j.ClusterName, j.hostClusterSecretName, j.joinFederationOptions.scope,
+		j.leastPrivilegeRBAC, j.DryRun, j.errorOnExisting)
 
 	return err
 }
 
 // JoinCluster registers a cluster with a KubeFed control plane. The
 // KubeFed namespace in the joining cluster will be the same as in the
-// host cluster.
+// host cluster. If leastPrivilegeRBAC is true, the control plane is
+// only granted access to the target types of the enabled
+// FederatedTypeConfigs in the joining cluster.
 func JoinCluster(hostConfig, clusterConfig *rest.Config, kubefedNamespace,
 	hostClusterName, joiningClusterName, hostClusterSecretName string,
-	scope apiextv1.ResourceScope, dryRun, errorOnExisting bool) (*fedv1b1.KubeFedCluster, error) {
+	scope apiextv1.ResourceScope, leastPrivilegeRBAC, dryRun, errorOnExisting bool) (*fedv1b1.KubeFedCluster, error) {
 	return joinClusterForNamespace(hostConfig, clusterConfig, kubefedNamespace,
 		kubefedNamespace, hostClusterName, joiningClusterName, hostClusterSecretName,
-		scope, dryRun, errorOnExisting)
+		scope, leastPrivilegeRBAC, dryRun, errorOnExisting)
 }
 
 // joinClusterForNamespace registers a cluster with a KubeFed control
@@ -218,7 +227,7 @@ func JoinCluster(hostConfig, clusterConfig *rest.Config, kubefedNamespace,
 // the joiningNamespace parameter.
 func joinClusterForNamespace(hostConfig, clusterConfig *rest.Config, kubefedNamespace,
 	joiningNamespace, hostClusterName, joiningClusterName, hostClusterSecretName string,
-	scope apiextv1.ResourceScope, dryRun, errorOnExisting bool) (*fedv1b1.KubeFedCluster, error) {
+	scope apiextv1.ResourceScope, leastPrivilegeRBAC, dryRun, errorOnExisting bool) (*fedv1b1.KubeFedCluster, error) {
 	start := time.Now()
 
 	hostClientset, err := util.HostClientset(hostConfig)
@@ -254,9 +263,26 @@ func joinClusterForNamespace(hostConfig, clusterConfig *rest.Config, kubefedName
 	}
 	klog.V(2).Infof("Created %s namespace in joining cluster", joiningNamespace)
 
+	// The role granting least-privilege access is recorded on the
+	// KubeFedCluster so that the control plane can keep it in step
+	// with the enabled types.
+	var rbacRoleName string
+	rules := clusterPolicyRules
+	if scope == apiextv1.NamespaceScoped {
+		rules = namespacedPolicyRules
+	}
+	if leastPrivilegeRBAC {
+		rbacRoleName = util.RoleName(util.ClusterServiceAccountName(joiningClusterName, hostClusterName))
+		rules, err = leastPrivilegePolicyRules(client, kubefedNamespace, scope, rbacRoleName)
+		if err != nil {
+			klog.V(2).Infof("Failed to determine policy rules for joining cluster: %v", err)
+			return nil, err
+		}
+	}
+
 	joiningClusterSATokenSecretName, err := createAuthorizedServiceAccount(clusterClientset,
 		joiningNamespace, joiningClusterName, hostClusterName,
-		scope, dryRun, errorOnExisting)
+		scope, rules, dryRun, errorOnExisting)
 	if err != nil {
 		return nil, err
 	}
@@ -291,7 +317,7 @@ func joinClusterForNamespace(hostConfig, clusterConfig *rest.Config, kubefedName
 	}
 
 	kubefedCluster, err := createKubeFedCluster(client, joiningClusterName, clusterConfig.Host,
-		secret.Name, kubefedNamespace, caBundle, disabledTLSValidations, proxyURL, dryRun, errorOnExisting)
+		secret.Name, kubefedNamespace, caBundle, disabledTLSValidations, proxyURL, rbacRoleName, dryRun, errorOnExisting)
 	if err != nil {
 		klog.V(2).Infof("Failed to create federated cluster resource: %v", err)
 		return nil, err
@@ -306,6 +332,49 @@ func joinClusterForNamespace(hostConfig, clusterConfig *rest.Config, kubefedName
 // TestOnlyJoinClusterForNamespace is exported for testing purposes only.
 var TestOnlyJoinClusterForNamespace = joinClusterForNamespace
 
+// leastPrivilegePolicyRules returns the rules granting the KubeFed
+// control plane access to the target types of the
+// FederatedTypeConfigs in the host cluster.
+func leastPrivilegePolicyRules(client genericclient.Client, kubefedNamespace string,
+	scope apiextv1.ResourceScope, roleName string) ([]rbacv1.PolicyRule, error) {
+	typeConfigList := &fedv1b1.FederatedTypeConfigList{}
+	err := client.List(context.TODO(), typeConfigList, kubefedNamespace)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to list FederatedTypeConfigs")
+	}
+	var typeConfigs []typeconfig.Interface
+	for i := range typeConfigList.Items {
+		typeConfig := &typeConfigList.Items[i]
+		if typeConfig.DeletionTimestamp != nil {
+			continue
+		}
+		fedv1b1.SetFederatedTypeConfigDefaults(typeConfig)
+		typeConfigs = append(typeConfigs, typeConfig)
+	}
+	rotateCredentials, err := credentialRotationEnabled(client, kubefedNamespace)
+	if err != nil {
+		return nil, err
+	}
+	return clusterrbac.PolicyRules(typeConfigs, scope, roleName, rotateCredentials), nil
+}
+
+// credentialRotationEnabled returns whether the CredentialRotation
+// feature is enabled by the KubeFedConfig in the given namespace.
+func credentialRotationEnabled(client genericclient.Client, kubefedNamespace string) (bool, error) {
+	fedConfig := &fedv1b1.KubeFedConfig{}
+	err := client.Get(context.TODO(), fedConfig, kubefedNamespace, ctlutil.KubeFedConfigName)
+	if err != nil {
+		return false, errors.Wrapf(err, "Failed to get KubeFedConfig %q", ctlutil.KubeFedConfigName)
+	}
+	enabled := features.DefaultKubeFedFeatureGates[features.CredentialRotation].Default
+	for _, gate := range fedConfig.Spec.FeatureGates {
+		if gate.Name == string(features.CredentialRotation) {
+			enabled = gate.Configuration == fedv1b1.ConfigurationEnabled
+		}
+	}
+	return enabled, nil
+}
+
 // performPreflightChecks checks that the host and joining clusters are in
 // a consistent state.
 func performPreflightChecks(clusterClientset kubeclient.Interface, name, hostClusterName,
@@ -333,7 +402,7 @@ func performPreflightChecks(clusterClientset kubeclient.Interface, name, hostClu
 // the cluster and secret.
 func createKubeFedCluster(client genericclient.Client, joiningClusterName, apiEndpoint,
 	secretName, kubefedNamespace string, caBundle []byte, disabledTLSValidations []fedv1b1.TLSValidation,
-	proxyURL string, dryRun, errorOnExisting bool) (*fedv1b1.KubeFedCluster, error) {
+	proxyURL, rbacRoleName string, dryRun, errorOnExisting bool) (*fedv1b1.KubeFedCluster, error) {
 	fedCluster := &fedv1b1.KubeFedCluster{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: kubefedNamespace,
@@ -349,6 +418,9 @@ func createKubeFedCluster(client genericclient.Client, joiningClusterName, apiEn
 			ProxyURL:               proxyURL,
 		},
 	}
+	if rbacRoleName != "" {
+		fedCluster.Annotations = map[string]string{clusterrbac.RoleAnnotation: rbacRoleName}
+	}
 
 	if dryRun {
 		return fedCluster, nil
@@ -365,6 +437,14 @@ func createKubeFedCluster(client genericclient.Client, joiningClusterName, apiEn
 	case err == nil:
 		patch := runtimeclient.MergeFrom(existingFedCluster.DeepCopy())
 		existingFedCluster.Spec = fedCluster.Spec
+		if rbacRoleName != "" {
+			if existingFedCluster.Annotations == nil {
+				existingFedCluster.Annotations = make(map[string]string)
+			}
+			existingFedCluster.Annotations[clusterrbac.RoleAnnotation] = rbacRoleName
+		} else {
+			delete(existingFedCluster.Annotations, clusterrbac.RoleAnnotation)
+		}
 		err := client.Patch(context.TODO(), existingFedCluster, patch)
 		if err != nil {
 			klog.V(2).Infof("Could not update federated cluster %s due to %v", fedCluster.Name, err)
@@ -419,12 +499,12 @@ func createKubeFedNamespace(clusterClientset kubeclient.Interface, kubefedNamesp
 }
 
 // createAuthorizedServiceAccount creates a service account and service account token secret
-// and grants the privileges required by the KubeFed control plane to manage
-// resources in the joining cluster.  The name of the created service
+// and grants the privileges described by rules to the KubeFed control plane
+// to manage resources in the joining cluster.  The name of the created service
 // account is returned on success.
 func createAuthorizedServiceAccount(joiningClusterClientset kubeclient.Interface,
 	namespace, joiningClusterName, hostClusterName string,
-	scope apiextv1.ResourceScope, dryRun, errorOnExisting bool) (saTokenSecretName string, err error) {
+	scope apiextv1.ResourceScope, rules []rbacv1.PolicyRule, dryRun, errorOnExisting bool) (saTokenSecretName string, err error) {
 	klog.V(2).Infof("Creating service account in joining cluster: %s", joiningClusterName)
 
 	saName, err := createServiceAccount(joiningClusterClientset, namespace,
@@ -450,7 +530,7 @@ func createAuthorizedServiceAccount(joiningClusterClientset kubeclient.Interface
 	if scope == apiextv1.NamespaceScoped {
 		klog.V(2).Infof("Creating role and binding for service account: %s in joining cluster: %s", saName, joiningClusterName)
 
-		err = createRoleAndBinding(joiningClusterClientset, saName, namespace, joiningClusterName, dryRun, errorOnExisting)
+		err = createRoleAndBinding(joiningClusterClientset, saName, namespace, joiningClusterName, rules, dryRun, errorOnExisting)
 		if err != nil {
 			klog.V(2).Infof("Error creating role and binding for service account: %s in joining cluster: %s due to: %v", saName, joiningClusterName, err)
 			return "", err
@@ -474,7 +554,7 @@ func createAuthorizedServiceAccount(joiningClusterClientset kubeclient.Interface
 	} else {
 		klog.V(2).Infof("Creating cluster role and binding for service account: %s in joining cluster: %s", saName, joiningClusterName)
 
-		err = createClusterRoleAndBinding(joiningClusterClientset, saName, namespace, joiningClusterName, dryRun, errorOnExisting)
+		err = createClusterRoleAndBinding(joiningClusterClientset, saName, namespace, joiningClusterName, rules, dryRun, errorOnExisting)
 		if err != nil {
 			klog.V(2).Infof("Error creating cluster role and binding for service account: %s in joining cluster: %s due to: %v",
 				saName, joiningClusterName, err)
@@ -576,9 +656,10 @@ func bindingSubjects(saName, namespace string) []rbacv1.Subject {
 
 // createClusterRoleAndBinding creates an RBAC cluster role and
 // binding that allows the service account identified by saName to
-// access all resources in all namespaces in the cluster associated
+// access the resources described by rules in the cluster associated
 // with clientset.
-func createClusterRoleAndBinding(clientset kubeclient.Interface, saName, namespace, clusterName string, dryRun, errorOnExisting bool) error {
+func createClusterRoleAndBinding(clientset kubeclient.Interface, saName, namespace, clusterName string,
+	rules []rbacv1.PolicyRule, dryRun, errorOnExisting bool) error {
 	if dryRun {
 		return nil
 	}
@@ -589,7 +670,7 @@ func createClusterRoleAndBinding(clientset kubeclient.Interface, saName, namespa
 		ObjectMeta: metav1.ObjectMeta{
 			Name: roleName,
 		},
-		Rules: clusterPolicyRules,
+		Rules: rules,
 	}
 	existingRole, err := clientset.RbacV1().ClusterRoles().Get(context.Background(), roleName, metav1.GetOptions{})
 	switch {
@@ -616,7 +697,6 @@ func createClusterRoleAndBinding(clientset kubeclient.Interface, saName, namespa
 		}
 	}
 
-	// TODO: This should limit its access to only necessary resources.
 	binding := &rbacv1.ClusterRoleBinding{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: roleName,
@@ -673,9 +753,10 @@ func createClusterRoleAndBinding(clientset kubeclient.Interface, saName, namespa
 }
 
 // createRoleAndBinding creates an RBAC role and binding
-// that allows the service account identified by saName to access all
-// resources in the specified namespace.
-func createRoleAndBinding(clientset kubeclient.Interface, saName, namespace, clusterName string, dryRun, errorOnExisting bool) error {
+// that allows the service account identified by saName to access the
+// resources described by rules in the specified namespace.
+func createRoleAndBinding(clientset kubeclient.Interface, saName, namespace, clusterName string,
+	rules []rbacv1.PolicyRule, dryRun, errorOnExisting bool) error {
 	if dryRun {
 		return nil
 	}
@@ -686,7 +767,7 @@ func createRoleAndBinding(clientset kubeclient.Interface, saName, namespace, clu
 		ObjectMeta: metav1.ObjectMeta{
 			Name: roleName,
 		},
-		Rules: namespacedPolicyRules,
+		Rules: rules,
 	}
 	existingRole, err := clientset.RbacV1().Roles(namespace).Get(context.Background(), roleName, metav1.GetOptions{})
 	switch {
@@ -79,7 +79,7 @@ var _ = Describe("Kubefedctl", func() {
 				hostClusterName,
 				joiningClusterName,
 				"secret",
-				v1.ClusterScoped, true, false)
+				v1.ClusterScoped, false, true, false)
 
 			Expect(err).NotTo(HaveOccurred())
 			Expect(kubefedCluster).To(Equal(expectedKubefedCluster))
@@ -119,7 +119,7 @@ var _ = Describe("Kubefedctl", func() {
 				hostClusterName,
 				joiningClusterName,
 				"",
-				v1.ClusterScoped, true, false)
+				v1.ClusterScoped, false, true, false)
 
 			Expect(err).NotTo(HaveOccurred())
 			Expect(kubefedClusterWithProxyURL).To(Equal(expectedKubefedClusterWithProxyURL))
@@ -0,0 +1,135 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/pmezard/go-difflib/difflib"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+
+	ctlutil "sigs.k8s.io/kubefed/pkg/controller/util"
+)
+
+// Metadata fields that are maintained by the API server and would only
+// add noise to a diff.
+var ignoredMetadataFields = []string{
+	"creationTimestamp",
+	"generation",
+	"managedFields",
+	"resourceVersion",
+	"selfLink",
+	"uid",
+}
+
+// DiffObjects returns a unified diff between the YAML of the object in
+// the named cluster and the object that would be written to it. Either
+// object may be nil to indicate creation or deletion. Status and
+// metadata maintained by the API server are ignored, and if both
+// objects are provided, fields that are only set in the cluster object
+// (e.g. those defaulted by the member cluster) are omitted. An empty
+// diff is returned if the objects are equivalent.
+func DiffObjects(clusterName string, clusterObj, desiredObj *unstructured.Unstructured) (string, error) {
+	var clusterContent, desiredContent map[string]interface{}
+	if clusterObj != nil {
+		clusterContent = normalizeObject(clusterObj)
+	}
+	if desiredObj != nil {
+		desiredContent = normalizeObject(desiredObj)
+	}
+	if clusterContent != nil && desiredContent != nil {
+		pruneClusterOnlyFields(clusterContent, desiredContent)
+	}
+
+	clusterYAML, err := objectYAML(clusterContent)
+	if err != nil {
+		return "", err
+	}
+	desiredYAML, err := objectYAML(desiredContent)
+	if err != nil {
+		return "", err
+	}
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        splitLines(clusterYAML),
+		B:        splitLines(desiredYAML),
+		FromFile: clusterName + "/current",
+		ToFile:   clusterName + "/desired",
+		Context:  3,
+	})
+}
+
+func normalizeObject(obj *unstructured.Unstructured) map[string]interface{} {
+	content := obj.DeepCopy().Object
+	delete(content, ctlutil.StatusField)
+	if metadata, ok := content[ctlutil.MetadataField].(map[string]interface{}); ok {
+		for _, field := range ignoredMetadataFields {
+			delete(metadata, field)
+		}
+	}
+	return content
+}
+
+// pruneClusterOnlyFields removes the fields of the cluster value that
+// are absent from the desired value. Lists are only pruned element-wise
+// if they have the same length.
+func pruneClusterOnlyFields(clusterValue, desiredValue interface{}) {
+	switch cluster := clusterValue.(type) {
+	case map[string]interface{}:
+		desired, ok := desiredValue.(map[string]interface{})
+		if !ok {
+			return
+		}
+		for key, value := range cluster {
+			desiredField, ok := desired[key]
+			if !ok {
+				delete(cluster, key)
+				continue
+			}
+			pruneClusterOnlyFields(value, desiredField)
+		}
+	case []interface{}:
+		desired, ok := desiredValue.([]interface{})
+		if !ok || len(cluster) != len(desired) {
+			return
+		}
+		for i := range cluster {
+			pruneClusterOnlyFields(cluster[i], desired[i])
+		}
+	}
+}
+
+func objectYAML(content map[string]interface{}) (string, error) {
+	if content == nil {
+		return "", nil
+	}
+	objYAML, err := yaml.Marshal(content)
+	if err != nil {
+		return "", errors.Wrap(err, "Failed to marshal object to YAML")
+	}
+	return string(objYAML), nil
+}
+
+func splitLines(content string) []string {
+	lines := strings.SplitAfter(content, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
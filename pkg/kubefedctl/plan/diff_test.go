@@ -0,0 +1,97 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestDiffObjects(t *testing.T) {
+	desiredObj := newConfigMap(map[string]interface{}{"key": "desired"})
+	clusterObj := newConfigMap(map[string]interface{}{"key": "current"})
+	clusterObj.SetUID("some-uid")
+	clusterObj.SetResourceVersion("42")
+	clusterObj.Object["status"] = map[string]interface{}{"phase": "Active"}
+	clusterObj.SetAnnotations(map[string]string{"defaulted": "true"})
+
+	testCases := map[string]struct {
+		clusterObj   *unstructured.Unstructured
+		desiredObj   *unstructured.Unstructured
+		expectedDiff string
+	}{
+		"Changed field is diffed while cluster-only fields are ignored": {
+			clusterObj: clusterObj,
+			desiredObj: desiredObj,
+			expectedDiff: `--- cluster1/current
++++ cluster1/desired
+@@ -1,6 +1,6 @@
+ apiVersion: v1
+ data:
+-  key: current
++  key: desired
+ kind: ConfigMap
+ metadata:
+   name: test
+`,
+		},
+		"Equivalent objects have an empty diff": {
+			clusterObj: clusterObj,
+			desiredObj: newConfigMap(map[string]interface{}{"key": "current"}),
+		},
+		"Creation diffs against nothing": {
+			desiredObj: desiredObj,
+			expectedDiff: `--- cluster1/current
++++ cluster1/desired
+@@ -0,0 +1,7 @@
++apiVersion: v1
++data:
++  key: desired
++kind: ConfigMap
++metadata:
++  name: test
++  namespace: test-ns
+`,
+		},
+	}
+
+	for testName, tc := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			diff, err := DiffObjects("cluster1", tc.clusterObj, tc.desiredObj)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedDiff, diff)
+		})
+	}
+}
+
+func newConfigMap(data map[string]interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata": map[string]interface{}{
+				"name":      "test",
+				"namespace": "test-ns",
+			},
+			"data": data,
+		},
+	}
+}
@@ -0,0 +1,317 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/kubefed/pkg/apis/core/typeconfig"
+	fedv1b1 "sigs.k8s.io/kubefed/pkg/apis/core/v1beta1"
+	genericclient "sigs.k8s.io/kubefed/pkg/client/generic"
+	synccontroller "sigs.k8s.io/kubefed/pkg/controller/sync"
+	"sigs.k8s.io/kubefed/pkg/controller/sync/dispatch"
+	"sigs.k8s.io/kubefed/pkg/controller/sync/status"
+	ctlutil "sigs.k8s.io/kubefed/pkg/controller/util"
+	"sigs.k8s.io/kubefed/pkg/kubefedctl/enable"
+	"sigs.k8s.io/kubefed/pkg/kubefedctl/options"
+	"sigs.k8s.io/kubefed/pkg/kubefedctl/util"
+)
+
+var (
+	planLong = `
+		Plan shows what the sync controller would do to propagate a
+		federated resource to member clusters, without doing it. For each
+		member cluster the operation (Create, Update, Delete,
+		RemoveManagedLabel or None) and the resulting propagation status
+		are shown, along with a diff between the resource in the cluster
+		and the resource that would be written to it after field retention
+		and overrides have been applied.
+
+		The type may be given as either the target type (e.g.
+		'deployments.apps') or the federated type (e.g.
+		'federateddeployments.types.kubefed.io'). The federated resource
+		and the resources in member clusters are read directly from the
+		API, so the plan reflects their current state.
+
+		Current context is assumed to be a Kubernetes cluster hosting
+		the kubefed control plane. Please use the --host-cluster-context
+		flag otherwise.`
+
+	planExample = `
+		# Show the plan for the federated deployment named "my-app" in namespace "my-ns"
+		kubefedctl plan deployments.apps my-app -n my-ns --host-cluster-context=cluster1
+
+		# Output the plan, including the rendered resources, as YAML
+		kubefedctl plan deploy my-app -n my-ns -o yaml`
+)
+
+type planResource struct {
+	options.GlobalSubcommandOptions
+	typeName          string
+	resourceName      string
+	resourceNamespace string
+	output            string
+}
+
+// Bind adds the plan specific arguments to the flagset passed in as an argument.
+func (p *planResource) Bind(flags *pflag.FlagSet) error {
+	flags.StringVarP(&p.resourceNamespace, "namespace", "n", "", "The namespace of the federated resource. Defaults to the namespace of the current context.")
+	flags.StringVarP(&p.output, "output", "o", "", "If provided, the plan is output to stdout in the provided format. Valid format is ['yaml'].")
+	return flags.MarkHidden("dry-run")
+}
+
+// Complete ensures that options are valid.
+func (p *planResource) Complete(args []string, config util.FedConfig) error {
+	if len(p.output) > 0 && p.output != "yaml" {
+		return errors.Errorf("Invalid value for --output: %s", p.output)
+	}
+
+	if len(args) == 0 {
+		return errors.New("TYPE-NAME is required")
+	}
+	p.typeName = args[0]
+
+	if len(args) == 1 {
+		return errors.New("RESOURCE-NAME is required")
+	}
+	p.resourceName = args[1]
+
+	if len(p.resourceNamespace) == 0 {
+		var err error
+		p.resourceNamespace, err = util.GetNamespace(p.HostClusterContext, p.Kubeconfig, config)
+		return err
+	}
+	return nil
+}
+
+// NewCmdPlan defines the `plan` command that shows the operations the
+// sync controller would perform for a federated resource.
+func NewCmdPlan(cmdOut io.Writer, config util.FedConfig) *cobra.Command {
+	opts := &planResource{}
+
+	cmd := &cobra.Command{
+		Use:     "plan TYPE-NAME RESOURCE-NAME",
+		Short:   "Show what propagation of a federated resource would do",
+		Long:    planLong,
+		Example: planExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			err := opts.Complete(args, config)
+			if err != nil {
+				klog.Fatalf("Error: %v", err)
+			}
+
+			err = opts.Run(cmdOut, config)
+			if err != nil {
+				klog.Fatalf("Error: %v", err)
+			}
+		},
+	}
+
+	flags := cmd.Flags()
+	opts.GlobalSubcommandBind(flags)
+	err := opts.Bind(flags)
+	if err != nil {
+		klog.Fatalf("Error: %v", err)
+	}
+
+	return cmd
+}
+
+// Run is the implementation of the `plan` command.
+func (p *planResource) Run(cmdOut io.Writer, config util.FedConfig) error {
+	hostConfig, err := config.HostConfig(p.HostClusterContext, p.Kubeconfig)
+	if err != nil {
+		return errors.Wrap(err, "Failed to get host cluster config")
+	}
+	client, err := genericclient.New(hostConfig)
+	if err != nil {
+		return errors.Wrap(err, "Failed to get kubefed clientset")
+	}
+
+	controllerConfig, err := p.controllerConfig(client, hostConfig)
+	if err != nil {
+		return err
+	}
+	typeConfig, err := p.typeConfig(client, hostConfig)
+	if err != nil {
+		return err
+	}
+
+	var fedNamespaceAPIResource *metav1.APIResource
+	if typeConfig.GetNamespaced() {
+		namespaceTypeConfig := &fedv1b1.FederatedTypeConfig{}
+		err = client.Get(context.TODO(), namespaceTypeConfig, p.KubeFedNamespace, ctlutil.NamespaceName)
+		if err != nil {
+			return errors.Wrapf(err, "Error retrieving FederatedTypeConfig %q", ctlutil.NamespaceName)
+		}
+		apiResource := namespaceTypeConfig.GetFederatedType()
+		fedNamespaceAPIResource = &apiResource
+	}
+
+	targetName := ctlutil.QualifiedName{Name: p.resourceName}
+	if typeConfig.GetNamespaced() {
+		targetName.Namespace = p.resourceNamespace
+	}
+	plan, err := synccontroller.PlanPropagation(controllerConfig, typeConfig, fedNamespaceAPIResource, targetName)
+	if err != nil {
+		return err
+	}
+
+	if p.output == "yaml" {
+		planYAML, err := yaml.Marshal(plan)
+		if err != nil {
+			return errors.Wrap(err, "Failed to marshal plan to YAML")
+		}
+		_, err = cmdOut.Write(planYAML)
+		return err
+	}
+	return writePlan(cmdOut, typeConfig.GetFederatedType().Kind, targetName, plan)
+}
+
+// controllerConfig returns the subset of the configuration of the
+// KubeFed control plane that affects propagation.
+func (p *planResource) controllerConfig(client genericclient.Client, hostConfig *rest.Config) (*ctlutil.ControllerConfig, error) {
+	fedConfig := &fedv1b1.KubeFedConfig{}
+	err := client.Get(context.TODO(), fedConfig, p.KubeFedNamespace, ctlutil.KubeFedConfigName)
+	if apierrors.IsNotFound(err) {
+		return nil, errors.Errorf(
+			"A KubeFedConfig named %q was not found in namespace %q. Is a KubeFed control plane running in this namespace?",
+			ctlutil.KubeFedConfigName, p.KubeFedNamespace)
+	} else if err != nil {
+		return nil, errors.Wrapf(err, "Error retrieving KubeFedConfig %q",
+			ctlutil.QualifiedName{Namespace: p.KubeFedNamespace, Name: ctlutil.KubeFedConfigName})
+	}
+
+	controllerConfig := &ctlutil.ControllerConfig{
+		KubeFedNamespaces: ctlutil.KubeFedNamespaces{
+			KubeFedNamespace: p.KubeFedNamespace,
+			TargetNamespace:  metav1.NamespaceAll,
+		},
+		KubeConfig: hostConfig,
+	}
+	if fedConfig.Spec.Scope == apiextv1.NamespaceScoped {
+		controllerConfig.TargetNamespace = p.KubeFedNamespace
+	}
+	if syncConfig := fedConfig.Spec.SyncController; syncConfig != nil && syncConfig.AdoptResources != nil {
+		controllerConfig.SkipAdoptingResources = *syncConfig.AdoptResources == fedv1b1.AdoptResourcesDisabled
+	}
+	if durationConfig := fedConfig.Spec.ControllerDuration; durationConfig != nil && durationConfig.CacheSyncTimeout != nil {
+		controllerConfig.CacheSyncTimeout = durationConfig.CacheSyncTimeout.Duration
+	}
+	return controllerConfig, nil
+}
+
+// typeConfig returns the FederatedTypeConfig for the requested type,
+// which may be either the target type or the federated type.
+func (p *planResource) typeConfig(client genericclient.Client, hostConfig *rest.Config) (typeconfig.Interface, error) {
+	apiResource, err := enable.LookupAPIResource(hostConfig, p.typeName, "")
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to find targeted %s type", p.typeName)
+	}
+	resolvedTypeName := typeconfig.GroupQualifiedName(*apiResource)
+	klog.V(2).Infof("API Resource for %s/%s found", resolvedTypeName, apiResource.Version)
+
+	if !util.IsFederatedAPIResource(apiResource.Kind, apiResource.Group) {
+		concreteTypeConfig := &fedv1b1.FederatedTypeConfig{}
+		err = client.Get(context.TODO(), concreteTypeConfig, p.KubeFedNamespace, resolvedTypeName)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Error retrieving FederatedTypeConfig %q", resolvedTypeName)
+		}
+		return concreteTypeConfig, nil
+	}
+
+	typeConfigs := &fedv1b1.FederatedTypeConfigList{}
+	err = client.List(context.TODO(), typeConfigs, p.KubeFedNamespace)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error getting FederatedTypeConfig list")
+	}
+	for i := range typeConfigs.Items {
+		typeConfig := &typeConfigs.Items[i]
+		if typeconfig.GroupQualifiedName(typeConfig.GetFederatedType()) == resolvedTypeName {
+			return typeConfig, nil
+		}
+	}
+	return nil, errors.Errorf("No FederatedTypeConfig found for federated type %q", resolvedTypeName)
+}
+
+// writePlan writes a summary of the plan followed by a diff for each
+// cluster whose resource would be changed.
+func writePlan(w io.Writer, kind string, targetName ctlutil.QualifiedName, plan *synccontroller.PropagationPlan) error {
+	fmt.Fprintf(w, "%s %q\n", kind, targetName)
+	fmt.Fprintf(w, "Selected clusters: %s\n", strings.Join(plan.SelectedClusters, ", "))
+	if plan.Rollout != nil {
+		fmt.Fprintf(w, "Rollout: %s (wave %d of %d, %d of %d clusters updated, %d ready)\n",
+			plan.Rollout.Phase, plan.Rollout.CurrentWave+1, plan.Rollout.Waves,
+			plan.Rollout.UpdatedClusters, plan.Rollout.Clusters, plan.Rollout.ReadyClusters)
+	}
+	fmt.Fprintln(w)
+
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "CLUSTER\tOPERATION\tSTATUS\tMESSAGE")
+	for _, clusterPlan := range plan.Clusters {
+		propStatus := string(clusterPlan.Status)
+		if clusterPlan.Status == status.ClusterPropagationOK {
+			propStatus = "OK"
+		}
+		message := clusterPlan.Message
+		if len(clusterPlan.DriftedPaths) > 0 {
+			message = fmt.Sprintf("%s (drifted: %s)", message, strings.Join(clusterPlan.DriftedPaths, ", "))
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", clusterPlan.ClusterName, clusterPlan.Operation, propStatus, message)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	for _, clusterPlan := range plan.Clusters {
+		desiredObj := clusterPlan.DesiredObject
+		switch clusterPlan.Operation {
+		case dispatch.OperationNone:
+			if clusterPlan.Status != status.Drifted {
+				continue
+			}
+		case dispatch.OperationRemoveManagedLabel:
+			// Only the managed label is removed.
+			continue
+		case dispatch.OperationDelete:
+			desiredObj = nil
+		}
+		diff, err := DiffObjects(clusterPlan.ClusterName, clusterPlan.ClusterObject, desiredObj)
+		if err != nil {
+			return err
+		}
+		if len(diff) > 0 {
+			fmt.Fprintf(w, "\n%s", diff)
+		}
+	}
+	return nil
+}
@@ -244,21 +244,25 @@ func deleteFederatedClusterAndSecret(hostClientset kubeclient.Interface, client
 		return errors.Wrapf(err, "Failed to get kubefed cluster \"%s/%s\"", kubefedNamespace, unjoiningClusterName)
 	}
 
-	err = hostClientset.CoreV1().Secrets(kubefedNamespace).Delete(
-		context.Background(), fedCluster.Spec.SecretRef.Name, metav1.DeleteOptions{},
-	)
-	switch {
-	case apierrors.IsNotFound(err):
-		klog.V(2).Infof("Secret \"%s/%s\" does not exist in the host cluster.", kubefedNamespace, fedCluster.Spec.SecretRef.Name)
-	case err != nil:
-		wrappedErr := errors.Wrapf(err, "Failed to delete secret \"%s/%s\" for unjoin cluster %q",
-			kubefedNamespace, fedCluster.Spec.SecretRef.Name, unjoiningClusterName)
-		if !forceDeletion {
-			return wrappedErr
+	// A cluster whose credentials are provided by an exec command
+	// may not reference a secret.
+	if fedCluster.Spec.SecretRef.Name != "" {
+		err = hostClientset.CoreV1().Secrets(kubefedNamespace).Delete(
+			context.Background(), fedCluster.Spec.SecretRef.Name, metav1.DeleteOptions{},
+		)
+		switch {
+		case apierrors.IsNotFound(err):
+			klog.V(2).Infof("Secret \"%s/%s\" does not exist in the host cluster.", kubefedNamespace, fedCluster.Spec.SecretRef.Name)
+		case err != nil:
+			wrappedErr := errors.Wrapf(err, "Failed to delete secret \"%s/%s\" for unjoin cluster %q",
+				kubefedNamespace, fedCluster.Spec.SecretRef.Name, unjoiningClusterName)
+			if !forceDeletion {
+				return wrappedErr
+			}
+			klog.V(2).Infof("%v", wrappedErr)
+		default:
+			klog.V(2).Infof("Deleted secret \"%s/%s\" for unjoin cluster %q", kubefedNamespace, fedCluster.Spec.SecretRef.Name, unjoiningClusterName)
 		}
-		klog.V(2).Infof("%v", wrappedErr)
-	default:
-		klog.V(2).Infof("Deleted secret \"%s/%s\" for unjoin cluster %q", kubefedNamespace, fedCluster.Spec.SecretRef.Name, unjoiningClusterName)
 	}
 
 	err = client.Delete(context.TODO(), fedCluster, fedCluster.Namespace, fedCluster.Name)
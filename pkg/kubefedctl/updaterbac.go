@@ -0,0 +1,178 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubefedctl
+
+import (
+	"context"
+	"io"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
+
+	fedv1b1 "sigs.k8s.io/kubefed/pkg/apis/core/v1beta1"
+	genericclient "sigs.k8s.io/kubefed/pkg/client/generic"
+	"sigs.k8s.io/kubefed/pkg/controller/clusterrbac"
+	"sigs.k8s.io/kubefed/pkg/kubefedctl/options"
+	"sigs.k8s.io/kubefed/pkg/kubefedctl/util"
+)
+
+var (
+	updateRBACLong = `
+		Update-rbac updates the least-privilege role granted to a
+		KubeFed control plane in a member cluster to match the
+		FederatedTypeConfigs enabled in the control plane. The
+		control plane is not permitted to update its own role, so
+		this command must be run with the credentials of an
+		administrator of the member cluster whenever a type is
+		enabled. Current context is assumed to be a Kubernetes
+		cluster hosting a KubeFed control plane. Please use the
+		--host-cluster-context flag otherwise.`
+	updateRBACExample = `
+		# Update the role granted to a KubeFed control plane in
+		# the member cluster foo, using the cluster context foo
+		# and the context name of the control plane's host
+		# cluster.
+		kubefedctl update-rbac foo --host-cluster-context=bar`
+)
+
+type updateRBAC struct {
+	options.GlobalSubcommandOptions
+	options.CommonJoinOptions
+}
+
+// NewCmdUpdateRBAC defines the `update-rbac` command that updates the
+// least-privilege role granted to a KubeFed control plane in a member
+// cluster.
+func NewCmdUpdateRBAC(cmdOut io.Writer, config util.FedConfig) *cobra.Command {
+	opts := &updateRBAC{}
+
+	cmd := &cobra.Command{
+		Use:     "update-rbac CLUSTER_NAME --host-cluster-context=HOST_CONTEXT",
+		Short:   "Update the role granted to a KubeFed control plane in a member cluster",
+		Long:    updateRBACLong,
+		Example: updateRBACExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			err := opts.Complete(args)
+			if err != nil {
+				klog.Fatalf("Error: %v", err)
+			}
+
+			err = opts.Run(cmdOut, config)
+			if err != nil {
+				klog.Fatalf("Error: %v", err)
+			}
+		},
+	}
+
+	flags := cmd.Flags()
+	opts.GlobalSubcommandBind(flags)
+	opts.CommonSubcommandBind(flags)
+
+	return cmd
+}
+
+// Complete ensures that options are valid and marshals them if necessary.
+func (u *updateRBAC) Complete(args []string) error {
+	err := u.SetName(args)
+	if err != nil {
+		return err
+	}
+
+	if u.ClusterContext == "" {
+		klog.V(2).Infof("Defaulting cluster context to cluster name %s", u.ClusterName)
+		u.ClusterContext = u.ClusterName
+	}
+
+	klog.V(2).Infof("Args and flags: name %s, host-cluster-context: %s, host-system-namespace: %s, kubeconfig: %s, cluster-context: %s, dry-run: %v",
+		u.ClusterName, u.HostClusterContext, u.KubeFedNamespace, u.Kubeconfig, u.ClusterContext, u.DryRun)
+
+	return nil
+}
+
+// Run is the implementation of the `update-rbac` command.
+func (u *updateRBAC) Run(cmdOut io.Writer, config util.FedConfig) error {
+	hostClientConfig := config.GetClientConfig(u.HostClusterContext, u.Kubeconfig)
+	if err := u.SetHostClusterContextFromConfig(hostClientConfig); err != nil {
+		return err
+	}
+
+	hostConfig, err := hostClientConfig.ClientConfig()
+	if err != nil {
+		klog.V(2).Infof("Failed to get host cluster config: %v", err)
+		return err
+	}
+
+	clusterConfig, err := config.ClusterConfig(u.ClusterContext, u.Kubeconfig)
+	if err != nil {
+		klog.V(2).Infof("Failed to get member cluster config: %v", err)
+		return err
+	}
+
+	return UpdateClusterRBAC(hostConfig, clusterConfig, u.KubeFedNamespace, u.ClusterName, u.DryRun)
+}
+
+// UpdateClusterRBAC updates the least-privilege role granted to a
+// KubeFed control plane in the named member cluster to match the
+// enabled FederatedTypeConfigs. The clusterConfig must carry
+// credentials that are permitted to update the role.
+func UpdateClusterRBAC(hostConfig, clusterConfig *rest.Config, kubefedNamespace, clusterName string, dryRun bool) error {
+	client, err := genericclient.New(hostConfig)
+	if err != nil {
+		klog.V(2).Infof("Failed to get kubefed clientset: %v", err)
+		return err
+	}
+
+	fedCluster := &fedv1b1.KubeFedCluster{}
+	err = client.Get(context.TODO(), fedCluster, kubefedNamespace, clusterName)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to get KubeFedCluster %q", clusterName)
+	}
+	roleName := fedCluster.Annotations[clusterrbac.RoleAnnotation]
+	if roleName == "" {
+		return errors.Errorf("Cluster %q was not joined with least-privilege RBAC", clusterName)
+	}
+
+	scope, err := options.GetScopeFromKubeFedConfig(hostConfig, kubefedNamespace)
+	if err != nil {
+		return err
+	}
+
+	rules, err := leastPrivilegePolicyRules(client, kubefedNamespace, scope, roleName)
+	if err != nil {
+		return err
+	}
+
+	if dryRun {
+		return nil
+	}
+
+	clusterClientset, err := util.ClusterClientset(clusterConfig)
+	if err != nil {
+		klog.V(2).Infof("Failed to get member cluster clientset: %v", err)
+		return err
+	}
+
+	err = clusterrbac.UpdateRoleRules(clusterClientset, scope, kubefedNamespace, roleName, rules)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to update role %q in cluster %q", roleName, clusterName)
+	}
+	klog.V(2).Infof("Updated role %q in cluster %q", roleName, clusterName)
+	return nil
+}
@@ -88,6 +88,35 @@ var (
 		}, []string{"action"},
 	)
 
+	clusterOperationsQueued = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cluster_operations_queued",
+			Help: "Number of operations waiting to be run against a member cluster.",
+		}, []string{"cluster"},
+	)
+
+	clusterOperationsRunning = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cluster_operations_running",
+			Help: "Number of operations running against a member cluster.",
+		}, []string{"cluster"},
+	)
+
+	clusterOperationQueueDuration = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "cluster_operation_queue_duration_seconds",
+			Help:    "Time an operation waited to be run against a member cluster.",
+			Buckets: []float64{0.01, 0.05, 0.1, 0.5, 1.0, 2.5, 5.0, 7.5, 10.0, 12.5, 15.0, 17.5, 20.0, 22.5, 25.0, 27.5, 30.0, 50.0, 75.0, 100.0, 1000.0},
+		},
+	)
+
+	driftedResourceTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "drifted_resource_total",
+			Help: "Number of times a resource in a member cluster was found to have drifted from its desired state.",
+		}, []string{"kind", "cluster", "policy"},
+	)
+
 	controllerRuntimeReconcileDuration = prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
 			Name:    "controller_runtime_reconcile_duration_seconds",
@@ -157,6 +186,10 @@ func RegisterAll() {
 		joinedClusterDuration,
 		unjoinedClusterDuration,
 		dispatchOperationDuration,
+		clusterOperationsQueued,
+		clusterOperationsRunning,
+		clusterOperationQueueDuration,
+		driftedResourceTotal,
 		controllerRuntimeReconcileDuration,
 		controllerRuntimeReconcileDurationSummary,
 		ControllerRuntimeReconcileTotal,
@@ -201,6 +234,43 @@ func DispatchOperationDurationFromStart(action string, start time.Time) {
 	dispatchOperationDuration.WithLabelValues(action).Observe(duration.Seconds())
 }
 
+// ClusterOperationsQueuedInc increases by one the number of operations
+// queued for the given cluster
+func ClusterOperationsQueuedInc(cluster string) {
+	clusterOperationsQueued.WithLabelValues(cluster).Inc()
+}
+
+// ClusterOperationsQueuedDec decreases by one the number of operations
+// queued for the given cluster
+func ClusterOperationsQueuedDec(cluster string) {
+	clusterOperationsQueued.WithLabelValues(cluster).Dec()
+}
+
+// ClusterOperationsRunningInc increases by one the number of operations
+// running against the given cluster
+func ClusterOperationsRunningInc(cluster string) {
+	clusterOperationsRunning.WithLabelValues(cluster).Inc()
+}
+
+// ClusterOperationsRunningDec decreases by one the number of operations
+// running against the given cluster
+func ClusterOperationsRunningDec(cluster string) {
+	clusterOperationsRunning.WithLabelValues(cluster).Dec()
+}
+
+// ClusterOperationQueueDurationFromStart records the time an operation
+// was queued before being run
+func ClusterOperationQueueDurationFromStart(start time.Time) {
+	duration := time.Since(start)
+	clusterOperationQueueDuration.Observe(duration.Seconds())
+}
+
+// DriftedResourceInc increases by one the number of times a resource of the
+// given kind was found to have drifted in a cluster
+func DriftedResourceInc(kind, cluster, policy string) {
+	driftedResourceTotal.WithLabelValues(kind, cluster, policy).Inc()
+}
+
 // ClusterHealthStatusDurationFromStart records the duration of the cluster health status operation
 func ClusterHealthStatusDurationFromStart(start time.Time) {
 	duration := time.Since(start)
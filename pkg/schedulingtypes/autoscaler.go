@@ -0,0 +1,334 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schedulingtypes
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/pkg/errors"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	runtimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	fedschedulingv1a1 "sigs.k8s.io/kubefed/pkg/apis/scheduling/v1alpha1"
+	ctlutil "sigs.k8s.io/kubefed/pkg/controller/util"
+)
+
+const (
+	// The total replicas are not scaled while the ratio of the current
+	// to the target CPU utilization is within this tolerance of 1, as
+	// for a HorizontalPodAutoscaler.
+	autoscalingTolerance = 0.1
+
+	defaultScaleDownStabilizationSeconds = 300
+)
+
+var podMetricsListGVK = schema.GroupVersionKind{Group: "metrics.k8s.io", Version: "v1beta1", Kind: "PodMetricsList"}
+
+// podCPUMetrics is the CPU usage and requests in millicores of the pods
+// of the target of an RSP.
+type podCPUMetrics struct {
+	// Pods that are running and have metrics.
+	pods     int64
+	usage    int64
+	requests int64
+
+	// Pods that are running without metrics.
+	missingPods     int64
+	missingRequests int64
+
+	// Pods that are not running yet.
+	pendingPods     int64
+	pendingRequests int64
+}
+
+// autoscale updates the total replicas of the given RSP to the replicas
+// desired for the load of its target in the given clusters.
+func (s *ReplicaScheduler) autoscale(rsp *fedschedulingv1a1.ReplicaSchedulingPreference, plugin *Plugin,
+	qualifiedName ctlutil.QualifiedName, clusterNames []string) error {
+	autoscaling := rsp.Spec.Autoscaling
+
+	var desired int32
+	var utilization *int32
+	var err error
+	if autoscaling.TargetCPUUtilizationPercentage != nil {
+		var currentUtilization int32
+		desired, currentUtilization, err = s.desiredReplicasForCPU(rsp, plugin, qualifiedName, clusterNames)
+		utilization = &currentUtilization
+	} else {
+		desired, err = s.desiredReplicasForHPAs(qualifiedName, clusterNames)
+	}
+	if err != nil {
+		setCondition(rsp, fedschedulingv1a1.ScalingActive, metav1.ConditionFalse, FailedGetMetricsReason, err.Error())
+		return err
+	}
+	setCondition(rsp, fedschedulingv1a1.ScalingActive, metav1.ConditionTrue, ValidMetricFoundReason, "")
+
+	desired = clampReplicas(desired, autoscaling)
+	if rsp.Status.Autoscaling == nil {
+		rsp.Status.Autoscaling = &fedschedulingv1a1.ReplicaAutoscalingStatus{}
+	}
+	autoscalingStatus := rsp.Status.Autoscaling
+	autoscalingStatus.DesiredReplicas = desired
+	autoscalingStatus.CurrentCPUUtilizationPercentage = utilization
+
+	window := time.Duration(defaultScaleDownStabilizationSeconds) * time.Second
+	if autoscaling.ScaleDownStabilizationSeconds != nil {
+		window = time.Duration(*autoscaling.ScaleDownStabilizationSeconds) * time.Second
+	}
+	replicas := stabilizedReplicas(rsp.Spec.TotalReplicas, desired, autoscalingStatus.LastScaleTime, window, time.Now())
+	if replicas == rsp.Spec.TotalReplicas {
+		return nil
+	}
+
+	// The time of the scale is persisted with the desired replicas
+	// before the spec is changed, so that the stabilization window is
+	// not lost if the status cannot be updated after the scale.
+	lastScaleTime := autoscalingStatus.LastScaleTime
+	now := metav1.Now()
+	autoscalingStatus.LastScaleTime = &now
+	err = s.client.UpdateStatus(context.Background(), rsp)
+	if err != nil {
+		autoscalingStatus.LastScaleTime = lastScaleTime
+		return errors.Wrap(err, "Failed to record the scale of the total replicas")
+	}
+
+	// The update of the spec returns the persisted status, which must
+	// not replace the status computed by this reconciliation.
+	status := rsp.Status.DeepCopy()
+	rsp.Spec.TotalReplicas = replicas
+	err = s.client.Update(context.Background(), rsp)
+	rsp.Status = *status
+	if err != nil {
+		return errors.Wrap(err, "Failed to update the total replicas")
+	}
+	return nil
+}
+
+// desiredReplicasForHPAs returns the sum of the desired replicas of the
+// HorizontalPodAutoscalers with the given name in the given clusters.
+func (s *ReplicaScheduler) desiredReplicasForHPAs(qualifiedName ctlutil.QualifiedName, clusterNames []string) (int32, error) {
+	var desired int32
+	found := false
+	for _, clusterName := range clusterNames {
+		client, err := s.podInformer.GetClientForCluster(clusterName)
+		if err != nil {
+			return 0, err
+		}
+		hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+		err = client.Get(context.Background(), hpa, qualifiedName.Namespace, qualifiedName.Name)
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return 0, errors.Wrapf(err, "Failed to retrieve HorizontalPodAutoscaler %q from cluster %q", qualifiedName, clusterName)
+		}
+		found = true
+		desired += hpa.Status.DesiredReplicas
+	}
+	if !found {
+		return 0, errors.Errorf("No HorizontalPodAutoscaler %q found in the clusters", qualifiedName)
+	}
+	return desired, nil
+}
+
+// desiredReplicasForCPU returns the total replicas desired for the
+// target CPU utilization of the given RSP, and the current CPU
+// utilization of the pods of its target in the given clusters.
+func (s *ReplicaScheduler) desiredReplicasForCPU(rsp *fedschedulingv1a1.ReplicaSchedulingPreference, plugin *Plugin,
+	qualifiedName ctlutil.QualifiedName, clusterNames []string) (int32, int32, error) {
+	metrics := podCPUMetrics{}
+	for _, clusterName := range clusterNames {
+		obj, exists, err := plugin.targetInformer.GetTargetStore().GetByKey(clusterName, qualifiedName.String())
+		if err != nil {
+			return 0, 0, err
+		}
+		if !exists {
+			continue
+		}
+		selector, err := plugin.scale.Selector(obj.(*unstructured.Unstructured))
+		if err != nil {
+			return 0, 0, err
+		}
+		client, err := s.podInformer.GetClientForCluster(clusterName)
+		if err != nil {
+			return 0, 0, err
+		}
+
+		podList := &corev1.PodList{}
+		err = client.List(context.Background(), podList, qualifiedName.Namespace, runtimeclient.MatchingLabelsSelector{Selector: selector})
+		if err != nil {
+			return 0, 0, errors.Wrapf(err, "Failed to list the pods of %q in cluster %q", qualifiedName, clusterName)
+		}
+		metricsList := &unstructured.UnstructuredList{}
+		metricsList.SetGroupVersionKind(podMetricsListGVK)
+		err = client.List(context.Background(), metricsList, qualifiedName.Namespace, runtimeclient.MatchingLabelsSelector{Selector: selector})
+		if err != nil {
+			return 0, 0, errors.Wrapf(err, "Failed to retrieve the pod metrics of %q from cluster %q", qualifiedName, clusterName)
+		}
+		podUsage, err := podCPUUsage(metricsList)
+		if err != nil {
+			return 0, 0, err
+		}
+
+		for i := range podList.Items {
+			pod := &podList.Items[i]
+			if pod.DeletionTimestamp != nil || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+				continue
+			}
+			podRequests := podCPURequests(pod)
+			if podRequests == 0 {
+				return 0, 0, errors.Errorf("Pod %q in cluster %q has no CPU request", pod.Name, clusterName)
+			}
+			podUsage, ok := podUsage[pod.Name]
+			switch {
+			case pod.Status.Phase != corev1.PodRunning:
+				metrics.pendingPods++
+				metrics.pendingRequests += podRequests
+			case !ok:
+				metrics.missingPods++
+				metrics.missingRequests += podRequests
+			default:
+				metrics.pods++
+				metrics.usage += podUsage
+				metrics.requests += podRequests
+			}
+		}
+	}
+	if metrics.pods == 0 {
+		return 0, 0, errors.Errorf("No CPU metrics of running pods of %q found in the clusters", qualifiedName)
+	}
+	desired, utilization := desiredReplicasForUtilization(rsp.Spec.TotalReplicas, metrics,
+		*rsp.Spec.Autoscaling.TargetCPUUtilizationPercentage)
+	return desired, utilization, nil
+}
+
+// podCPUUsage returns the CPU usage in millicores of the pods in the
+// given list of pod metrics, by pod name.
+func podCPUUsage(metricsList *unstructured.UnstructuredList) (map[string]int64, error) {
+	usage := make(map[string]int64)
+	for _, item := range metricsList.Items {
+		containers, _, err := unstructured.NestedSlice(item.Object, "containers")
+		if err != nil {
+			return nil, errors.Wrapf(err, "Failed to read the metrics of pod %q", item.GetName())
+		}
+		var podUsage int64
+		for _, container := range containers {
+			cpu, _, err := unstructured.NestedString(container.(map[string]interface{}), "usage", "cpu")
+			if err != nil || cpu == "" {
+				continue
+			}
+			quantity, err := resource.ParseQuantity(cpu)
+			if err != nil {
+				return nil, errors.Wrapf(err, "Failed to parse the CPU usage of pod %q", item.GetName())
+			}
+			podUsage += quantity.MilliValue()
+		}
+		usage[item.GetName()] = podUsage
+	}
+	return usage, nil
+}
+
+// podCPURequests returns the CPU requests in millicores of the
+// containers of the given pod.
+func podCPURequests(pod *corev1.Pod) int64 {
+	var requests int64
+	for _, container := range pod.Spec.Containers {
+		if request, ok := container.Resources.Requests[corev1.ResourceCPU]; ok {
+			requests += request.MilliValue()
+		}
+	}
+	return requests
+}
+
+// desiredReplicasForUtilization returns the replicas desired for the
+// target utilization, given the CPU metrics of the pods, and the
+// current utilization of the pods with metrics as a percentage of their
+// requests. The current replicas are retained within the tolerance.
+// As for a HorizontalPodAutoscaler, running pods without metrics are
+// assumed to use none of their requests when scaling up and all of
+// them, or the target if higher, when scaling down. Pods that are not
+// running are assumed to use none of their requests when scaling up
+// and are otherwise ignored. The current replicas are also retained if
+// these assumptions reverse the direction of the scale.
+func desiredReplicasForUtilization(currentReplicas int32, metrics podCPUMetrics, targetUtilization int32) (int32, int32) {
+	utilization := float64(metrics.usage) / float64(metrics.requests)
+	ratio := utilization * 100 / float64(targetUtilization)
+	currentUtilization := int32(math.Round(utilization * 100))
+
+	pendingPods := metrics.pendingPods > 0 && ratio > 1
+	if metrics.missingPods == 0 && !pendingPods {
+		if math.Abs(ratio-1) <= autoscalingTolerance {
+			return currentReplicas, currentUtilization
+		}
+		return int32(math.Ceil(ratio * float64(metrics.pods))), currentUtilization
+	}
+
+	usage, requests, pods := metrics.usage, metrics.requests, metrics.pods
+	if ratio < 1 {
+		fallbackUtilization := int64(max(100, targetUtilization))
+		usage += metrics.missingRequests * fallbackUtilization / 100
+	}
+	requests += metrics.missingRequests
+	pods += metrics.missingPods
+	if pendingPods {
+		requests += metrics.pendingRequests
+		pods += metrics.pendingPods
+	}
+	newRatio := float64(usage) * 100 / float64(requests) / float64(targetUtilization)
+	if math.Abs(newRatio-1) <= autoscalingTolerance || (ratio < 1) != (newRatio < 1) {
+		return currentReplicas, currentUtilization
+	}
+	desired := int32(math.Ceil(newRatio * float64(pods)))
+	if (newRatio < 1 && desired > currentReplicas) || (newRatio > 1 && desired < currentReplicas) {
+		return currentReplicas, currentUtilization
+	}
+	return desired, currentUtilization
+}
+
+// clampReplicas returns the given replicas within the bounds of the
+// given autoscaling.
+func clampReplicas(replicas int32, autoscaling *fedschedulingv1a1.ReplicaAutoscaling) int32 {
+	minReplicas := int32(1)
+	if autoscaling.MinReplicas != nil {
+		minReplicas = *autoscaling.MinReplicas
+	}
+	if replicas < minReplicas {
+		replicas = minReplicas
+	}
+	if replicas > autoscaling.MaxReplicas {
+		replicas = autoscaling.MaxReplicas
+	}
+	return replicas
+}
+
+// stabilizedReplicas returns the total replicas to scale to. Scaling
+// down is delayed until the stabilization window since the last scale
+// has passed, to avoid flapping.
+func stabilizedReplicas(currentReplicas, desiredReplicas int32, lastScaleTime *metav1.Time, window time.Duration, now time.Time) int32 {
+	if desiredReplicas < currentReplicas && lastScaleTime != nil && now.Sub(lastScaleTime.Time) < window {
+		return currentReplicas
+	}
+	return desiredReplicas
+}
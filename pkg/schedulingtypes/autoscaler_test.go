@@ -0,0 +1,187 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schedulingtypes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
+
+	fedschedulingv1a1 "sigs.k8s.io/kubefed/pkg/apis/scheduling/v1alpha1"
+)
+
+func TestDesiredReplicasForUtilization(t *testing.T) {
+	testCases := map[string]struct {
+		metrics             podCPUMetrics
+		expectedReplicas    int32
+		expectedUtilization int32
+	}{
+		"Utilization above the target scales up": {
+			metrics:             podCPUMetrics{pods: 4, usage: 3200, requests: 4000},
+			expectedReplicas:    7,
+			expectedUtilization: 80,
+		},
+		"Utilization below the target scales down": {
+			metrics:             podCPUMetrics{pods: 4, usage: 800, requests: 4000},
+			expectedReplicas:    2,
+			expectedUtilization: 20,
+		},
+		"Utilization within the tolerance retains the current replicas": {
+			metrics:             podCPUMetrics{pods: 4, usage: 2080, requests: 4000},
+			expectedReplicas:    5,
+			expectedUtilization: 52,
+		},
+		"Pods without metrics use none of their requests when scaling up": {
+			metrics:             podCPUMetrics{pods: 4, usage: 4000, requests: 4000, missingPods: 2, missingRequests: 2000},
+			expectedReplicas:    8,
+			expectedUtilization: 100,
+		},
+		"Pods without metrics use all of their requests when scaling down": {
+			metrics:             podCPUMetrics{pods: 4, usage: 400, requests: 4000, missingPods: 1, missingRequests: 1000},
+			expectedReplicas:    3,
+			expectedUtilization: 10,
+		},
+		"Pods without metrics that reverse the scale retain the current replicas": {
+			metrics:             podCPUMetrics{pods: 2, usage: 2000, requests: 2000, missingPods: 3, missingRequests: 3000},
+			expectedReplicas:    5,
+			expectedUtilization: 100,
+		},
+		"Pending pods within the tolerance when scaling up retain the current replicas": {
+			metrics:             podCPUMetrics{pods: 2, usage: 2000, requests: 2000, pendingPods: 2, pendingRequests: 2000},
+			expectedReplicas:    5,
+			expectedUtilization: 100,
+		},
+		"Pending pods are ignored when scaling down": {
+			metrics:             podCPUMetrics{pods: 4, usage: 400, requests: 4000, pendingPods: 2, pendingRequests: 2000},
+			expectedReplicas:    1,
+			expectedUtilization: 10,
+		},
+	}
+
+	for testName, tc := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			replicas, utilization := desiredReplicasForUtilization(5, tc.metrics, 50)
+			assert.Equal(t, tc.expectedReplicas, replicas)
+			assert.Equal(t, tc.expectedUtilization, utilization)
+		})
+	}
+}
+
+func TestClampReplicas(t *testing.T) {
+	testCases := map[string]struct {
+		replicas    int32
+		minReplicas *int32
+		expected    int32
+	}{
+		"Replicas within the bounds are retained": {
+			replicas:    5,
+			minReplicas: ptr.To[int32](2),
+			expected:    5,
+		},
+		"Replicas are raised to the minimum": {
+			replicas:    1,
+			minReplicas: ptr.To[int32](2),
+			expected:    2,
+		},
+		"The minimum defaults to one": {
+			replicas: 0,
+			expected: 1,
+		},
+		"Replicas are lowered to the maximum": {
+			replicas: 20,
+			expected: 10,
+		},
+	}
+
+	for testName, tc := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			autoscaling := &fedschedulingv1a1.ReplicaAutoscaling{
+				MinReplicas: tc.minReplicas,
+				MaxReplicas: 10,
+			}
+			assert.Equal(t, tc.expected, clampReplicas(tc.replicas, autoscaling))
+		})
+	}
+}
+
+func TestStabilizedReplicas(t *testing.T) {
+	now := time.Now()
+	window := 5 * time.Minute
+
+	testCases := map[string]struct {
+		desiredReplicas int32
+		lastScaleTime   *metav1.Time
+		expected        int32
+	}{
+		"Scaling up is not delayed": {
+			desiredReplicas: 8,
+			lastScaleTime:   &metav1.Time{Time: now.Add(-time.Minute)},
+			expected:        8,
+		},
+		"Scaling down is delayed within the window": {
+			desiredReplicas: 2,
+			lastScaleTime:   &metav1.Time{Time: now.Add(-time.Minute)},
+			expected:        4,
+		},
+		"Scaling down after the window": {
+			desiredReplicas: 2,
+			lastScaleTime:   &metav1.Time{Time: now.Add(-10 * time.Minute)},
+			expected:        2,
+		},
+		"Scaling down without a previous scale": {
+			desiredReplicas: 2,
+			expected:        2,
+		},
+	}
+
+	for testName, tc := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			assert.Equal(t, tc.expected, stabilizedReplicas(4, tc.desiredReplicas, tc.lastScaleTime, window, now))
+		})
+	}
+}
+
+func TestPodCPUUsage(t *testing.T) {
+	metricsList := &unstructured.UnstructuredList{
+		Items: []unstructured.Unstructured{
+			{Object: map[string]interface{}{
+				"metadata": map[string]interface{}{"name": "web-1"},
+				"containers": []interface{}{
+					map[string]interface{}{"usage": map[string]interface{}{"cpu": "250m"}},
+					map[string]interface{}{"usage": map[string]interface{}{"cpu": "1"}},
+				},
+			}},
+			{Object: map[string]interface{}{
+				"metadata": map[string]interface{}{"name": "web-2"},
+				"containers": []interface{}{
+					map[string]interface{}{"usage": map[string]interface{}{"cpu": "12500000n"}},
+				},
+			}},
+		},
+	}
+
+	usage, err := podCPUUsage(metricsList)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	assert.Equal(t, map[string]int64{"web-1": 1250, "web-2": 13}, usage)
+}
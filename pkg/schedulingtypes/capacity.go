@@ -0,0 +1,75 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schedulingtypes
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	fedv1b1 "sigs.k8s.io/kubefed/pkg/apis/core/v1beta1"
+)
+
+// limitCapacityByResources estimates the capacity of the given clusters
+// whose replicas are all schedulable from the resources summarized in
+// their status: the replicas of the target in a cluster, which already
+// hold their requests, plus the pods with the given requests that fit
+// in the resources not yet requested. The capacity of clusters with
+// unschedulable replicas is already estimated from those replicas.
+func limitCapacityByResources(state *replicaState, clusterNames []string,
+	resourceSummaries map[string]*fedv1b1.ClusterResourceSummary, podRequests corev1.ResourceList) {
+	for _, clusterName := range clusterNames {
+		if _, ok := state.estimatedCapacity[clusterName]; ok {
+			continue
+		}
+		fitting, ok := podsFitting(resourceSummaries[clusterName], podRequests)
+		if !ok {
+			continue
+		}
+		state.estimatedCapacity[clusterName] = state.replicas[clusterName] + fitting
+	}
+}
+
+// podsFitting returns the number of pods with the given requests that
+// fit in the resources of a cluster that are not requested, and false if
+// this cannot be determined because the cluster has no resource summary
+// or the pods request neither CPU nor memory. Resources are only
+// considered in total, so pods may not fit the nodes individually.
+func podsFitting(summary *fedv1b1.ClusterResourceSummary, podRequests corev1.ResourceList) (int64, bool) {
+	if summary == nil {
+		return 0, false
+	}
+	fitting := int64(-1)
+	for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+		request, ok := podRequests[name]
+		if !ok || request.IsZero() {
+			continue
+		}
+		allocatable := summary.Allocatable[name]
+		requested := summary.Requested[name]
+		free := allocatable.MilliValue() - requested.MilliValue()
+		pods := int64(0)
+		if free > 0 {
+			pods = free / request.MilliValue()
+		}
+		if fitting < 0 || pods < fitting {
+			fitting = pods
+		}
+	}
+	if fitting < 0 {
+		return 0, false
+	}
+	return fitting, true
+}
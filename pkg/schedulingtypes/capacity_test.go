@@ -0,0 +1,99 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schedulingtypes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	fedv1b1 "sigs.k8s.io/kubefed/pkg/apis/core/v1beta1"
+)
+
+func TestLimitCapacityByResources(t *testing.T) {
+	summary := func(cpu, requestedCPU, memory, requestedMemory string) *fedv1b1.ClusterResourceSummary {
+		return &fedv1b1.ClusterResourceSummary{
+			Nodes: 2,
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse(cpu),
+				corev1.ResourceMemory: resource.MustParse(memory),
+			},
+			Requested: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse(requestedCPU),
+				corev1.ResourceMemory: resource.MustParse(requestedMemory),
+			},
+		}
+	}
+
+	testCases := map[string]struct {
+		podRequests       corev1.ResourceList
+		resourceSummaries map[string]*fedv1b1.ClusterResourceSummary
+		expectedCapacity  map[string]int64
+	}{
+		"Capacity is limited by the scarcest resource": {
+			podRequests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("500m"),
+				corev1.ResourceMemory: resource.MustParse("1Gi"),
+			},
+			resourceSummaries: map[string]*fedv1b1.ClusterResourceSummary{
+				"A": summary("8", "2", "8Gi", "4Gi"),
+				"B": summary("8", "7", "32Gi", "4Gi"),
+				"C": summary("8", "10", "32Gi", "4Gi"),
+			},
+			expectedCapacity: map[string]int64{
+				"A": 2 + 4,
+				"B": 2,
+				"C": 0,
+				"D": 3,
+			},
+		},
+		"Capacity is not estimated without requests": {
+			podRequests: corev1.ResourceList{},
+			resourceSummaries: map[string]*fedv1b1.ClusterResourceSummary{
+				"A": summary("8", "2", "8Gi", "4Gi"),
+			},
+			expectedCapacity: map[string]int64{
+				"D": 3,
+			},
+		},
+		"Capacity is not estimated without a resource summary": {
+			podRequests: corev1.ResourceList{
+				corev1.ResourceCPU: resource.MustParse("500m"),
+			},
+			expectedCapacity: map[string]int64{
+				"D": 3,
+			},
+		},
+	}
+
+	for testName, tc := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			state := &replicaState{
+				replicas: map[string]int64{"A": 2, "D": 5},
+				current:  map[string]int64{"A": 2, "D": 3},
+				// The capacity of D is estimated from its unschedulable
+				// replicas.
+				estimatedCapacity: map[string]int64{"D": 3},
+			}
+			limitCapacityByResources(state, []string{"A", "B", "C", "D"}, tc.resourceSummaries, tc.podRequests)
+			assert.Equal(t, tc.expectedCapacity, state.estimatedCapacity)
+		})
+	}
+}
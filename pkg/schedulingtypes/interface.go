@@ -33,7 +33,7 @@ type Scheduler interface {
 	Stop()
 	Reconcile(obj runtimeclient.Object, qualifiedName util.QualifiedName) util.ReconciliationStatus
 
-	StartPlugin(typeConfig typeconfig.Interface, nsAPIResource *metav1.APIResource) error
+	StartPlugin(typeConfig typeconfig.Interface, scale *ScaleSubresource, nsAPIResource *metav1.APIResource) error
 	StopPlugin(kind string)
 }
 
@@ -24,9 +24,11 @@ import (
 	"strings"
 
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	pkgruntime "k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
 	restclient "k8s.io/client-go/rest"
@@ -39,10 +41,6 @@ import (
 	"sigs.k8s.io/kubefed/pkg/controller/util"
 )
 
-const (
-	replicasPath = "/spec/replicas"
-)
-
 type Plugin struct {
 	targetInformer util.FederatedInformer
 
@@ -52,13 +50,15 @@ type Plugin struct {
 	federatedTypeClient util.ResourceClient
 
 	typeConfig   typeconfig.Interface
+	scale        *ScaleSubresource
 	fedNsClient  util.ResourceClient
 	limitedScope bool
 
 	stopChannel chan struct{}
 }
 
-func NewPlugin(controllerConfig *util.ControllerConfig, eventHandlers SchedulerEventHandlers, typeConfig typeconfig.Interface, nsAPIResource *metav1.APIResource) (*Plugin, error) {
+func NewPlugin(controllerConfig *util.ControllerConfig, eventHandlers SchedulerEventHandlers, typeConfig typeconfig.Interface,
+	scale *ScaleSubresource, nsAPIResource *metav1.APIResource) (*Plugin, error) {
 	targetAPIResource := typeConfig.GetTargetType()
 	userAgent := fmt.Sprintf("%s-replica-scheduler", strings.ToLower(targetAPIResource.Kind))
 	kubeConfig := restclient.CopyConfig(controllerConfig.KubeConfig)
@@ -79,6 +79,7 @@ func NewPlugin(controllerConfig *util.ControllerConfig, eventHandlers SchedulerE
 	p := &Plugin{
 		targetInformer: targetInformer,
 		typeConfig:     typeConfig,
+		scale:          scale,
 		limitedScope:   controllerConfig.LimitedScope(),
 		stopChannel:    make(chan struct{}),
 	}
@@ -146,6 +147,25 @@ func (p *Plugin) FederatedTypeExists(key string) bool {
 	return exist
 }
 
+// PodRequests returns the resources requested by a pod of the given
+// federated resource, or nil if its template has no pod template at
+// spec.template, as for the built-in scalable types.
+func (p *Plugin) PodRequests(qualifiedName util.QualifiedName) (corev1.ResourceList, error) {
+	obj, exists, err := p.federatedStore.GetByKey(qualifiedName.String())
+	if err != nil || !exists {
+		return nil, err
+	}
+	podSpecMap, ok, err := unstructured.NestedMap(obj.(*unstructured.Unstructured).Object, "spec", "template", "spec", "template", "spec")
+	if err != nil || !ok {
+		return nil, err
+	}
+	podSpec := &corev1.PodSpec{}
+	if err := pkgruntime.DefaultUnstructuredConverter.FromUnstructured(podSpecMap, podSpec); err != nil {
+		return nil, errors.Wrapf(err, "Failed to convert the pod template of %q", qualifiedName)
+	}
+	return util.PodRequests(podSpec), nil
+}
+
 func (p *Plugin) GetResourceClusters(qualifiedName util.QualifiedName, clusters []*fedv1b1.KubeFedCluster) (selectedClusters sets.Set[string], err error) {
 	fedObject, err := p.federatedTypeClient.Resources(qualifiedName.Namespace).Get(context.Background(), qualifiedName.Name, metav1.GetOptions{})
 	if err != nil {
@@ -164,6 +184,16 @@ func (p *Plugin) GetResourceClusters(qualifiedName util.QualifiedName, clusters
 	return util.ComputePlacement(fedObject, clusters, true)
 }
 
+// TolerantClusters returns the clusters whose taints are tolerated by
+// the federated resource.
+func (p *Plugin) TolerantClusters(qualifiedName util.QualifiedName, clusters []*fedv1b1.KubeFedCluster) ([]*fedv1b1.KubeFedCluster, error) {
+	fedObject, err := p.federatedTypeClient.Resources(qualifiedName.Namespace).Get(context.Background(), qualifiedName.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return util.TolerantClusters(fedObject, clusters)
+}
+
 func (p *Plugin) Reconcile(qualifiedName util.QualifiedName, result map[string]int64) error {
 	fedObject, err := p.federatedTypeClient.Resources(qualifiedName.Namespace).Get(context.Background(), qualifiedName.Name, metav1.GetOptions{})
 	if err != nil && apierrors.IsNotFound(err) {
@@ -196,8 +226,9 @@ func (p *Plugin) Reconcile(qualifiedName util.QualifiedName, result map[string]i
 	if err != nil {
 		return errors.Wrapf(err, "Error reading cluster overrides for %s %q", p.typeConfig.GetFederatedType().Kind, qualifiedName)
 	}
-	if OverrideUpdateNeeded(overridesMap, result) {
-		err := setOverrides(fedObject, overridesMap, result)
+	replicasPath := p.scale.ReplicasOverridePath()
+	if OverrideUpdateNeeded(overridesMap, result, replicasPath) {
+		err := setOverrides(fedObject, overridesMap, result, replicasPath)
 		if err != nil {
 			return err
 		}
@@ -221,15 +252,15 @@ func PlacementUpdateNeeded(names, newNames []string) bool {
 	return !reflect.DeepEqual(names, newNames)
 }
 
-func setOverrides(obj *unstructured.Unstructured, overridesMap util.OverridesMap, replicasMap map[string]int64) error {
+func setOverrides(obj *unstructured.Unstructured, overridesMap util.OverridesMap, replicasMap map[string]int64, replicasPath string) error {
 	if overridesMap == nil {
 		overridesMap = make(util.OverridesMap)
 	}
-	updateOverridesMap(overridesMap, replicasMap)
+	updateOverridesMap(overridesMap, replicasMap, replicasPath)
 	return util.SetOverrides(obj, overridesMap)
 }
 
-func updateOverridesMap(overridesMap util.OverridesMap, replicasMap map[string]int64) {
+func updateOverridesMap(overridesMap util.OverridesMap, replicasMap map[string]int64, replicasPath string) {
 	// Remove replicas override for clusters that are not scheduled
 	for clusterName, clusterOverrides := range overridesMap {
 		if _, ok := replicasMap[clusterName]; !ok {
@@ -263,7 +294,7 @@ func updateOverridesMap(overridesMap util.OverridesMap, replicasMap map[string]i
 	}
 }
 
-func OverrideUpdateNeeded(overridesMap util.OverridesMap, result map[string]int64) bool {
+func OverrideUpdateNeeded(overridesMap util.OverridesMap, result map[string]int64, replicasPath string) bool {
 	resultLen := len(result)
 	checkLen := 0
 	for clusterName, clusterOverridesMap := range overridesMap {
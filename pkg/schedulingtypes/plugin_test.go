@@ -23,6 +23,8 @@ import (
 	"sigs.k8s.io/kubefed/pkg/controller/util"
 )
 
+const replicasPath = "/spec/replicas"
+
 func TestUpdateOverridesMap(t *testing.T) {
 	cluster := "cluster1"
 
@@ -91,7 +93,7 @@ func TestUpdateOverridesMap(t *testing.T) {
 
 	for testName, tc := range testCases {
 		t.Run(testName, func(t *testing.T) {
-			updateOverridesMap(tc.overridesMap, tc.replicasMap)
+			updateOverridesMap(tc.overridesMap, tc.replicasMap, replicasPath)
 			actual := make(map[string]int64)
 			for _, override := range tc.overridesMap[cluster] {
 				actual[override.Path] = override.Value.(int64)
@@ -20,11 +20,13 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"reflect"
 	"sort"
 	"time"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/util/runtime"
@@ -44,13 +46,11 @@ const (
 	RSPKind = "ReplicaSchedulingPreference"
 )
 
-func init() {
-	schedulingType := SchedulingType{
-		Kind:             RSPKind,
-		SchedulerFactory: NewReplicaScheduler,
-	}
-	RegisterSchedulingType("deployments.apps", schedulingType)
-	RegisterSchedulingType("replicasets.apps", schedulingType)
+// RSPSchedulingType schedules the replicas of target types with a
+// scale subresource.
+var RSPSchedulingType = SchedulingType{
+	Kind:             RSPKind,
+	SchedulerFactory: NewReplicaScheduler,
 }
 
 type ReplicaScheduler struct {
@@ -96,11 +96,13 @@ func (s *ReplicaScheduler) SchedulingKind() string {
 	return RSPKind
 }
 
-func (s *ReplicaScheduler) StartPlugin(typeConfig typeconfig.Interface, nsAPIResource *metav1.APIResource) error {
+func (s *ReplicaScheduler) StartPlugin(typeConfig typeconfig.Interface, scale *ScaleSubresource, nsAPIResource *metav1.APIResource) error {
 	kind := typeConfig.GetFederatedType().Kind
-	// TODO(marun) Return an error if the kind is not supported
+	if scale == nil {
+		return errors.Errorf("%q is not a scalable type", kind)
+	}
 
-	plugin, err := NewPlugin(s.controllerConfig, s.eventHandlers, typeConfig, nsAPIResource)
+	plugin, err := NewPlugin(s.controllerConfig, s.eventHandlers, typeConfig, scale, nsAPIResource)
 	if err != nil {
 		return errors.Wrapf(err, "Failed to initialize replica scheduling plugin for %q", kind)
 	}
@@ -163,6 +165,21 @@ func (s *ReplicaScheduler) Reconcile(obj runtimeclient.Object, qualifiedName ctl
 		return ctlutil.StatusError
 	}
 
+	originalStatus := rsp.Status.DeepCopy()
+	status := s.reconcile(rsp, qualifiedName)
+	if !reflect.DeepEqual(originalStatus, &rsp.Status) {
+		err := s.client.UpdateStatus(context.Background(), rsp)
+		if err != nil {
+			runtime.HandleError(errors.Wrapf(err, "Failed to update the status of RSP named %q", qualifiedName))
+			return ctlutil.StatusError
+		}
+	}
+	return status
+}
+
+// reconcile schedules the replicas of the target of the given RSP and
+// records the result in the status of the RSP.
+func (s *ReplicaScheduler) reconcile(rsp *fedschedulingv1a1.ReplicaSchedulingPreference, qualifiedName ctlutil.QualifiedName) ctlutil.ReconciliationStatus {
 	fedClusters, err := s.podInformer.GetReadyClusters()
 	if err != nil {
 		runtime.HandleError(errors.Wrap(err, "Failed to get cluster list"))
@@ -172,33 +189,53 @@ func (s *ReplicaScheduler) Reconcile(obj runtimeclient.Object, qualifiedName ctl
 	clusterNames := s.clusterNames(fedClusters)
 	if len(clusterNames) == 0 {
 		// no joined clusters, nothing to do
+		setNotScheduledStatus(rsp, NoClustersReason, "No clusters are ready")
 		return ctlutil.StatusAllOK
 	}
 
+	// A plugin is only started for federated types whose target type
+	// has a scale subresource.
 	kind := rsp.Spec.TargetKind
-	if kind != "FederatedDeployment" && kind != "FederatedReplicaSet" {
-		runtime.HandleError(errors.Wrapf(err, "RSP target kind: %s is incorrect", kind))
-		return ctlutil.StatusNeedsRecheck
-	}
-
 	plugin, ok := s.plugins.Get(kind)
 	if !ok {
+		klog.V(2).Infof("RSP target kind %q is not a scalable federated type", kind)
 		return ctlutil.StatusAllOK
 	}
 
 	if !plugin.(*Plugin).FederatedTypeExists(qualifiedName.String()) {
 		// target FederatedType does not exist, nothing to do
+		setCondition(rsp, fedschedulingv1a1.TargetNotFound, metav1.ConditionTrue, TargetNotFoundReason,
+			fmt.Sprintf("%s %q does not exist", kind, qualifiedName))
+		setNotScheduledStatus(rsp, TargetNotFoundReason, fmt.Sprintf("%s %q does not exist", kind, qualifiedName))
 		return ctlutil.StatusAllOK
 	}
+	setCondition(rsp, fedschedulingv1a1.TargetNotFound, metav1.ConditionFalse, TargetFoundReason, "")
 
 	key := qualifiedName.String()
 
+	// Replicas are not scheduled to clusters whose taints are not
+	// tolerated by the target resource.
+	fedClusters, err = plugin.(*Plugin).TolerantClusters(qualifiedName, fedClusters)
+	if err != nil {
+		err = errors.Wrapf(err, "Failed to get the clusters tolerated by the target of RSP named %q", key)
+		runtime.HandleError(err)
+		setNotScheduledStatus(rsp, SchedulingFailedReason, err.Error())
+		return ctlutil.StatusError
+	}
+	clusterNames = s.clusterNames(fedClusters)
+	if len(clusterNames) == 0 {
+		setNotScheduledStatus(rsp, NoClustersReason, "No ready clusters are tolerated by the target")
+		return ctlutil.StatusAllOK
+	}
+
 	if rsp.Spec.IntersectWithClusterSelector {
 		klog.V(3).Infof("Computing placement of resource %q", qualifiedName)
 
 		resultClusters, err := plugin.(*Plugin).GetResourceClusters(qualifiedName, fedClusters)
 		if err != nil {
-			runtime.HandleError(errors.Wrapf(err, "Failed to get preferred clusters while reconciling RSP named %q", key))
+			err = errors.Wrapf(err, "Failed to get preferred clusters while reconciling RSP named %q", key)
+			runtime.HandleError(err)
+			setNotScheduledStatus(rsp, SchedulingFailedReason, err.Error())
 			return ctlutil.StatusError
 		}
 
@@ -207,6 +244,7 @@ func (s *ReplicaScheduler) Reconcile(obj runtimeclient.Object, qualifiedName ctl
 			preferredClusters = append(preferredClusters, clusterName)
 		}
 		if len(preferredClusters) == 0 {
+			setNotScheduledStatus(rsp, NoClustersReason, "No ready clusters are selected by the placement of the target")
 			return ctlutil.StatusAllOK
 		}
 		clusterNames = preferredClusters
@@ -214,18 +252,43 @@ func (s *ReplicaScheduler) Reconcile(obj runtimeclient.Object, qualifiedName ctl
 		klog.V(3).Infof("Preferred clusters %q", clusterNames)
 	}
 
-	result, status, err := s.GetSchedulingResult(rsp, qualifiedName, clusterNames)
+	if rsp.Spec.Autoscaling != nil {
+		// Replicas are still scheduled if the total replicas cannot be
+		// autoscaled.
+		err := s.autoscale(rsp, plugin.(*Plugin), qualifiedName, clusterNames)
+		if err != nil {
+			runtime.HandleError(errors.Wrapf(err, "Failed to autoscale the total replicas of RSP named %q", key))
+		}
+	} else {
+		rsp.Status.Autoscaling = nil
+		meta.RemoveStatusCondition(&rsp.Status.Conditions, fedschedulingv1a1.ScalingActive)
+	}
+
+	clusters := make(map[string]*fedv1b1.KubeFedCluster)
+	for _, cluster := range fedClusters {
+		clusters[cluster.Name] = cluster
+	}
+	result, status, err := s.getSchedulingResult(rsp, qualifiedName, clusterNames, clusters)
 	if err != nil {
-		runtime.HandleError(errors.Wrapf(err, "Failed to compute the schedule information while reconciling RSP named %q", key))
+		err = errors.Wrapf(err, "Failed to compute the schedule information while reconciling RSP named %q", key)
+		runtime.HandleError(err)
+		setNotScheduledStatus(rsp, SchedulingFailedReason, err.Error())
 		return ctlutil.StatusError
 	}
 
-	err = plugin.(*Plugin).Reconcile(qualifiedName, result)
+	err = plugin.(*Plugin).Reconcile(qualifiedName, result.replicas)
 	if err != nil {
-		runtime.HandleError(errors.Wrapf(err, "Failed to reconcile federated targets for RSP named %q", key))
+		err = errors.Wrapf(err, "Failed to reconcile federated targets for RSP named %q", key)
+		runtime.HandleError(err)
+		setNotScheduledStatus(rsp, SchedulingFailedReason, err.Error())
 		return ctlutil.StatusError
 	}
 
+	setScheduledStatus(rsp, clusterNames, result)
+	if rsp.Spec.Autoscaling != nil && status == ctlutil.StatusAllOK {
+		// The load of the target is polled.
+		return ctlutil.StatusNeedsRecheck
+	}
 	return status
 }
 
@@ -239,43 +302,83 @@ func (s *ReplicaScheduler) clusterNames(clusters []*fedv1b1.KubeFedCluster) []st
 	return clusterNames
 }
 
-func (s *ReplicaScheduler) GetSchedulingResult(rsp *fedschedulingv1a1.ReplicaSchedulingPreference,
-	qualifiedName ctlutil.QualifiedName, clusterNames []string) (map[string]int64, ctlutil.ReconciliationStatus, error) {
+// schedulingResult is the result of scheduling the replicas of the
+// target of an RSP.
+type schedulingResult struct {
+	// The replicas scheduled to each cluster, including overflow
+	// replicas.
+	replicas map[string]int64
+	// The replicas scheduled to each cluster within its estimated
+	// capacity.
+	plan map[string]int64
+	// The replicas scheduled to each cluster beyond its estimated
+	// capacity.
+	overflow map[string]int64
+	// The state of the replicas of the target before scheduling.
+	state *replicaState
+}
+
+// replicaState is the scheduling state of the replicas of a target in
+// the clusters in which it exists.
+type replicaState struct {
+	// The desired replicas of the target in each cluster.
+	replicas map[string]int64
+	// The running and ready replicas of the target in each cluster.
+	current map[string]int64
+	// The estimated capacity of the clusters with unschedulable
+	// replicas or a resource summary.
+	estimatedCapacity map[string]int64
+}
+
+func (s *ReplicaScheduler) getSchedulingResult(rsp *fedschedulingv1a1.ReplicaSchedulingPreference,
+	qualifiedName ctlutil.QualifiedName, clusterNames []string,
+	clusters map[string]*fedv1b1.KubeFedCluster) (*schedulingResult, ctlutil.ReconciliationStatus, error) {
 	key := qualifiedName.String()
 
+	abstractPlugin, ok := s.plugins.Get(rsp.Spec.TargetKind)
+	if !ok {
+		return nil, ctlutil.StatusAllOK, errors.Errorf("No plugin for RSP target kind %q", rsp.Spec.TargetKind)
+	}
+	plugin := abstractPlugin.(*Plugin)
+
 	objectGetter := func(clusterName, key string) (interface{}, bool, error) {
-		plugin, ok := s.plugins.Get(rsp.Spec.TargetKind)
-		if !ok {
-			return nil, false, nil
-		}
-		return plugin.(*Plugin).targetInformer.GetTargetStore().GetByKey(clusterName, key)
+		return plugin.targetInformer.GetTargetStore().GetByKey(clusterName, key)
 	}
 	podsGetter := func(clusterName string, unstructuredObj *unstructured.Unstructured) (*corev1.PodList, error) {
 		client, err := s.podInformer.GetClientForCluster(clusterName)
 		if err != nil {
 			return nil, err
 		}
-		selectorLabels, ok, err := unstructured.NestedStringMap(unstructuredObj.Object, "spec", "selector", "matchLabels")
-		if !ok {
-			return nil, errors.New("missing selector on object")
-		}
+		selector, err := plugin.scale.Selector(unstructuredObj)
 		if err != nil {
-			return nil, errors.Wrap(err, "error retrieving selector from object")
+			return nil, err
 		}
 
 		podList := &corev1.PodList{}
-		err = client.List(context.Background(), podList, unstructuredObj.GetNamespace(), runtimeclient.MatchingLabels(selectorLabels))
+		err = client.List(context.Background(), podList, unstructuredObj.GetNamespace(), runtimeclient.MatchingLabelsSelector{Selector: selector})
 		if err != nil {
 			return nil, err
 		}
 		return podList, nil
 	}
 
-	currentReplicasPerCluster, estimatedCapacity, status, err := clustersReplicaState(clusterNames, key, objectGetter, podsGetter)
+	state, status, err := clustersReplicaState(clusterNames, key, plugin.scale, objectGetter, podsGetter)
 	if err != nil {
 		return nil, status, err
 	}
 
+	// The capacity of clusters is estimated from their resources before
+	// replicas become unschedulable.
+	podRequests, err := plugin.PodRequests(qualifiedName)
+	if err != nil {
+		return nil, status, err
+	}
+	resourceSummaries := make(map[string]*fedv1b1.ClusterResourceSummary)
+	for clusterName, cluster := range clusters {
+		resourceSummaries[clusterName] = cluster.Status.ResourceSummary
+	}
+	limitCapacityByResources(state, clusterNames, resourceSummaries, podRequests)
+
 	// TODO: Move this to API defaulting logic
 	if len(rsp.Spec.Clusters) == 0 {
 		rsp.Spec.Clusters = map[string]fedschedulingv1a1.ClusterPreferences{
@@ -284,14 +387,25 @@ func (s *ReplicaScheduler) GetSchedulingResult(rsp *fedschedulingv1a1.ReplicaSch
 	}
 
 	plnr := planner.NewPlanner(rsp)
-	scheduleResult, err := schedule(plnr, key, clusterNames, currentReplicasPerCluster, estimatedCapacity)
+	if topology := rsp.Spec.Topology; topology != nil {
+		clusterDomains := make(map[string]string)
+		for _, clusterName := range clusterNames {
+			if cluster, ok := clusters[clusterName]; ok {
+				clusterDomains[clusterName] = ctlutil.TopologyDomain(cluster, topology.TopologyKey)
+			}
+		}
+		plnr = planner.NewPlannerWithDomains(rsp, clusterDomains)
+	}
+	result, err := schedule(plnr, key, clusterNames, state)
 	if err != nil {
 		return nil, status, err
 	}
-	return scheduleResult, status, err
+	return result, status, err
 }
 
-func schedule(planner *planner.Planner, key string, clusterNames []string, currentReplicasPerCluster map[string]int64, estimatedCapacity map[string]int64) (map[string]int64, error) {
+func schedule(planner *planner.Planner, key string, clusterNames []string, state *replicaState) (*schedulingResult, error) {
+	currentReplicasPerCluster := state.current
+	estimatedCapacity := state.estimatedCapacity
 	scheduleResult, overflow, err := planner.Plan(clusterNames, currentReplicasPerCluster, estimatedCapacity, key)
 	if err != nil {
 		return nil, err
@@ -328,62 +442,63 @@ func schedule(planner *planner.Planner, key string, clusterNames []string, curre
 		}
 		klog.V(4).Infof("buf.string:%s", buf.String())
 	}
-	return result, nil
+	return &schedulingResult{
+		replicas: result,
+		plan:     scheduleResult,
+		overflow: overflow,
+		state:    state,
+	}, nil
 }
 
 // clustersReplicaState returns information about the scheduling state of the pods running in the federated clusters.
 func clustersReplicaState(
 	clusterNames []string,
 	key string,
+	scale *ScaleSubresource,
 	objectGetter func(clusterName string, key string) (interface{}, bool, error),
 	podsGetter func(clusterName string, obj *unstructured.Unstructured) (*corev1.PodList, error)) (
-	currentReplicasPerCluster map[string]int64, estimatedCapacity map[string]int64,
-	status ctlutil.ReconciliationStatus, err error) {
-	currentReplicasPerCluster = make(map[string]int64)
-	estimatedCapacity = make(map[string]int64)
+	state *replicaState, status ctlutil.ReconciliationStatus, err error) {
+	state = &replicaState{
+		replicas:          make(map[string]int64),
+		current:           make(map[string]int64),
+		estimatedCapacity: make(map[string]int64),
+	}
 
 	for _, clusterName := range clusterNames {
 		obj, exists, err := objectGetter(clusterName, key)
 		if err != nil {
-			return nil, nil, status, err
+			return nil, status, err
 		}
 		if !exists {
 			continue
 		}
 
 		unstructuredObj := obj.(*unstructured.Unstructured)
-		replicas, ok, err := unstructured.NestedInt64(unstructuredObj.Object, "spec", "replicas")
-		if err != nil {
-			return nil, nil, status, errors.Wrap(err, "Error retrieving 'replicas' field")
-		}
-		if !ok {
-			replicas = int64(0)
-		}
-		readyReplicas, ok, err := unstructured.NestedInt64(unstructuredObj.Object, "status", "readyReplicas")
+		replicas, readyReplicas, err := scale.Replicas(unstructuredObj)
 		if err != nil {
-			return nil, nil, status, errors.Wrap(err, "Error retrieving 'readyreplicas' field")
-		}
-		if !ok {
-			readyReplicas = int64(0)
+			return nil, status, err
 		}
+		state.replicas[clusterName] = replicas
 
-		if replicas == readyReplicas {
-			currentReplicasPerCluster[clusterName] = readyReplicas
+		// The status of a custom resource may count replicas that are
+		// not ready, so its pods are always analyzed.
+		if scale.StatusReplicasReady && replicas == readyReplicas {
+			state.current[clusterName] = readyReplicas
 		} else {
-			currentReplicasPerCluster[clusterName] = int64(0)
+			state.current[clusterName] = int64(0)
 			podList, err := podsGetter(clusterName, unstructuredObj)
 			if err != nil {
-				return nil, nil, status, err
+				return nil, status, err
 			}
 
 			var podResult podanalyzer.PodAnalysisResult
 			podResult, status = podanalyzer.AnalyzePods(podList, time.Now())
-			currentReplicasPerCluster[clusterName] = int64(podResult.RunningAndReady) // include pending as well?
+			state.current[clusterName] = int64(podResult.RunningAndReady) // include pending as well?
 			unschedulable := int64(podResult.Unschedulable)
 			if unschedulable > 0 {
-				estimatedCapacity[clusterName] = replicas - unschedulable
+				state.estimatedCapacity[clusterName] = replicas - unschedulable
 			}
 		}
 	}
-	return currentReplicasPerCluster, estimatedCapacity, status, nil
+	return state, status, nil
 }
@@ -0,0 +1,112 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schedulingtypes
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestClustersReplicaState(t *testing.T) {
+	const clusterName = "cluster1"
+	customScale := &ScaleSubresource{
+		SpecReplicasPath:   ".spec.replicas",
+		StatusReplicasPath: ".status.replicas",
+		LabelSelectorPath:  ".status.selector",
+	}
+	readyPod := corev1.Pod{Status: corev1.PodStatus{
+		Phase:      corev1.PodRunning,
+		Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+	}}
+	unschedulablePod := corev1.Pod{Status: corev1.PodStatus{
+		Phase: corev1.PodPending,
+		Conditions: []corev1.PodCondition{{
+			Type:               corev1.PodScheduled,
+			Status:             corev1.ConditionFalse,
+			Reason:             corev1.PodReasonUnschedulable,
+			LastTransitionTime: metav1.Time{Time: time.Now().Add(-10 * time.Minute)},
+		}},
+	}}
+
+	testCases := map[string]struct {
+		scale            *ScaleSubresource
+		status           map[string]interface{}
+		expectPods       bool
+		expectedCurrent  int64
+		expectedCapacity map[string]int64
+	}{
+		"Ready replicas of a built-in type are trusted": {
+			scale:           BuiltinScaleSubresource("deployments.apps"),
+			status:          map[string]interface{}{"readyReplicas": int64(3)},
+			expectedCurrent: 3,
+		},
+		"Pods of a built-in type are analyzed if replicas are not ready": {
+			scale:            BuiltinScaleSubresource("deployments.apps"),
+			status:           map[string]interface{}{"readyReplicas": int64(2)},
+			expectPods:       true,
+			expectedCurrent:  2,
+			expectedCapacity: map[string]int64{clusterName: 2},
+		},
+		"Pods of a custom resource are analyzed even if all replicas are reported": {
+			scale:            customScale,
+			status:           map[string]interface{}{"replicas": int64(3), "selector": "app=web"},
+			expectPods:       true,
+			expectedCurrent:  2,
+			expectedCapacity: map[string]int64{clusterName: 2},
+		},
+	}
+
+	for testName, tc := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			obj := &unstructured.Unstructured{Object: map[string]interface{}{
+				"spec": map[string]interface{}{
+					"replicas": int64(3),
+					"selector": map[string]interface{}{
+						"matchLabels": map[string]interface{}{"app": "web"},
+					},
+				},
+				"status": tc.status,
+			}}
+			objectGetter := func(string, string) (interface{}, bool, error) {
+				return obj, true, nil
+			}
+			podsListed := false
+			podsGetter := func(string, *unstructured.Unstructured) (*corev1.PodList, error) {
+				podsListed = true
+				return &corev1.PodList{Items: []corev1.Pod{readyPod, readyPod, unschedulablePod}}, nil
+			}
+
+			state, _, err := clustersReplicaState([]string{clusterName}, "ns/web", tc.scale, objectGetter, podsGetter)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if podsListed != tc.expectPods {
+				t.Errorf("Expected pods to be analyzed: %v, got %v", tc.expectPods, podsListed)
+			}
+			if current := state.current[clusterName]; current != tc.expectedCurrent {
+				t.Errorf("Expected %d current replicas, got %d", tc.expectedCurrent, current)
+			}
+			if len(state.estimatedCapacity) != len(tc.expectedCapacity) || state.estimatedCapacity[clusterName] != tc.expectedCapacity[clusterName] {
+				t.Errorf("Expected estimated capacity %v, got %v", tc.expectedCapacity, state.estimatedCapacity)
+			}
+		})
+	}
+}
@@ -0,0 +1,185 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schedulingtypes
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"sigs.k8s.io/kubefed/pkg/apis/core/typeconfig"
+	genericclient "sigs.k8s.io/kubefed/pkg/client/generic"
+)
+
+// ScaleSubresource identifies the fields of a scalable target type, in
+// the form of the scale subresource of a CustomResourceDefinition
+// (e.g. .spec.replicas).
+type ScaleSubresource struct {
+	// The desired number of replicas.
+	SpecReplicasPath string
+	// The number of replicas reported in the status of the target.
+	StatusReplicasPath string
+	// Whether StatusReplicasPath counts only ready replicas, as it does
+	// for the built-in types. The scale subresource of a custom
+	// resource usually reports the total number of replicas at
+	// .status.replicas instead, so the replicas running in a cluster
+	// are then always determined from the pods of the target.
+	StatusReplicasReady bool
+	// The label selector of the pods of the target, either as a label
+	// selector or in its serialized form.
+	LabelSelectorPath string
+}
+
+// The scale subresources of the built-in scalable types, by the name of
+// their FederatedTypeConfig.
+var builtinScaleSubresources = map[string]ScaleSubresource{
+	"deployments.apps":  appsScaleSubresource,
+	"replicasets.apps":  appsScaleSubresource,
+	"statefulsets.apps": appsScaleSubresource,
+}
+
+var appsScaleSubresource = ScaleSubresource{
+	SpecReplicasPath:    ".spec.replicas",
+	StatusReplicasPath:  ".status.readyReplicas",
+	StatusReplicasReady: true,
+	LabelSelectorPath:   ".spec.selector",
+}
+
+// BuiltinScaleSubresource returns the scale subresource of the named
+// built-in target type (e.g. deployments.apps), or nil if the type is
+// not a built-in scalable type.
+func BuiltinScaleSubresource(name string) *ScaleSubresource {
+	scale, ok := builtinScaleSubresources[name]
+	if !ok {
+		return nil
+	}
+	return &scale
+}
+
+// GetScaleSubresource returns the scale subresource of the target type
+// of the given type config, or nil if the target type is not scalable.
+// The scale subresource of a custom resource is read from its
+// CustomResourceDefinition, which requires cluster scope. What its
+// StatusReplicasPath counts is not known, so it is not trusted to count
+// only ready replicas.
+func GetScaleSubresource(hostClient genericclient.Client, typeConfig typeconfig.Interface, limitedScope bool) (*ScaleSubresource, error) {
+	targetType := typeConfig.GetTargetType()
+	name := schema.GroupResource{Group: targetType.Group, Resource: targetType.Name}.String()
+	if scale := BuiltinScaleSubresource(name); scale != nil {
+		return scale, nil
+	}
+	if targetType.Group == "" || limitedScope {
+		return nil, nil
+	}
+
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(apiextv1.SchemeGroupVersion.String())
+	obj.SetKind("CustomResourceDefinition")
+	err := hostClient.Get(context.Background(), obj, "", name)
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to retrieve the CustomResourceDefinition %q", name)
+	}
+	crd := &apiextv1.CustomResourceDefinition{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, crd); err != nil {
+		return nil, errors.Wrapf(err, "Failed to convert the CustomResourceDefinition %q", name)
+	}
+
+	for _, version := range crd.Spec.Versions {
+		if version.Name != targetType.Version {
+			continue
+		}
+		if version.Subresources == nil || version.Subresources.Scale == nil {
+			return nil, nil
+		}
+		scale := version.Subresources.Scale
+		if scale.LabelSelectorPath == nil {
+			// Replicas can only be scheduled if the pods of the
+			// target can be determined.
+			return nil, nil
+		}
+		return &ScaleSubresource{
+			SpecReplicasPath:   scale.SpecReplicasPath,
+			StatusReplicasPath: scale.StatusReplicasPath,
+			LabelSelectorPath:  *scale.LabelSelectorPath,
+		}, nil
+	}
+	return nil, nil
+}
+
+// ReplicasOverridePath returns the path of the desired number of
+// replicas in the form of a cluster override.
+func (s *ScaleSubresource) ReplicasOverridePath() string {
+	return "/" + strings.Join(fieldPath(s.SpecReplicasPath), "/")
+}
+
+// Replicas returns the desired and running number of replicas of the
+// given target object.
+func (s *ScaleSubresource) Replicas(obj *unstructured.Unstructured) (replicas, statusReplicas int64, err error) {
+	replicas, _, err = unstructured.NestedInt64(obj.Object, fieldPath(s.SpecReplicasPath)...)
+	if err != nil {
+		return 0, 0, errors.Wrapf(err, "Error retrieving %q field", s.SpecReplicasPath)
+	}
+	statusReplicas, _, err = unstructured.NestedInt64(obj.Object, fieldPath(s.StatusReplicasPath)...)
+	if err != nil {
+		return 0, 0, errors.Wrapf(err, "Error retrieving %q field", s.StatusReplicasPath)
+	}
+	return replicas, statusReplicas, nil
+}
+
+// Selector returns the selector of the pods of the given target
+// object.
+func (s *ScaleSubresource) Selector(obj *unstructured.Unstructured) (labels.Selector, error) {
+	value, ok, err := unstructured.NestedFieldNoCopy(obj.Object, fieldPath(s.LabelSelectorPath)...)
+	if err != nil {
+		return nil, errors.Wrap(err, "error retrieving selector from object")
+	}
+	if !ok {
+		return nil, errors.New("missing selector on object")
+	}
+	switch typedValue := value.(type) {
+	case string:
+		selector, err := labels.Parse(typedValue)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error parsing selector %q", typedValue)
+		}
+		return selector, nil
+	case map[string]interface{}:
+		labelSelector := &metav1.LabelSelector{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(typedValue, labelSelector); err != nil {
+			return nil, errors.Wrap(err, "error converting selector")
+		}
+		return metav1.LabelSelectorAsSelector(labelSelector)
+	}
+	return nil, errors.Errorf("selector at %q has unexpected type %T", s.LabelSelectorPath, value)
+}
+
+// fieldPath returns the fields of a path of the form .spec.replicas.
+func fieldPath(path string) []string {
+	return strings.Split(strings.TrimPrefix(path, "."), ".")
+}
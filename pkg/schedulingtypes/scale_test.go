@@ -0,0 +1,108 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schedulingtypes
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestScaleSubresource(t *testing.T) {
+	testCases := map[string]struct {
+		scale                  *ScaleSubresource
+		obj                    map[string]interface{}
+		expectedPath           string
+		expectedReplicas       int64
+		expectedStatusReplicas int64
+		expectedSelector       string
+	}{
+		"Built-in type with a label selector": {
+			scale: BuiltinScaleSubresource("statefulsets.apps"),
+			obj: map[string]interface{}{
+				"spec": map[string]interface{}{
+					"replicas": int64(3),
+					"selector": map[string]interface{}{
+						"matchLabels": map[string]interface{}{"app": "web"},
+					},
+				},
+				"status": map[string]interface{}{
+					"readyReplicas": int64(2),
+				},
+			},
+			expectedPath:           "/spec/replicas",
+			expectedReplicas:       3,
+			expectedStatusReplicas: 2,
+			expectedSelector:       "app=web",
+		},
+		"Custom resource with a serialized selector": {
+			scale: &ScaleSubresource{
+				SpecReplicasPath:   ".spec.scale.replicas",
+				StatusReplicasPath: ".status.availableReplicas",
+				LabelSelectorPath:  ".status.selector",
+			},
+			obj: map[string]interface{}{
+				"spec": map[string]interface{}{
+					"scale": map[string]interface{}{"replicas": int64(5)},
+				},
+				"status": map[string]interface{}{
+					"availableReplicas": int64(5),
+					"selector":          "app=rollout",
+				},
+			},
+			expectedPath:           "/spec/scale/replicas",
+			expectedReplicas:       5,
+			expectedStatusReplicas: 5,
+			expectedSelector:       "app=rollout",
+		},
+		"Missing replicas default to zero": {
+			scale: BuiltinScaleSubresource("deployments.apps"),
+			obj: map[string]interface{}{
+				"spec": map[string]interface{}{
+					"selector": map[string]interface{}{
+						"matchLabels": map[string]interface{}{"app": "web"},
+					},
+				},
+			},
+			expectedPath:     "/spec/replicas",
+			expectedSelector: "app=web",
+		},
+	}
+
+	for testName, tc := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			obj := &unstructured.Unstructured{Object: tc.obj}
+			if path := tc.scale.ReplicasOverridePath(); path != tc.expectedPath {
+				t.Errorf("Expected replicas path %q, got %q", tc.expectedPath, path)
+			}
+			replicas, statusReplicas, err := tc.scale.Replicas(obj)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if replicas != tc.expectedReplicas || statusReplicas != tc.expectedStatusReplicas {
+				t.Errorf("Expected replicas %d/%d, got %d/%d", tc.expectedReplicas, tc.expectedStatusReplicas, replicas, statusReplicas)
+			}
+			selector, err := tc.scale.Selector(obj)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if selector.String() != tc.expectedSelector {
+				t.Errorf("Expected selector %q, got %q", tc.expectedSelector, selector.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schedulingtypes
+
+import (
+	"fmt"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	fedschedulingv1a1 "sigs.k8s.io/kubefed/pkg/apis/scheduling/v1alpha1"
+)
+
+// Reasons of the conditions of a ReplicaSchedulingPreference.
+const (
+	ScheduledReason              = "Scheduled"
+	NoClustersReason             = "NoClusters"
+	SchedulingFailedReason       = "SchedulingFailed"
+	TargetFoundReason            = "TargetFound"
+	TargetNotFoundReason         = "TargetNotFound"
+	SufficientCapacityReason     = "SufficientCapacity"
+	ReplicasExceedCapacityReason = "ReplicasExceedCapacity"
+	ValidMetricFoundReason       = "ValidMetricFound"
+	FailedGetMetricsReason       = "FailedGetMetrics"
+)
+
+// setScheduledStatus records the given scheduling result in the status
+// of the RSP.
+func setScheduledStatus(rsp *fedschedulingv1a1.ReplicaSchedulingPreference, clusterNames []string, result *schedulingResult) {
+	rsp.Status.ObservedGeneration = rsp.Generation
+
+	rsp.Status.Clusters = append([]string{}, clusterNames...)
+	sort.Strings(rsp.Status.Clusters)
+
+	// Clusters not considered may still run replicas of the target, which
+	// are scheduled to be removed.
+	statusClusterNames := sets.New(clusterNames...).Insert(sets.List(sets.KeySet(result.replicas))...)
+	rsp.Status.ClusterStatuses = nil
+	var scheduledReplicas int64
+	scheduledClusters := 0
+	for _, clusterName := range sets.List(statusClusterNames) {
+		clusterStatus := fedschedulingv1a1.ClusterReplicaStatus{
+			ClusterName:      clusterName,
+			DesiredReplicas:  result.replicas[clusterName],
+			OverflowReplicas: result.overflow[clusterName],
+			CurrentReplicas:  result.state.replicas[clusterName],
+			ReadyReplicas:    result.state.current[clusterName],
+		}
+		if capacity, ok := result.state.estimatedCapacity[clusterName]; ok {
+			clusterStatus.EstimatedCapacity = &capacity
+		}
+		if clusterStatus.DesiredReplicas > 0 {
+			scheduledReplicas += clusterStatus.DesiredReplicas
+			scheduledClusters++
+		}
+		rsp.Status.ClusterStatuses = append(rsp.Status.ClusterStatuses, clusterStatus)
+	}
+
+	setCondition(rsp, fedschedulingv1a1.Scheduled, metav1.ConditionTrue, ScheduledReason,
+		fmt.Sprintf("Scheduled %d replicas to %d clusters", scheduledReplicas, scheduledClusters))
+
+	var planned int64
+	for _, replicas := range result.plan {
+		planned += replicas
+	}
+	if planned < int64(rsp.Spec.TotalReplicas) {
+		setCondition(rsp, fedschedulingv1a1.InsufficientCapacity, metav1.ConditionTrue, ReplicasExceedCapacityReason,
+			fmt.Sprintf("Only %d of %d replicas fit the preferences and estimated capacity of the clusters", planned, rsp.Spec.TotalReplicas))
+	} else {
+		setCondition(rsp, fedschedulingv1a1.InsufficientCapacity, metav1.ConditionFalse, SufficientCapacityReason, "")
+	}
+}
+
+// setNotScheduledStatus records in the status of the RSP that its
+// replicas were not scheduled for the given reason.
+func setNotScheduledStatus(rsp *fedschedulingv1a1.ReplicaSchedulingPreference, reason, message string) {
+	rsp.Status.ObservedGeneration = rsp.Generation
+	setCondition(rsp, fedschedulingv1a1.Scheduled, metav1.ConditionFalse, reason, message)
+}
+
+func setCondition(rsp *fedschedulingv1a1.ReplicaSchedulingPreference, conditionType string, status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&rsp.Status.Conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		ObservedGeneration: rsp.Generation,
+		Reason:             reason,
+		Message:            message,
+	})
+}
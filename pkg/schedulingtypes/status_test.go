@@ -0,0 +1,111 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schedulingtypes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	fedschedulingv1a1 "sigs.k8s.io/kubefed/pkg/apis/scheduling/v1alpha1"
+	"sigs.k8s.io/kubefed/pkg/controller/util/planner"
+)
+
+func TestSetScheduledStatus(t *testing.T) {
+	testCases := map[string]struct {
+		clusters                     map[string]fedschedulingv1a1.ClusterPreferences
+		state                        *replicaState
+		expectedClusterStatuses      []fedschedulingv1a1.ClusterReplicaStatus
+		expectedInsufficientCapacity metav1.ConditionStatus
+	}{
+		"Replicas are distributed by weight": {
+			clusters: map[string]fedschedulingv1a1.ClusterPreferences{
+				"*": {Weight: 1},
+			},
+			state: &replicaState{
+				replicas:          map[string]int64{"A": 2},
+				current:           map[string]int64{"A": 2},
+				estimatedCapacity: map[string]int64{},
+			},
+			expectedClusterStatuses: []fedschedulingv1a1.ClusterReplicaStatus{
+				{ClusterName: "A", DesiredReplicas: 2, CurrentReplicas: 2, ReadyReplicas: 2},
+				{ClusterName: "B", DesiredReplicas: 2},
+			},
+			expectedInsufficientCapacity: metav1.ConditionFalse,
+		},
+		"Replicas exceeding the maximum of clusters are not scheduled": {
+			clusters: map[string]fedschedulingv1a1.ClusterPreferences{
+				"*": {Weight: 1, MaxReplicas: ptr.To[int64](1)},
+			},
+			state: &replicaState{
+				replicas:          map[string]int64{},
+				current:           map[string]int64{},
+				estimatedCapacity: map[string]int64{},
+			},
+			expectedClusterStatuses: []fedschedulingv1a1.ClusterReplicaStatus{
+				{ClusterName: "A", DesiredReplicas: 1},
+				{ClusterName: "B", DesiredReplicas: 1},
+			},
+			expectedInsufficientCapacity: metav1.ConditionTrue,
+		},
+		"Replicas exceeding the estimated capacity of a cluster overflow": {
+			clusters: map[string]fedschedulingv1a1.ClusterPreferences{
+				"A": {Weight: 1},
+			},
+			state: &replicaState{
+				replicas:          map[string]int64{"A": 4},
+				current:           map[string]int64{"A": 1},
+				estimatedCapacity: map[string]int64{"A": 1},
+			},
+			expectedClusterStatuses: []fedschedulingv1a1.ClusterReplicaStatus{
+				{ClusterName: "A", DesiredReplicas: 4, OverflowReplicas: 3, CurrentReplicas: 4, ReadyReplicas: 1, EstimatedCapacity: ptr.To[int64](1)},
+				{ClusterName: "B"},
+			},
+			expectedInsufficientCapacity: metav1.ConditionTrue,
+		},
+	}
+
+	for testName, tc := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			rsp := &fedschedulingv1a1.ReplicaSchedulingPreference{
+				Spec: fedschedulingv1a1.ReplicaSchedulingPreferenceSpec{
+					TotalReplicas: 4,
+					Rebalance:     true,
+					Clusters:      tc.clusters,
+				},
+			}
+			clusterNames := []string{"A", "B"}
+			result, err := schedule(planner.NewPlanner(rsp), "ns/name", clusterNames, tc.state)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			setScheduledStatus(rsp, clusterNames, result)
+			assert.Equal(t, clusterNames, rsp.Status.Clusters)
+			assert.Equal(t, tc.expectedClusterStatuses, rsp.Status.ClusterStatuses)
+			assert.True(t, meta.IsStatusConditionTrue(rsp.Status.Conditions, fedschedulingv1a1.Scheduled))
+			condition := meta.FindStatusCondition(rsp.Status.Conditions, fedschedulingv1a1.InsufficientCapacity)
+			if condition == nil || condition.Status != tc.expectedInsufficientCapacity {
+				t.Fatalf("Expected condition %s to be %s, got %v", fedschedulingv1a1.InsufficientCapacity, tc.expectedInsufficientCapacity, condition)
+			}
+		})
+	}
+}
@@ -18,6 +18,7 @@ package schedulingtypes
 
 import (
 	"fmt"
+	"sync"
 )
 
 type SchedulingType struct {
@@ -26,18 +27,32 @@ type SchedulingType struct {
 }
 
 // Mapping of qualified target name (e.g. deployment.apps) targeted
-// for scheduling with scheduling type
-var typeRegistry = make(map[string]SchedulingType)
+// for scheduling with scheduling type. Target types are registered
+// dynamically as their FederatedTypeConfigs are reconciled.
+var (
+	typeRegistry     = make(map[string]SchedulingType)
+	typeRegistryLock sync.RWMutex
+)
 
 func RegisterSchedulingType(kind string, schedulingType SchedulingType) {
+	typeRegistryLock.Lock()
+	defer typeRegistryLock.Unlock()
 	existing, ok := typeRegistry[kind]
-	if ok {
+	if ok && existing.Kind != schedulingType.Kind {
 		panic(fmt.Sprintf("Kind %q is already registered for scheduling with %q", kind, existing.Kind))
 	}
 	typeRegistry[kind] = schedulingType
 }
 
+func UnregisterSchedulingType(kind string) {
+	typeRegistryLock.Lock()
+	defer typeRegistryLock.Unlock()
+	delete(typeRegistry, kind)
+}
+
 func SchedulingTypes() map[string]SchedulingType {
+	typeRegistryLock.RLock()
+	defer typeRegistryLock.RUnlock()
 	result := make(map[string]SchedulingType)
 	for key, value := range typeRegistry {
 		result[key] = value
@@ -46,6 +61,8 @@ func SchedulingTypes() map[string]SchedulingType {
 }
 
 func GetSchedulingType(kind string) *SchedulingType {
+	typeRegistryLock.RLock()
+	defer typeRegistryLock.RUnlock()
 	schedulingType, ok := typeRegistry[kind]
 	if ok {
 		return &schedulingType
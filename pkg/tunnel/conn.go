@@ -0,0 +1,85 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tunnel
+
+import (
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/httpstream"
+)
+
+// tunnelAddr is the address of both ends of a connection made through
+// the tunnel of the named cluster.
+type tunnelAddr string
+
+func (a tunnelAddr) Network() string { return "tunnel" }
+func (a tunnelAddr) String() string  { return string(a) }
+
+// conn adapts an io.ReadWriteCloser to a net.Conn. Deadlines are not
+// supported; connections through a tunnel rely on the timeouts of the
+// clients using them.
+type conn struct {
+	io.ReadWriteCloser
+	addr net.Addr
+}
+
+func (c *conn) LocalAddr() net.Addr                { return c.addr }
+func (c *conn) RemoteAddr() net.Addr               { return c.addr }
+func (c *conn) SetDeadline(_ time.Time) error      { return nil }
+func (c *conn) SetReadDeadline(_ time.Time) error  { return nil }
+func (c *conn) SetWriteDeadline(_ time.Time) error { return nil }
+
+// streamCloser closes both directions of a stream on Close, rather than
+// only the local one, and removes the stream from its connection.
+type streamCloser struct {
+	httpstream.Stream
+	connection httpstream.Connection
+	once       sync.Once
+}
+
+func (s *streamCloser) Close() error {
+	var err error
+	s.once.Do(func() {
+		err = s.Stream.Reset()
+		s.connection.RemoveStreams(s.Stream)
+	})
+	return err
+}
+
+func newStreamConn(connection httpstream.Connection, stream httpstream.Stream, clusterName string) net.Conn {
+	return &conn{
+		ReadWriteCloser: &streamCloser{Stream: stream, connection: connection},
+		addr:            tunnelAddr(clusterName),
+	}
+}
+
+// pipe copies data between a and b until either direction is closed.
+func pipe(a, b io.ReadWriter) {
+	done := make(chan struct{}, 2)
+	go func() {
+		_, _ = io.Copy(a, b)
+		done <- struct{}{}
+	}()
+	go func() {
+		_, _ = io.Copy(b, a)
+		done <- struct{}{}
+	}()
+	<-done
+}
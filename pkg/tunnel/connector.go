@@ -0,0 +1,152 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tunnel
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/apimachinery/pkg/util/httpstream/spdy"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	restclient "k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
+)
+
+const (
+	retryPeriod = 5 * time.Second
+	dialTimeout = 10 * time.Second
+)
+
+// Connector opens the tunnel of a member cluster to the tunnel server
+// of the control plane and forwards the connections made through the
+// tunnel to the API server of the member cluster.
+type Connector struct {
+	clusterName string
+
+	// URL of the tunnel of the cluster on the tunnel server
+	tunnelURL string
+
+	// Transport to the tunnel server, authenticating the connector
+	transport http.RoundTripper
+
+	// Address of the API server of the member cluster
+	apiServerAddress string
+}
+
+// NewConnector returns a connector for the named cluster that
+// connects to the tunnel server at the host of the given configuration
+// with the credentials of the configuration, which must include a
+// bearer token, and forwards connections to the given address of the
+// API server of the member cluster.
+func NewConnector(clusterName string, tunnelConfig *restclient.Config, apiServerAddress string) (*Connector, error) {
+	transport, err := restclient.TransportFor(tunnelConfig)
+	if err != nil {
+		return nil, err
+	}
+	return &Connector{
+		clusterName:      clusterName,
+		tunnelURL:        strings.TrimSuffix(tunnelConfig.Host, "/") + ClustersPath + clusterName,
+		transport:        transport,
+		apiServerAddress: apiServerAddress,
+	}, nil
+}
+
+// Run keeps the tunnel open until stopChan is closed.
+func (c *Connector) Run(stopChan <-chan struct{}) {
+	wait.Until(func() {
+		if err := c.connect(stopChan); err != nil {
+			runtime.HandleError(errors.Wrapf(err, "Tunnel of cluster %q", c.clusterName))
+		}
+	}, retryPeriod, stopChan)
+}
+
+// connect opens the tunnel and serves it until it is closed or
+// stopChan is closed.
+func (c *Connector) connect(stopChan <-chan struct{}) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.tunnelURL, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set(httpstream.HeaderConnection, httpstream.HeaderUpgrade)
+	req.Header.Set(httpstream.HeaderUpgrade, UpgradeProtocol)
+	resp, err := c.transport.RoundTrip(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to the tunnel server")
+	}
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return errors.Errorf("the tunnel server responded with %q: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	rwc, ok := resp.Body.(io.ReadWriteCloser)
+	if !ok {
+		resp.Body.Close()
+		return errors.New("the connection to the tunnel server cannot be upgraded")
+	}
+
+	// Streams may be opened before the connection is returned.
+	var connection httpstream.Connection
+	ready := make(chan struct{})
+	newStream := func(stream httpstream.Stream, replySent <-chan struct{}) error {
+		go func() {
+			<-ready
+			<-replySent
+			c.forward(connection, stream)
+		}()
+		return nil
+	}
+	connection, err = spdy.NewServerConnection(&conn{ReadWriteCloser: rwc, addr: tunnelAddr(c.clusterName)}, newStream)
+	if err != nil {
+		return errors.Wrap(err, "failed to open the tunnel")
+	}
+	close(ready)
+	klog.Infof("Opened the tunnel of cluster %q to %s", c.clusterName, c.tunnelURL)
+
+	select {
+	case <-connection.CloseChan():
+		return errors.New("the tunnel was closed")
+	case <-stopChan:
+		return connection.Close()
+	}
+}
+
+// forward forwards a connection made through the tunnel to the API
+// server.
+func (c *Connector) forward(connection httpstream.Connection, stream httpstream.Stream) {
+	tunneled := newStreamConn(connection, stream, c.clusterName)
+	defer tunneled.Close()
+
+	apiServer, err := net.DialTimeout("tcp", c.apiServerAddress, dialTimeout)
+	if err != nil {
+		runtime.HandleError(errors.Wrapf(err, "Failed to dial the API server of cluster %q", c.clusterName))
+		return
+	}
+	defer apiServer.Close()
+
+	pipe(tunneled, apiServer)
+}
@@ -0,0 +1,284 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tunnel implements reverse tunnels that allow the control
+// plane to access the API server of a member cluster it cannot dial.
+// A connector running in the member cluster opens a connection to the
+// tunnel server of the control plane, over which the control plane
+// then opens a stream for each connection it makes to the API server
+// of the member cluster.
+package tunnel
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/apimachinery/pkg/util/httpstream/spdy"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	kubeclientset "k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+
+	fedv1b1 "sigs.k8s.io/kubefed/pkg/apis/core/v1beta1"
+)
+
+const (
+	// UpgradeProtocol is the protocol a connector upgrades its
+	// connection to the tunnel server to.
+	UpgradeProtocol = "kubefed-tunnel"
+
+	// ClustersPath is the path under which the tunnel server accepts
+	// the connection of the connector of the cluster named by the
+	// remainder of the path.
+	ClustersPath = "/clusters/"
+
+	// TunnelSubresource is the subresource of a KubeFedCluster that the
+	// connector of the cluster must be allowed to update.
+	TunnelSubresource = "tunnel"
+
+	// Keep idle tunnels open through load balancers.
+	pingPeriod = 30 * time.Second
+)
+
+var defaultRegistry = newRegistry()
+
+// Dialer returns a function that dials the API server of the named
+// cluster through its tunnel, ignoring the given address.
+func Dialer(clusterName string) func(ctx context.Context, network, address string) (net.Conn, error) {
+	return func(ctx context.Context, network, address string) (net.Conn, error) {
+		return defaultRegistry.dial(clusterName)
+	}
+}
+
+// Connected returns whether the connector of the named cluster has
+// opened a tunnel to the tunnel server of this process.
+func Connected(clusterName string) bool {
+	return defaultRegistry.connected(clusterName)
+}
+
+// authorizeFunc returns whether the given bearer token allows opening
+// the tunnel of the named cluster.
+type authorizeFunc func(ctx context.Context, token, clusterName string) (bool, error)
+
+// Server accepts the tunnels opened by the connectors of member
+// clusters. Only the controllers of the leading replica of the control
+// plane use tunnels, so a server refuses tunnels until SetLeading is
+// called and connectors retry until they reach the leader.
+type Server struct {
+	registry  *registry
+	authorize authorizeFunc
+	leading   atomic.Bool
+}
+
+// NewServer returns a tunnel server that authenticates connectors by
+// their bearer token and authorizes them to open the tunnel of a
+// cluster if they are allowed to update the tunnel subresource of its
+// KubeFedCluster in the KubeFed namespace.
+func NewServer(kubeClient kubeclientset.Interface, kubeFedNamespace string) *Server {
+	return &Server{
+		registry:  defaultRegistry,
+		authorize: reviewAuthorizer(kubeClient, kubeFedNamespace),
+	}
+}
+
+// SetLeading marks the replica of the server as the leader, after
+// which tunnels are accepted. Leadership is not given up by a running
+// replica, so there is no way to unset it.
+func (s *Server) SetLeading() {
+	s.leading.Store(true)
+}
+
+// ListenAndServeTLS serves the tunnel server on the given address.
+// HTTP/2 is disabled since it does not support upgrading connections.
+func (s *Server) ListenAndServeTLS(address, certFile, keyFile string) error {
+	server := &http.Server{
+		Addr:              address,
+		Handler:           s,
+		ReadHeaderTimeout: 30 * time.Second,
+		TLSNextProto:      map[string]func(*http.Server, *tls.Conn, http.Handler){},
+	}
+	return server.ListenAndServeTLS(certFile, keyFile)
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	clusterName := strings.TrimPrefix(req.URL.Path, ClustersPath)
+	if !strings.HasPrefix(req.URL.Path, ClustersPath) || clusterName == "" || strings.Contains(clusterName, "/") {
+		http.NotFound(w, req)
+		return
+	}
+	if !httpstream.IsUpgradeRequest(req) || !strings.EqualFold(req.Header.Get(httpstream.HeaderUpgrade), UpgradeProtocol) {
+		http.Error(w, "the connection must be upgraded to "+UpgradeProtocol, http.StatusBadRequest)
+		return
+	}
+	if !s.leading.Load() {
+		http.Error(w, "this replica of the control plane is not the leader", http.StatusServiceUnavailable)
+		return
+	}
+
+	token, found := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+	if !found || token == "" {
+		http.Error(w, "a bearer token is required", http.StatusUnauthorized)
+		return
+	}
+	allowed, err := s.authorize(req.Context(), token, clusterName)
+	if err != nil {
+		runtime.HandleError(errors.Wrapf(err, "Failed to authorize the tunnel of cluster %q", clusterName))
+		http.Error(w, "failed to authorize the tunnel", http.StatusInternalServerError)
+		return
+	}
+	if !allowed {
+		http.Error(w, "opening the tunnel of cluster "+clusterName+" is not allowed", http.StatusForbidden)
+		return
+	}
+
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "unable to upgrade the connection", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set(httpstream.HeaderConnection, httpstream.HeaderUpgrade)
+	w.Header().Set(httpstream.HeaderUpgrade, UpgradeProtocol)
+	w.WriteHeader(http.StatusSwitchingProtocols)
+	netConn, bufrw, err := hijacker.Hijack()
+	if err != nil {
+		runtime.HandleError(errors.Wrapf(err, "Failed to upgrade the connection of the connector of cluster %q", clusterName))
+		return
+	}
+	connection, err := spdy.NewClientConnectionWithPings(&bufferedConn{Conn: netConn, reader: bufrw.Reader}, pingPeriod)
+	if err != nil {
+		runtime.HandleError(errors.Wrapf(err, "Failed to open the tunnel of cluster %q", clusterName))
+		return
+	}
+	klog.Infof("Opened the tunnel of cluster %q from %s", clusterName, netConn.RemoteAddr())
+	s.registry.add(clusterName, connection)
+}
+
+// bufferedConn is a hijacked connection whose reads first return the
+// data buffered by the HTTP server before the connection was hijacked.
+type bufferedConn struct {
+	net.Conn
+	reader *bufio.Reader
+}
+
+func (c *bufferedConn) Read(b []byte) (int, error) {
+	return c.reader.Read(b)
+}
+
+func reviewAuthorizer(kubeClient kubeclientset.Interface, kubeFedNamespace string) authorizeFunc {
+	return func(ctx context.Context, token, clusterName string) (bool, error) {
+		tokenReview, err := kubeClient.AuthenticationV1().TokenReviews().Create(ctx, &authenticationv1.TokenReview{
+			Spec: authenticationv1.TokenReviewSpec{Token: token},
+		}, metav1.CreateOptions{})
+		if err != nil {
+			return false, errors.Wrap(err, "failed to review the token")
+		}
+		if !tokenReview.Status.Authenticated {
+			return false, nil
+		}
+
+		user := tokenReview.Status.User
+		extra := make(map[string]authorizationv1.ExtraValue, len(user.Extra))
+		for key, value := range user.Extra {
+			extra[key] = authorizationv1.ExtraValue(value)
+		}
+		accessReview, err := kubeClient.AuthorizationV1().SubjectAccessReviews().Create(ctx, &authorizationv1.SubjectAccessReview{
+			Spec: authorizationv1.SubjectAccessReviewSpec{
+				ResourceAttributes: &authorizationv1.ResourceAttributes{
+					Namespace:   kubeFedNamespace,
+					Verb:        "update",
+					Group:       fedv1b1.SchemeGroupVersion.Group,
+					Resource:    "kubefedclusters",
+					Subresource: TunnelSubresource,
+					Name:        clusterName,
+				},
+				User:   user.Username,
+				Groups: user.Groups,
+				UID:    user.UID,
+				Extra:  extra,
+			},
+		}, metav1.CreateOptions{})
+		if err != nil {
+			return false, errors.Wrap(err, "failed to review the access of the connector")
+		}
+		return accessReview.Status.Allowed, nil
+	}
+}
+
+// registry holds the tunnels opened by the connectors of member
+// clusters.
+type registry struct {
+	sync.RWMutex
+	connections map[string]httpstream.Connection
+}
+
+func newRegistry() *registry {
+	return &registry{connections: make(map[string]httpstream.Connection)}
+}
+
+// add registers the tunnel of the named cluster, replacing and closing
+// any previous tunnel, until the tunnel is closed.
+func (r *registry) add(clusterName string, connection httpstream.Connection) {
+	r.Lock()
+	previous := r.connections[clusterName]
+	r.connections[clusterName] = connection
+	r.Unlock()
+	if previous != nil {
+		previous.Close()
+	}
+
+	go func() {
+		<-connection.CloseChan()
+		klog.Infof("The tunnel of cluster %q was closed", clusterName)
+		r.Lock()
+		defer r.Unlock()
+		if r.connections[clusterName] == connection {
+			delete(r.connections, clusterName)
+		}
+	}()
+}
+
+func (r *registry) connected(clusterName string) bool {
+	r.RLock()
+	defer r.RUnlock()
+	_, ok := r.connections[clusterName]
+	return ok
+}
+
+func (r *registry) dial(clusterName string) (net.Conn, error) {
+	r.RLock()
+	connection, ok := r.connections[clusterName]
+	r.RUnlock()
+	if !ok {
+		return nil, errors.Errorf("the tunnel of cluster %q is not connected", clusterName)
+	}
+	stream, err := connection.CreateStream(http.Header{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open a stream through the tunnel of cluster %q", clusterName)
+	}
+	return newStreamConn(connection, stream, clusterName), nil
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tunnel
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	restclient "k8s.io/client-go/rest"
+)
+
+func TestTunnel(t *testing.T) {
+	// Stands in for the API server of the member cluster.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer listener.Close()
+	go func() {
+		for {
+			c, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer c.Close()
+				_, _ = io.Copy(c, c)
+			}()
+		}
+	}()
+
+	testCases := map[string]struct {
+		token          string
+		clusterName    string
+		notLeading     bool
+		expectedStatus string
+	}{
+		"connector with an allowed token opens the tunnel": {
+			token:       "allowed",
+			clusterName: "cluster1",
+		},
+		"connector with a token not allowed for the cluster is rejected": {
+			token:          "allowed",
+			clusterName:    "cluster2",
+			expectedStatus: "403",
+		},
+		"connector with an unknown token is rejected": {
+			token:          "unknown",
+			clusterName:    "cluster1",
+			expectedStatus: "403",
+		},
+		"connector of a replica that is not leading is refused": {
+			token:          "allowed",
+			clusterName:    "cluster1",
+			notLeading:     true,
+			expectedStatus: "503",
+		},
+	}
+	for testName, tc := range testCases {
+		t.Run(testName, func(t *testing.T) {
+			server := &Server{
+				registry: newRegistry(),
+				authorize: func(_ context.Context, token, clusterName string) (bool, error) {
+					return token == "allowed" && clusterName == "cluster1", nil
+				},
+			}
+			if !tc.notLeading {
+				server.SetLeading()
+			}
+			httpServer := httptest.NewServer(server)
+			defer httpServer.Close()
+
+			connector, err := NewConnector(tc.clusterName, &restclient.Config{
+				Host:        httpServer.URL,
+				BearerToken: tc.token,
+			}, listener.Addr().String())
+			if err != nil {
+				t.Fatalf("Failed to create connector: %v", err)
+			}
+			stopChan := make(chan struct{})
+			defer close(stopChan)
+			errChan := make(chan error, 1)
+			go func() { errChan <- connector.connect(stopChan) }()
+
+			if tc.expectedStatus != "" {
+				err := <-errChan
+				if err == nil || !strings.Contains(err.Error(), tc.expectedStatus) {
+					t.Fatalf("Expected the tunnel to be refused with %s, got %v", tc.expectedStatus, err)
+				}
+				if server.registry.connected(tc.clusterName) {
+					t.Fatalf("Expected cluster %q not to be connected", tc.clusterName)
+				}
+				return
+			}
+
+			err = wait.PollUntilContextTimeout(context.Background(), 10*time.Millisecond, wait.ForeverTestTimeout, true,
+				func(context.Context) (bool, error) {
+					return server.registry.connected(tc.clusterName), nil
+				})
+			if err != nil {
+				t.Fatalf("Timed out waiting for cluster %q to be connected", tc.clusterName)
+			}
+
+			// Connections through the tunnel are independent.
+			for i := 0; i < 2; i++ {
+				c, err := server.registry.dial(tc.clusterName)
+				if err != nil {
+					t.Fatalf("Failed to dial through the tunnel: %v", err)
+				}
+				message := "hello " + strings.Repeat("!", i)
+				if _, err := c.Write([]byte(message)); err != nil {
+					t.Fatalf("Failed to write through the tunnel: %v", err)
+				}
+				reply := make([]byte, len(message))
+				if _, err := io.ReadFull(c, reply); err != nil {
+					t.Fatalf("Failed to read through the tunnel: %v", err)
+				}
+				if string(reply) != message {
+					t.Fatalf("Expected %q, got %q", message, reply)
+				}
+				c.Close()
+			}
+		})
+	}
+}
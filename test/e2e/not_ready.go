@@ -88,13 +88,13 @@ var _ = Describe("[NOT_READY] Simulated not-ready nodes", func() {
 		hostConfig := f.KubeConfig()
 
 		unhealthyCluster := "unhealthy"
-		_, err = kubefedctl.TestOnlyJoinClusterForNamespace(hostConfig, hostConfig, hostNamespace, unhealthyCluster, hostNamespace, unhealthyCluster, "", apiextv1.NamespaceScoped, false, false)
+		_, err = kubefedctl.TestOnlyJoinClusterForNamespace(hostConfig, hostConfig, hostNamespace, unhealthyCluster, hostNamespace, unhealthyCluster, "", apiextv1.NamespaceScoped, false, false, false)
 		if err != nil {
 			tl.Fatalf("Error joining unhealthy cluster: %v", err)
 		}
 
 		healthyCluster := "healthy"
-		_, err = kubefedctl.TestOnlyJoinClusterForNamespace(hostConfig, hostConfig, hostNamespace, healthyCluster, hostNamespace, healthyCluster, "", apiextv1.NamespaceScoped, false, false)
+		_, err = kubefedctl.TestOnlyJoinClusterForNamespace(hostConfig, hostConfig, hostNamespace, healthyCluster, hostNamespace, healthyCluster, "", apiextv1.NamespaceScoped, false, false, false)
 		if err != nil {
 			tl.Fatalf("Error joining healthy cluster: %v", err)
 		}
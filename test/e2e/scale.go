@@ -109,7 +109,7 @@ var _ = Describe("Simulated Scale", func() {
 			_, err := kubefedctl.TestOnlyJoinClusterForNamespace(
 				hostConfig, hostConfig, hostNamespace,
 				joiningNamespace, hostCluster, memberCluster,
-				"", apiextv1.NamespaceScoped, false, false)
+				"", apiextv1.NamespaceScoped, false, false, false)
 
 			defer func() {
 				framework.DeleteNamespace(client, joiningNamespace)
@@ -129,13 +129,13 @@ var _ = Describe("Simulated Scale", func() {
 			_, errJoin := kubefedctl.TestOnlyJoinClusterForNamespace(
 				hostConfig, hostConfig, hostNamespace,
 				joiningNamespace, hostCluster, memberCluster,
-				secretName, apiextv1.NamespaceScoped, false, false)
+				secretName, apiextv1.NamespaceScoped, false, false, false)
 
 			// rejoin cluster, and secret not change
 			_, errReJoin := kubefedctl.TestOnlyJoinClusterForNamespace(
 				hostConfig, hostConfig, hostNamespace,
 				joiningNamespace, hostCluster, memberCluster,
-				secretName, apiextv1.NamespaceScoped, false, false)
+				secretName, apiextv1.NamespaceScoped, false, false, false)
 
 			// serviceaccount token recreate
 			saName := kfutil.ClusterServiceAccountName(memberCluster, hostCluster)
@@ -185,7 +185,7 @@ var _ = Describe("Simulated Scale", func() {
 			_, errReJoinAfterChange := kubefedctl.TestOnlyJoinClusterForNamespace(
 				hostConfig, hostConfig, hostNamespace,
 				joiningNamespace, hostCluster, memberCluster,
-				secretName, apiextv1.NamespaceScoped, false, false)
+				secretName, apiextv1.NamespaceScoped, false, false, false)
 
 			defer func() {
 				framework.DeleteNamespace(client, joiningNamespace)
@@ -211,12 +211,12 @@ var _ = Describe("Simulated Scale", func() {
 			_, errJoin := kubefedctl.TestOnlyJoinClusterForNamespace(
 				hostConfig, hostConfig, hostNamespace,
 				joiningNamespace, hostCluster, memberCluster,
-				secretName, apiextv1.NamespaceScoped, false, true)
+				secretName, apiextv1.NamespaceScoped, false, false, true)
 
 			_, errReJoin := kubefedctl.TestOnlyJoinClusterForNamespace(
 				hostConfig, hostConfig, hostNamespace,
 				joiningNamespace, hostCluster, memberCluster,
-				secretName, apiextv1.NamespaceScoped, false, true)
+				secretName, apiextv1.NamespaceScoped, false, false, true)
 
 			if errJoin != nil {
 				tl.Fatalf("Error joining cluster %s: %v", memberCluster, err)
@@ -99,11 +99,13 @@ var _ = Describe("Scheduler", func() {
 
 func GetSchedulingTypes(tl common.TestLogger) map[string]schedulingtypes.SchedulerFactory {
 	schedulingTypes := make(map[string]schedulingtypes.SchedulerFactory)
-	for typeConfigName, schedulingType := range schedulingtypes.SchedulingTypes() {
-		if schedulingType.Kind != schedulingtypes.RSPKind {
+	// Scheduling types are registered dynamically, so the built-in
+	// scalable types enabled for the tests are scheduled.
+	for _, enableTypeDirective := range framework.LoadEnableTypeDirectives(tl) {
+		if schedulingtypes.BuiltinScaleSubresource(enableTypeDirective.Name) == nil {
 			continue
 		}
-		schedulingTypes[typeConfigName] = schedulingType.SchedulerFactory
+		schedulingTypes[enableTypeDirective.Name] = schedulingtypes.RSPSchedulingType.SchedulerFactory
 	}
 	if len(schedulingTypes) == 0 {
 		tl.Fatalf("No target types found for scheduling type %q", schedulingtypes.RSPKind)
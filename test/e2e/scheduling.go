@@ -298,6 +298,7 @@ func waitForMatchingFederatedObject(tl common.TestLogger, typeConfig typeconfig.
 	}
 
 	expected64 := int32MapToInt64(expected32)
+	replicasPath := schedulingtypes.BuiltinScaleSubresource(typeConfig.GetObjectMeta().Name).ReplicasOverridePath()
 
 	return wait.PollUntilContextTimeout(context.Background(), framework.PollInterval, framework.TestContext.SingleCallTimeout, true, func(ctx context.Context) (bool, error) {
 		fedObject, err := client.Resources(namespace).Get(ctx, name, metav1.GetOptions{})
@@ -322,7 +323,7 @@ func waitForMatchingFederatedObject(tl common.TestLogger, typeConfig typeconfig.
 			tl.Errorf("Error reading cluster overrides for %s %s/%s: %v", kind, namespace, name, err)
 			return false, nil
 		}
-		return !schedulingtypes.OverrideUpdateNeeded(overridesMap, expected64), nil
+		return !schedulingtypes.OverrideUpdateNeeded(overridesMap, expected64, replicasPath), nil
 	})
 }
 